@@ -13,6 +13,7 @@ import (
 const (
 	secretKey = "something secret here" // token sign key
 	adminRole = "superuser"             // token subject
+	userClaim = "name"                  // token claim with the user name
 )
 
 type AAA struct {
@@ -52,8 +53,9 @@ func (a AAA) Login(name, password string) (string, error) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"sub": adminRole,
-		"exp": time.Now().Add(a.tokenTTL).Unix(),
+		"sub":     adminRole,
+		userClaim: name,
+		"exp":     time.Now().Add(a.tokenTTL).Unix(),
 	})
 
 	tokenString, err := token.SignedString([]byte(secretKey))
@@ -65,8 +67,11 @@ func (a AAA) Login(name, password string) (string, error) {
 	return tokenString, nil
 }
 
-func (a AAA) Verify(tokenString string) error {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+// Verify checks the token and returns the name of the user it was issued to,
+// empty for tokens issued before the name was put into them.
+func (a AAA) Verify(tokenString string) (string, error) {
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method")
 		}
@@ -75,18 +80,18 @@ func (a AAA) Verify(tokenString string) error {
 
 	if err != nil || !token.Valid {
 		a.log.Warn("invalid token", "error", err)
-		return errors.New("invalid token")
+		return "", errors.New("invalid token")
 	}
 
 	subject, err := token.Claims.GetSubject()
 	if err != nil {
 		a.log.Error("no subject", "error", err)
-		return errors.New("incomplete token")
+		return "", errors.New("incomplete token")
 	}
 	if subject != adminRole {
 		a.log.Error("not admin", "subject", subject)
-		return errors.New("not authorized")
+		return "", errors.New("not authorized")
 	}
-	return nil
-
+	user, _ := claims[userClaim].(string)
+	return user, nil
 }
@@ -89,12 +89,13 @@ func TestVerify(t *testing.T) {
 	token, _ := service.Login("admin", "password")
 
 	t.Run("valid token", func(t *testing.T) {
-		err := service.Verify(token)
+		user, err := service.Verify(token)
 		assert.NoError(t, err)
+		assert.Equal(t, "admin", user)
 	})
 
 	t.Run("invalid token", func(t *testing.T) {
-		err := service.Verify("invalid token")
+		_, err := service.Verify("invalid token")
 		assert.Error(t, err)
 		assert.Equal(t, "invalid token", err.Error())
 	})
@@ -106,7 +107,7 @@ func TestVerify(t *testing.T) {
 		})
 		tokenString, _ := expiredToken.SignedString([]byte(secretKey))
 
-		err := service.Verify(tokenString)
+		_, err := service.Verify(tokenString)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid token", err.Error())
 	})
@@ -118,7 +119,7 @@ func TestVerify(t *testing.T) {
 		})
 		tokenString, _ := wrongSubToken.SignedString([]byte(secretKey))
 
-		err := service.Verify(tokenString)
+		_, err := service.Verify(tokenString)
 		assert.Error(t, err)
 		assert.Equal(t, "not authorized", err.Error())
 	})
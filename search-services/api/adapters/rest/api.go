@@ -1,16 +1,20 @@
 package rest
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"io"
 
+	"yadro.com/course/api/adapters/rest/middleware"
 	"yadro.com/course/api/core"
 )
 
@@ -76,6 +80,11 @@ func NewPingHandler(log *slog.Logger, pingers map[string]core.Pinger) http.Handl
 type WordsResponse struct {
 	Words []string `json:"words"`
 	Total int      `json:"total"`
+	Lang  string   `json:"lang"`
+	Mode  string   `json:"mode"`
+	// Tokens and Terms are set for detail=true requests
+	Tokens []core.Token         `json:"tokens,omitempty"`
+	Terms  []core.TermFrequency `json:"terms,omitempty"`
 }
 
 func NewWordsHandler(log *slog.Logger, norm core.Normalizer) http.HandlerFunc {
@@ -87,7 +96,31 @@ func NewWordsHandler(log *slog.Logger, norm core.Normalizer) http.HandlerFunc {
 			return
 		}
 
-		words, err := norm.Norm(r.Context(), phrase)
+		detail := false
+		if value := r.URL.Query().Get("detail"); value != "" {
+			var err error
+			if detail, err = strconv.ParseBool(value); err != nil {
+				log.Error("invalid detail", "detail", value)
+				http.Error(w, fmt.Sprintf("invalid detail: %q", value), http.StatusBadRequest)
+				return
+			}
+		}
+
+		lang, mode := r.URL.Query().Get("lang"), r.URL.Query().Get("mode")
+		var reply WordsResponse
+		var err error
+		if detail {
+			reply, err = normDetailed(r.Context(), norm, phrase, lang, mode)
+		} else {
+			var normalized core.Normalized
+			normalized, err = norm.Norm(r.Context(), phrase, lang, mode)
+			reply = WordsResponse{
+				Words: normalized.Words,
+				Total: len(normalized.Words),
+				Lang:  normalized.Lang,
+				Mode:  normalized.Mode,
+			}
+		}
 		if err != nil {
 			log.Error("bad reply from normalizer", "error", err)
 			if errors.Is(err, core.ErrBadArguments) {
@@ -98,11 +131,6 @@ func NewWordsHandler(log *slog.Logger, norm core.Normalizer) http.HandlerFunc {
 			return
 		}
 
-		reply := WordsResponse{
-			Words: words,
-			Total: len(words),
-		}
-
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(reply); err != nil {
 			log.Error("cannot encode reply", "error", err)
@@ -111,6 +139,27 @@ func NewWordsHandler(log *slog.Logger, norm core.Normalizer) http.HandlerFunc {
 	}
 }
 
+// normDetailed builds the detailed words reply, the words being the terms of
+// the phrase.
+func normDetailed(ctx context.Context, norm core.Normalizer, phrase, lang, mode string) (WordsResponse, error) {
+	detailed, err := norm.NormDetailed(ctx, phrase, lang, mode)
+	if err != nil {
+		return WordsResponse{}, err
+	}
+	words := make([]string, len(detailed.Terms))
+	for i, t := range detailed.Terms {
+		words[i] = t.Term
+	}
+	return WordsResponse{
+		Words:  words,
+		Total:  len(words),
+		Lang:   detailed.Lang,
+		Mode:   detailed.Mode,
+		Tokens: detailed.Tokens,
+		Terms:  detailed.Terms,
+	}, nil
+}
+
 type UpdateStatsResponse struct {
 	WordsTotal    int `json:"words_total"`
 	WordsUnique   int `json:"words_unique"`
@@ -209,8 +258,14 @@ func NewDropHandler(log *slog.Logger, updater core.Updater) http.HandlerFunc {
 }
 
 type SearchResponse struct {
-	Comics []core.Comics `json:"comics"`
-	Total  int32         `json:"total"`
+	Comics     []core.Comics `json:"comics"`
+	Total      int32         `json:"total"`
+	Suggestion string        `json:"suggestion,omitempty"`
+	// Corrected is the phrase the results were found for when the requested
+	// one found nothing
+	Corrected string `json:"corrected,omitempty"`
+	// Explain is set for explain=true requests
+	Explain *core.QueryExplanation `json:"explain,omitempty"`
 }
 
 func NewSearchHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
@@ -218,17 +273,9 @@ func NewSearchHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
 		ctx := r.Context()
 
 		phrase := r.URL.Query().Get("phrase")
-		limitStr := r.URL.Query().Get("limit")
-
-		limit := 10
-		if limitStr != "" {
-			limitInt, err := strconv.Atoi(limitStr)
-			if err != nil || limitInt < 1 {
-				log.Warn("invalid limit", "limit", limitStr)
-				http.Error(w, "invalid limit", http.StatusBadRequest)
-				return
-			}
-			limit = limitInt
+		limit, ok := parseLimit(w, r, log, 10)
+		if !ok {
+			return
 		}
 
 		if phrase == "" {
@@ -237,7 +284,23 @@ func NewSearchHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
 			return
 		}
 
-		comics, total, err := client.Search(ctx, phrase, int32(limit))
+		opts, err := parseSearchOptions(r)
+		if err != nil {
+			log.Warn("invalid search options", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if notModified(w, r, log, client) {
+			return
+		}
+
+		result, err := client.Search(ctx, phrase, int32(limit), opts)
+		var corrected string
+		if err == nil {
+			result, corrected, err = autoCorrect(ctx, log, client, phrase, result, func(phrase string) (core.SearchResult, error) {
+				return client.Search(ctx, phrase, int32(limit), opts)
+			})
+		}
 		if err != nil {
 			if errors.Is(err, core.ErrBadArguments) {
 				log.Warn("bad request", "error", err)
@@ -250,10 +313,14 @@ func NewSearchHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
 		}
 
 		response := SearchResponse{
-			Comics: comics,
-			Total:  total,
+			Comics:     result.Comics,
+			Total:      result.Total,
+			Suggestion: result.Suggestion,
+			Corrected:  corrected,
+			Explain:    result.Explain,
 		}
 
+		setETag(w, result.Generation)
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(response); err != nil {
 			log.Error("failed to encode response", "error", err)
@@ -263,8 +330,14 @@ func NewSearchHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
 }
 
 type IndexSearchResponse struct {
-	Comics []core.Comics `json:"comics"`
-	Total  int32         `json:"total"`
+	Comics     []core.Comics `json:"comics"`
+	Total      int32         `json:"total"`
+	Suggestion string        `json:"suggestion,omitempty"`
+	// Corrected is the phrase the results were found for when the requested
+	// one found nothing
+	Corrected string `json:"corrected,omitempty"`
+	// Explain is set for explain=true requests
+	Explain *core.QueryExplanation `json:"explain,omitempty"`
 }
 
 func NewSearchIndexHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
@@ -272,17 +345,9 @@ func NewSearchIndexHandler(log *slog.Logger, client core.Searcher) http.HandlerF
 		ctx := r.Context()
 
 		phrase := r.URL.Query().Get("phrase")
-		limitStr := r.URL.Query().Get("limit")
-
-		limit := 10
-		if limitStr != "" {
-			limitInt, err := strconv.Atoi(limitStr)
-			if err != nil || limitInt < 1 {
-				log.Warn("invalid limit", "limit", limitStr)
-				http.Error(w, "invalid limit", http.StatusBadRequest)
-				return
-			}
-			limit = limitInt
+		limit, ok := parseLimit(w, r, log, 10)
+		if !ok {
+			return
 		}
 
 		if phrase == "" {
@@ -291,7 +356,23 @@ func NewSearchIndexHandler(log *slog.Logger, client core.Searcher) http.HandlerF
 			return
 		}
 
-		comics, total, err := client.IndexSearch(ctx, phrase, int32(limit))
+		opts, err := parseSearchOptions(r)
+		if err != nil {
+			log.Warn("invalid search options", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if notModified(w, r, log, client) {
+			return
+		}
+
+		result, err := client.IndexSearch(ctx, phrase, int32(limit), opts)
+		var corrected string
+		if err == nil {
+			result, corrected, err = autoCorrect(ctx, log, client, phrase, result, func(phrase string) (core.SearchResult, error) {
+				return client.IndexSearch(ctx, phrase, int32(limit), opts)
+			})
+		}
 		if err != nil {
 			if errors.Is(err, core.ErrBadArguments) {
 				log.Warn("bad request", "error", err)
@@ -304,10 +385,14 @@ func NewSearchIndexHandler(log *slog.Logger, client core.Searcher) http.HandlerF
 		}
 
 		response := IndexSearchResponse{
-			Comics: comics,
-			Total:  total,
+			Comics:     result.Comics,
+			Total:      result.Total,
+			Suggestion: result.Suggestion,
+			Corrected:  corrected,
+			Explain:    result.Explain,
 		}
 
+		setETag(w, result.Generation)
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(response); err != nil {
 			log.Error("failed to encode response", "error", err)
@@ -316,6 +401,510 @@ func NewSearchIndexHandler(log *slog.Logger, client core.Searcher) http.HandlerF
 	}
 }
 
+// autoCorrect repeats a search that found nothing with the phrase corrected
+// by the search service. It returns the corrected phrase if that finds
+// anything and the original result otherwise, also when the correction
+// fails, as a failed correction is no reason to fail the search.
+func autoCorrect(ctx context.Context, log *slog.Logger, client core.Searcher, phrase string, result core.SearchResult,
+	search func(phrase string) (core.SearchResult, error)) (core.SearchResult, string, error) {
+	if result.Total > 0 {
+		return result, "", nil
+	}
+	corrected, err := client.Correct(ctx, phrase)
+	if err != nil {
+		log.Warn("failed to correct phrase", "phrase", phrase, "error", err)
+		return result, "", nil
+	}
+	if corrected.Phrase == phrase {
+		return result, "", nil
+	}
+
+	found, err := search(corrected.Phrase)
+	if err != nil {
+		return core.SearchResult{}, "", err
+	}
+	if found.Total == 0 {
+		return result, "", nil
+	}
+	log.Debug("search corrected", "phrase", phrase, "corrected", corrected.Phrase)
+	return found, corrected.Phrase, nil
+}
+
+func NewCorrectHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		phrase := r.URL.Query().Get("phrase")
+		if strings.TrimSpace(phrase) == "" {
+			log.Warn("phrase is required")
+			http.Error(w, "phrase is required", http.StatusBadRequest)
+			return
+		}
+
+		corrected, err := client.Correct(r.Context(), phrase)
+		if err != nil {
+			if errors.Is(err, core.ErrBadArguments) {
+				log.Warn("bad request", "error", err)
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			log.Error("correct failed", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(corrected); err != nil {
+			log.Error("failed to encode response", "error", err)
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+type SuggestResponse struct {
+	Suggestions []core.Suggestion `json:"suggestions"`
+}
+
+func NewSuggestHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prefix := r.URL.Query().Get("prefix")
+		if strings.TrimSpace(prefix) == "" {
+			log.Warn("prefix is required")
+			http.Error(w, "prefix is required", http.StatusBadRequest)
+			return
+		}
+
+		limit, ok := parseLimit(w, r, log, 10)
+		if !ok {
+			return
+		}
+
+		suggestions, err := client.Suggest(r.Context(), prefix, int32(limit))
+		if err != nil {
+			if errors.Is(err, core.ErrBadArguments) {
+				log.Warn("bad request", "error", err)
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			log.Error("suggest failed", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(SuggestResponse{Suggestions: suggestions}); err != nil {
+			log.Error("failed to encode response", "error", err)
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+type SimilarResponse struct {
+	Comics     []core.Comics `json:"comics"`
+	Total      int32         `json:"total"`
+	Generation uint64        `json:"generation"`
+}
+
+func NewSimilarHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil || id < 1 {
+			log.Warn("invalid comic id", "id", r.PathValue("id"))
+			http.Error(w, "invalid comic id", http.StatusBadRequest)
+			return
+		}
+
+		limit, ok := parseLimit(w, r, log, 10)
+		if !ok {
+			return
+		}
+
+		result, err := client.Similar(r.Context(), id, int32(limit))
+		if err != nil {
+			switch {
+			case errors.Is(err, core.ErrNotFound):
+				log.Warn("comic not found", "id", id)
+				http.Error(w, "comic not found", http.StatusNotFound)
+			case errors.Is(err, core.ErrBadArguments):
+				log.Warn("bad request", "error", err)
+				http.Error(w, "bad request", http.StatusBadRequest)
+			default:
+				log.Error("similar failed", "error", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(SimilarResponse{Comics: result.Comics, Total: result.Total, Generation: result.Generation}); err != nil {
+			log.Error("failed to encode response", "error", err)
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+func NewComicHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil || id < 1 {
+			log.Warn("invalid comic id", "id", r.PathValue("id"))
+			http.Error(w, "invalid comic id", http.StatusBadRequest)
+			return
+		}
+
+		comic, err := client.GetComic(r.Context(), id)
+		writeComic(w, log, comic, err)
+	}
+}
+
+func NewRandomComicHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		comic, err := client.RandomComic(r.Context())
+		writeComic(w, log, comic, err)
+	}
+}
+
+func writeComic(w http.ResponseWriter, log *slog.Logger, comic core.ComicDetail, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, core.ErrNotFound):
+			log.Warn("comic not found", "error", err)
+			http.Error(w, "comic not found", http.StatusNotFound)
+		case errors.Is(err, core.ErrBadArguments):
+			log.Warn("bad request", "error", err)
+			http.Error(w, "bad request", http.StatusBadRequest)
+		default:
+			log.Error("get comic failed", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(comic); err != nil {
+		log.Error("failed to encode response", "error", err)
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func NewIndexStatsHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, ok := parseLimit(w, r, log, 20)
+		if !ok {
+			return
+		}
+
+		stats, err := client.IndexStats(r.Context(), int32(limit))
+		if err != nil {
+			log.Error("index stats failed", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(stats); err != nil {
+			log.Error("failed to encode response", "error", err)
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+func NewTermInfoHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		term := r.PathValue("term")
+		if strings.TrimSpace(term) == "" {
+			log.Warn("term is required")
+			http.Error(w, "term is required", http.StatusBadRequest)
+			return
+		}
+		limit, ok := parseLimit(w, r, log, 100)
+		if !ok {
+			return
+		}
+
+		info, err := client.TermInfo(r.Context(), term, int32(limit))
+		if err != nil {
+			switch {
+			case errors.Is(err, core.ErrNotFound):
+				log.Warn("term not found", "term", term)
+				http.Error(w, "term not found", http.StatusNotFound)
+			case errors.Is(err, core.ErrBadArguments):
+				log.Warn("bad request", "error", err)
+				http.Error(w, "bad request", http.StatusBadRequest)
+			default:
+				log.Error("term info failed", "error", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(info); err != nil {
+			log.Error("failed to encode response", "error", err)
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// NewAnalyticsHandler reports the search queries of the last window
+// (24h by default), e.g. ?window=168h for a week.
+func NewAnalyticsHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		window := 24 * time.Hour
+		if windowStr := r.URL.Query().Get("window"); windowStr != "" {
+			d, err := time.ParseDuration(windowStr)
+			if err != nil || d < time.Second {
+				log.Warn("invalid window", "window", windowStr)
+				http.Error(w, "invalid window", http.StatusBadRequest)
+				return
+			}
+			window = d
+		}
+		limit, ok := parseLimit(w, r, log, 20)
+		if !ok {
+			return
+		}
+
+		analytics, err := client.Analytics(r.Context(), window, int32(limit))
+		if err != nil {
+			if errors.Is(err, core.ErrBadArguments) {
+				http.Error(w, "bad arguments", http.StatusBadRequest)
+				return
+			}
+			log.Error("analytics failed", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(analytics); err != nil {
+			log.Error("failed to encode response", "error", err)
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+func NewSynonymsHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groups, err := client.Synonyms(r.Context())
+		if err != nil {
+			if errors.Is(err, core.ErrNotFound) {
+				http.Error(w, "synonyms are disabled", http.StatusNotFound)
+				return
+			}
+			log.Error("synonyms failed", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if groups == nil {
+			groups = [][]string{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(core.SynonymGroups{Groups: groups}); err != nil {
+			log.Error("failed to encode response", "error", err)
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// NewSetSynonymsHandler replaces all synonym groups with the ones of the
+// body, e.g. {"groups": [["car", "automobile"]]}.
+func NewSetSynonymsHandler(log *slog.Logger, client core.Searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req core.SynonymGroups
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Debug("failed to decode synonyms", "error", err)
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		if err := client.SetSynonyms(r.Context(), req.Groups); err != nil {
+			switch {
+			case errors.Is(err, core.ErrBadArguments):
+				log.Warn("invalid synonyms", "error", err)
+				http.Error(w, "invalid synonym groups", http.StatusBadRequest)
+			case errors.Is(err, core.ErrNotFound):
+				http.Error(w, "synonyms are disabled", http.StatusNotFound)
+			default:
+				log.Error("set synonyms failed", "error", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewDictionariesHandler(log *slog.Logger, editor core.DictionaryEditor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dicts, err := editor.Dictionaries(r.Context())
+		if err != nil {
+			log.Error("dictionaries failed", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if dicts.StopWords == nil {
+			dicts.StopWords = []string{}
+		}
+		if dicts.Protected == nil {
+			dicts.Protected = []string{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(dicts); err != nil {
+			log.Error("failed to encode response", "error", err)
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// NewSetDictionariesHandler replaces the stop words and protected words with
+// the ones of the body, e.g. {"stop_words": ["comic"], "protected": ["xkcd"]},
+// and answers with the new version. The change is audited with the user the
+// token was issued to.
+func NewSetDictionariesHandler(log *slog.Logger, editor core.DictionaryEditor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req core.Dictionaries
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Debug("failed to decode dictionaries", "error", err)
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		author := requestAuthor(r)
+		version, err := editor.SetDictionaries(r.Context(), req, author)
+		if err != nil {
+			if errors.Is(err, core.ErrBadArguments) {
+				log.Warn("invalid dictionaries", "error", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			log.Error("set dictionaries failed", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		log.Info("dictionaries uploaded", "author", author, "version", version.Version, "hash", version.Hash)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(version); err != nil {
+			log.Error("failed to encode response", "error", err)
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// requestAuthor returns the authenticated user of the request or, without
+// one, the client address the proxy forwarded or the request came from.
+func requestAuthor(r *http.Request) string {
+	if user := middleware.User(r.Context()); user != "" {
+		return user
+	}
+	if forwarded, _, _ := strings.Cut(r.Header.Get("X-Forwarded-For"), ","); strings.TrimSpace(forwarded) != "" {
+		return strings.TrimSpace(forwarded)
+	}
+	return r.RemoteAddr
+}
+
+func NewDictionaryVersionHandler(log *slog.Logger, editor core.DictionaryEditor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		version, err := editor.DictionaryVersion(r.Context())
+		if err != nil {
+			log.Error("dictionary version failed", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(version); err != nil {
+			log.Error("failed to encode response", "error", err)
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// parseLimit reads the positive limit query parameter, answering 400 if it
+// is malformed.
+func parseLimit(w http.ResponseWriter, r *http.Request, log *slog.Logger, def int) (int, bool) {
+	limitStr := r.URL.Query().Get("limit")
+	if limitStr == "" {
+		return def, true
+	}
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		log.Warn("invalid limit", "limit", limitStr)
+		http.Error(w, "invalid limit", http.StatusBadRequest)
+		return 0, false
+	}
+	return limit, true
+}
+
+func parseSearchOptions(r *http.Request) (core.SearchOptions, error) {
+	var opts core.SearchOptions
+	if fuzzy := r.URL.Query().Get("fuzzy"); fuzzy != "" {
+		v, err := strconv.ParseBool(fuzzy)
+		if err != nil {
+			return opts, fmt.Errorf("invalid fuzzy: %q", fuzzy)
+		}
+		opts.Fuzzy = v
+	}
+	if explain := r.URL.Query().Get("explain"); explain != "" {
+		v, err := strconv.ParseBool(explain)
+		if err != nil {
+			return opts, fmt.Errorf("invalid explain: %q", explain)
+		}
+		opts.Explain = v
+	}
+	if weights := r.URL.Query().Get("weights"); weights != "" {
+		opts.Weights = make(map[string]float64)
+		for _, pair := range strings.Split(weights, ",") {
+			field, value, ok := strings.Cut(pair, ":")
+			if !ok {
+				return opts, fmt.Errorf("invalid weights: %q", weights)
+			}
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil || v < 0 {
+				return opts, fmt.Errorf("invalid weight of %s: %q", field, value)
+			}
+			opts.Weights[strings.TrimSpace(field)] = v
+		}
+	}
+	var err error
+	if from := r.URL.Query().Get("from"); from != "" {
+		if opts.From, err = parseDate(from, false); err != nil {
+			return opts, err
+		}
+	}
+	if to := r.URL.Query().Get("to"); to != "" {
+		if opts.To, err = parseDate(to, true); err != nil {
+			return opts, err
+		}
+	}
+	opts.Sort = r.URL.Query().Get("sort")
+	return opts, nil
+}
+
+// parseDate accepts a year, a month (2010-03) or a day (2010-03-05). As the
+// upper bound of a range a year or a month stands for its last day.
+func parseDate(value string, end bool) (time.Time, error) {
+	for _, p := range []struct {
+		layout        string
+		years, months int
+	}{
+		{"2006", 1, 0},
+		{"2006-01", 0, 1},
+		{time.DateOnly, 0, 0},
+	} {
+		date, err := time.Parse(p.layout, value)
+		if err != nil {
+			continue
+		}
+		if end && (p.years > 0 || p.months > 0) {
+			date = date.AddDate(p.years, p.months, -1)
+		}
+		return date, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %q", value)
+}
+
 type DetectHandler struct {
 	log          *slog.Logger
 	yoloClient   core.YoloDetector
@@ -340,6 +929,11 @@ func (h *DetectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	limit, ok := parseLimit(w, r, h.log, 10)
+	if !ok {
+		return
+	}
+
 	file, _, err := r.FormFile("image")
 	if err != nil {
 		h.log.Error("failed to get image", "error", err)
@@ -368,7 +962,7 @@ func (h *DetectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	phrase := strings.Join(labels, " ")
 
-	comics, total, err := h.searchClient.Search(r.Context(), phrase, 10)
+	result, err := h.searchClient.Search(r.Context(), phrase, int32(limit), core.SearchOptions{Mode: core.ModeDetect})
 	if err != nil {
 		h.log.Error("search failed", "error", err)
 		if errors.Is(err, core.ErrBadArguments) {
@@ -380,8 +974,8 @@ func (h *DetectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := IndexSearchResponse{
-		Comics: comics,
-		Total:  total,
+		Comics: result.Comics,
+		Total:  result.Total,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
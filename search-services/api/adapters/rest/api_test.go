@@ -8,11 +8,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"yadro.com/course/api/adapters/rest/middleware"
 	mockrest "yadro.com/course/api/adapters/rest/mock"
 	"yadro.com/course/api/core"
 )
@@ -174,13 +176,32 @@ func TestNewWordsHandler(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockNorm.EXPECT().
-					Norm(gomock.Any(), "test phrase").
-					Return([]string{"test", "phrase"}, nil)
+					Norm(gomock.Any(), "test phrase", "", "").
+					Return(core.Normalized{Words: []string{"test", "phrase"}, Lang: "en"}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: WordsResponse{
 				Words: []string{"test", "phrase"},
 				Total: 2,
+				Lang:  "en",
+			},
+		},
+		{
+			name: "requested language",
+			queryParams: map[string]string{
+				"phrase": "роботы",
+				"lang":   "ru",
+			},
+			mockSetup: func() {
+				mockNorm.EXPECT().
+					Norm(gomock.Any(), "роботы", "ru", "").
+					Return(core.Normalized{Words: []string{"робот"}, Lang: "ru"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: WordsResponse{
+				Words: []string{"робот"},
+				Total: 1,
+				Lang:  "ru",
 			},
 		},
 		{
@@ -196,21 +217,97 @@ func TestNewWordsHandler(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockNorm.EXPECT().
-					Norm(gomock.Any(), "test").
-					Return(nil, errors.New("normalization error"))
+					Norm(gomock.Any(), "test", "", "").
+					Return(core.Normalized{}, errors.New("normalization error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
 		{
-			name: "bad arguments error",
+			name: "unsupported language",
+			queryParams: map[string]string{
+				"phrase": "test",
+				"lang":   "de",
+			},
+			mockSetup: func() {
+				mockNorm.EXPECT().
+					Norm(gomock.Any(), "test", "de", "").
+					Return(core.Normalized{}, core.ErrBadArguments)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "detailed normalization",
+			queryParams: map[string]string{
+				"phrase": "Robots and robots",
+				"detail": "true",
+			},
+			mockSetup: func() {
+				mockNorm.EXPECT().
+					NormDetailed(gomock.Any(), "Robots and robots", "", "").
+					Return(core.DetailedNormalized{
+						Tokens: []core.Token{
+							{Text: "Robots", Start: 0, End: 6, Position: 0, Stem: "robot", Lemma: "robot", Term: "robot"},
+							{Text: "and", Start: 7, End: 10, Position: 1, Stem: "and", Lemma: "and", Term: "and", Stop: true},
+							{Text: "robots", Start: 11, End: 17, Position: 2, Stem: "robot", Lemma: "robot", Term: "robot"},
+						},
+						Terms: []core.TermFrequency{{Term: "robot", Count: 2}},
+						Lang:  "en",
+						Mode:  "stem",
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: WordsResponse{
+				Words: []string{"robot"},
+				Total: 1,
+				Lang:  "en",
+				Mode:  "stem",
+				Tokens: []core.Token{
+					{Text: "Robots", Start: 0, End: 6, Position: 0, Stem: "robot", Lemma: "robot", Term: "robot"},
+					{Text: "and", Start: 7, End: 10, Position: 1, Stem: "and", Lemma: "and", Term: "and", Stop: true},
+					{Text: "robots", Start: 11, End: 17, Position: 2, Stem: "robot", Lemma: "robot", Term: "robot"},
+				},
+				Terms: []core.TermFrequency{{Term: "robot", Count: 2}},
+			},
+		},
+		{
+			name: "lemma mode",
+			queryParams: map[string]string{
+				"phrase": "studies of mice",
+				"mode":   "lemma",
+			},
+			mockSetup: func() {
+				mockNorm.EXPECT().
+					Norm(gomock.Any(), "studies of mice", "", "lemma").
+					Return(core.Normalized{Words: []string{"study", "mouse"}, Lang: "en", Mode: "lemma"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: WordsResponse{
+				Words: []string{"study", "mouse"},
+				Total: 2,
+				Lang:  "en",
+				Mode:  "lemma",
+			},
+		},
+		{
+			name: "detailed normalization error",
 			queryParams: map[string]string{
 				"phrase": "test",
+				"detail": "true",
 			},
 			mockSetup: func() {
 				mockNorm.EXPECT().
-					Norm(gomock.Any(), "test").
-					Return(nil, core.ErrBadArguments)
+					NormDetailed(gomock.Any(), "test", "", "").
+					Return(core.DetailedNormalized{}, core.ErrBadArguments)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid detail",
+			queryParams: map[string]string{
+				"phrase": "test",
+				"detail": "maybe",
 			},
+			mockSetup:      func() {},
 			expectedStatus: http.StatusBadRequest,
 		},
 	}
@@ -496,8 +593,8 @@ func TestNewSearchHandler(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockSearcher.EXPECT().
-					Search(gomock.Any(), "test", int32(5)).
-					Return([]core.Comics{{ID: 1, URL: "Test Comic"}}, int32(1), nil)
+					Search(gomock.Any(), "test", int32(5), core.SearchOptions{}).
+					Return(core.SearchResult{Comics: []core.Comics{{ID: 1, URL: "Test Comic"}}, Total: 1}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: SearchResponse{
@@ -505,6 +602,109 @@ func TestNewSearchHandler(t *testing.T) {
 				Total:  1,
 			},
 		},
+		{
+			name: "fuzzy search with suggestion",
+			queryParams: map[string]string{
+				"phrase": "tset",
+				"limit":  "5",
+				"fuzzy":  "true",
+			},
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Search(gomock.Any(), "tset", int32(5), core.SearchOptions{Fuzzy: true}).
+					Return(core.SearchResult{Comics: []core.Comics{{ID: 1, URL: "Test Comic"}}, Total: 1, Suggestion: "test"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: SearchResponse{
+				Comics:     []core.Comics{{ID: 1, URL: "Test Comic"}},
+				Total:      1,
+				Suggestion: "test",
+			},
+		},
+		{
+			name: "zero hits corrected",
+			queryParams: map[string]string{
+				"phrase": "sandwhich",
+			},
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Search(gomock.Any(), "sandwhich", int32(10), core.SearchOptions{}).
+					Return(core.SearchResult{Comics: []core.Comics{}}, nil)
+				mockSearcher.EXPECT().
+					Correct(gomock.Any(), "sandwhich").
+					Return(core.Corrected{Phrase: "sandwich"}, nil)
+				mockSearcher.EXPECT().
+					Search(gomock.Any(), "sandwich", int32(10), core.SearchOptions{}).
+					Return(core.SearchResult{Comics: []core.Comics{{ID: 149, URL: "Sandwich"}}, Total: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: SearchResponse{
+				Comics:    []core.Comics{{ID: 149, URL: "Sandwich"}},
+				Total:     1,
+				Corrected: "sandwich",
+			},
+		},
+		{
+			name: "correction finds nothing either",
+			queryParams: map[string]string{
+				"phrase": "qwrty",
+			},
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Search(gomock.Any(), "qwrty", int32(10), core.SearchOptions{}).
+					Return(core.SearchResult{Comics: []core.Comics{}}, nil)
+				mockSearcher.EXPECT().
+					Correct(gomock.Any(), "qwrty").
+					Return(core.Corrected{Phrase: "party"}, nil)
+				mockSearcher.EXPECT().
+					Search(gomock.Any(), "party", int32(10), core.SearchOptions{}).
+					Return(core.SearchResult{Comics: []core.Comics{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   SearchResponse{Comics: []core.Comics{}},
+		},
+		{
+			name: "correction error keeps result",
+			queryParams: map[string]string{
+				"phrase": "qwrty",
+			},
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Search(gomock.Any(), "qwrty", int32(10), core.SearchOptions{}).
+					Return(core.SearchResult{Comics: []core.Comics{}}, nil)
+				mockSearcher.EXPECT().
+					Correct(gomock.Any(), "qwrty").
+					Return(core.Corrected{}, errors.New("search error"))
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   SearchResponse{Comics: []core.Comics{}},
+		},
+		{
+			name: "search with snippet",
+			queryParams: map[string]string{
+				"phrase": "robots",
+			},
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Search(gomock.Any(), "robots", int32(10), core.SearchOptions{}).
+					Return(core.SearchResult{Comics: []core.Comics{{
+						ID:      1,
+						URL:     "Test Comic",
+						Terms:   []string{"robots"},
+						Snippet: &core.Snippet{Field: "alt", Text: "Robots!", Highlights: []core.Span{{Start: 0, End: 6}}},
+					}}, Total: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: SearchResponse{
+				Comics: []core.Comics{{
+					ID:      1,
+					URL:     "Test Comic",
+					Terms:   []string{"robots"},
+					Snippet: &core.Snippet{Field: "alt", Text: "Robots!", Highlights: []core.Span{{Start: 0, End: 6}}},
+				}},
+				Total: 1,
+			},
+		},
 		{
 			name: "missing phrase",
 			queryParams: map[string]string{
@@ -513,6 +713,84 @@ func TestNewSearchHandler(t *testing.T) {
 			mockSetup:      func() {},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name: "invalid fuzzy",
+			queryParams: map[string]string{
+				"phrase": "test",
+				"fuzzy":  "maybe",
+			},
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "field query with weights",
+			queryParams: map[string]string{
+				"phrase":  "title:robot laser",
+				"weights": "title:5,alt:0.5",
+			},
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Search(gomock.Any(), "title:robot laser", int32(10), core.SearchOptions{
+						Weights: map[string]float64{"title": 5, "alt": 0.5},
+					}).
+					Return(core.SearchResult{Comics: []core.Comics{{ID: 1, URL: "Test Comic"}}, Total: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: SearchResponse{
+				Comics: []core.Comics{{ID: 1, URL: "Test Comic"}},
+				Total:  1,
+			},
+		},
+		{
+			name: "date range and sort",
+			queryParams: map[string]string{
+				"phrase": "robot",
+				"from":   "2010",
+				"to":     "2011-02",
+				"sort":   "newest",
+			},
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Search(gomock.Any(), "robot", int32(10), core.SearchOptions{
+						From: time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC),
+						To:   time.Date(2011, time.February, 28, 0, 0, 0, 0, time.UTC),
+						Sort: "newest",
+					}).
+					Return(core.SearchResult{Comics: []core.Comics{{ID: 700, URL: "Test Comic", Date: "2010-03-05"}}, Total: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: SearchResponse{
+				Comics: []core.Comics{{ID: 700, URL: "Test Comic", Date: "2010-03-05"}},
+				Total:  1,
+			},
+		},
+		{
+			name: "invalid date",
+			queryParams: map[string]string{
+				"phrase": "robot",
+				"from":   "last year",
+			},
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid weights",
+			queryParams: map[string]string{
+				"phrase":  "test",
+				"weights": "title=5",
+			},
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "negative weight",
+			queryParams: map[string]string{
+				"phrase":  "test",
+				"weights": "title:-1",
+			},
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name: "invalid limit",
 			queryParams: map[string]string{
@@ -530,8 +808,8 @@ func TestNewSearchHandler(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockSearcher.EXPECT().
-					Search(gomock.Any(), "test", int32(5)).
-					Return(nil, int32(0), errors.New("search error"))
+					Search(gomock.Any(), "test", int32(5), core.SearchOptions{}).
+					Return(core.SearchResult{}, errors.New("search error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -543,8 +821,8 @@ func TestNewSearchHandler(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockSearcher.EXPECT().
-					Search(gomock.Any(), "test", int32(5)).
-					Return(nil, int32(0), core.ErrBadArguments)
+					Search(gomock.Any(), "test", int32(5), core.SearchOptions{}).
+					Return(core.SearchResult{}, core.ErrBadArguments)
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
@@ -578,7 +856,7 @@ func TestNewSearchHandler(t *testing.T) {
 	}
 }
 
-func TestNewSearchIndexHandler(t *testing.T) {
+func TestNewSuggestHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -587,27 +865,772 @@ func TestNewSearchIndexHandler(t *testing.T) {
 
 	tests := []struct {
 		name           string
-		queryParams    map[string]string
+		query          string
 		mockSetup      func()
 		expectedStatus int
-		expectedBody   IndexSearchResponse
+		expectedBody   SuggestResponse
 	}{
 		{
-			name: "successful index search",
-			queryParams: map[string]string{
-				"phrase": "test",
-				"limit":  "5",
+			name:  "successful suggest",
+			query: "prefix=prog&limit=3",
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Suggest(gomock.Any(), "prog", int32(3)).
+					Return([]core.Suggestion{{Word: "programming", Count: 12}, {Word: "progress", Count: 4}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: SuggestResponse{
+				Suggestions: []core.Suggestion{{Word: "programming", Count: 12}, {Word: "progress", Count: 4}},
 			},
+		},
+		{
+			name:  "default limit",
+			query: "prefix=x",
 			mockSetup: func() {
 				mockSearcher.EXPECT().
-					IndexSearch(gomock.Any(), "test", int32(5)).
-					Return([]core.Comics{{ID: 1, URL: "Test Comic"}}, int32(1), nil)
+					Suggest(gomock.Any(), "x", int32(10)).
+					Return([]core.Suggestion{}, nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody: IndexSearchResponse{
-				Comics: []core.Comics{{ID: 1, URL: "Test Comic"}},
-				Total:  1,
+			expectedBody:   SuggestResponse{Suggestions: []core.Suggestion{}},
+		},
+		{
+			name:           "missing prefix",
+			query:          "limit=3",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid limit",
+			query:          "prefix=prog&limit=-1",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "search error",
+			query: "prefix=prog",
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Suggest(gomock.Any(), "prog", int32(10)).
+					Return(nil, errors.New("search error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest("GET", "/api/suggest?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			NewSuggestHandler(log, mockSearcher)(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response SuggestResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
+
+func TestNewCorrectHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mockrest.NewMockSearcher(ctrl)
+	log := slog.Default()
+	handler := NewCorrectHandler(log, mockSearcher)
+
+	t.Run("corrected", func(t *testing.T) {
+		expected := core.Corrected{
+			Phrase:      "black hat",
+			Corrections: []core.Correction{{Word: "blak", Correction: "black", Distance: 1, Count: 40}},
+			Generation:  2,
+		}
+		mockSearcher.EXPECT().Correct(gomock.Any(), "blak hat").Return(expected, nil)
+
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", "/api/correct?phrase=blak+hat", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response core.Corrected
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("missing phrase", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", "/api/correct", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		mockSearcher.EXPECT().Correct(gomock.Any(), "blak").Return(core.Corrected{}, errors.New("search error"))
+
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", "/api/correct?phrase=blak", nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestNewSimilarHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mockrest.NewMockSearcher(ctrl)
+	log := slog.Default()
+
+	tests := []struct {
+		name           string
+		path           string
+		mockSetup      func()
+		expectedStatus int
+		expectedBody   SimilarResponse
+	}{
+		{
+			name: "successful similar",
+			path: "/api/comics/7/similar?limit=2",
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Similar(gomock.Any(), 7, int32(2)).
+					Return(core.SearchResult{Comics: []core.Comics{{ID: 8, URL: "Test Comic", Score: 0.5}}, Total: 3}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: SimilarResponse{
+				Comics: []core.Comics{{ID: 8, URL: "Test Comic", Score: 0.5}},
+				Total:  3,
+			},
+		},
+		{
+			name: "unknown comic",
+			path: "/api/comics/42/similar",
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Similar(gomock.Any(), 42, int32(10)).
+					Return(core.SearchResult{}, core.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid id",
+			path:           "/api/comics/abc/similar",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid limit",
+			path:           "/api/comics/7/similar?limit=0",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "search error",
+			path: "/api/comics/7/similar",
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					Similar(gomock.Any(), 7, int32(10)).
+					Return(core.SearchResult{}, errors.New("search error"))
 			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/comics/{id}/similar", NewSimilarHandler(log, mockSearcher))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response SimilarResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
+
+func TestNewComicHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mockrest.NewMockSearcher(ctrl)
+	log := slog.Default()
+
+	comic := core.ComicDetail{
+		ID: 7, URL: "Test Comic", Title: "Robots", Date: "2006-01-02",
+		Words: []string{"robot"}, Prev: 6, Next: 8,
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		mockSetup      func()
+		expectedStatus int
+		expectedBody   core.ComicDetail
+	}{
+		{
+			name: "successful get",
+			path: "/api/comics/7",
+			mockSetup: func() {
+				mockSearcher.EXPECT().GetComic(gomock.Any(), 7).Return(comic, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   comic,
+		},
+		{
+			name: "random comic",
+			path: "/api/comics/random",
+			mockSetup: func() {
+				mockSearcher.EXPECT().RandomComic(gomock.Any()).Return(comic, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   comic,
+		},
+		{
+			name: "unknown comic",
+			path: "/api/comics/42",
+			mockSetup: func() {
+				mockSearcher.EXPECT().GetComic(gomock.Any(), 42).Return(core.ComicDetail{}, core.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "empty index",
+			path: "/api/comics/random",
+			mockSetup: func() {
+				mockSearcher.EXPECT().RandomComic(gomock.Any()).Return(core.ComicDetail{}, core.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid id",
+			path:           "/api/comics/0",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "search error",
+			path: "/api/comics/7",
+			mockSetup: func() {
+				mockSearcher.EXPECT().GetComic(gomock.Any(), 7).Return(core.ComicDetail{}, errors.New("search error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/comics/{id}", NewComicHandler(log, mockSearcher))
+	mux.Handle("GET /api/comics/random", NewRandomComicHandler(log, mockSearcher))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response core.ComicDetail
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
+
+func TestNewIndexStatsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mockrest.NewMockSearcher(ctrl)
+	log := slog.Default()
+	handler := NewIndexStatsHandler(log, mockSearcher)
+
+	stats := core.IndexStats{
+		Generation: 2,
+		BuiltAt:    "2025-03-01T12:00:00Z",
+		Documents:  10,
+		Terms:      20,
+		TopTerms:   []core.TermStats{{Term: "robot", Docs: 7, Total: 9}},
+		RareTerms:  []core.TermStats{{Term: "laser", Docs: 1, Total: 1}},
+	}
+	mockSearcher.EXPECT().IndexStats(gomock.Any(), int32(20)).Return(stats, nil)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/index/stats", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response core.IndexStats
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, stats, response)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/index/stats?limit=x", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockSearcher.EXPECT().IndexStats(gomock.Any(), int32(5)).Return(core.IndexStats{}, errors.New("search error"))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/index/stats?limit=5", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestNewAnalyticsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mockrest.NewMockSearcher(ctrl)
+	handler := NewAnalyticsHandler(slog.Default(), mockSearcher)
+
+	analytics := core.Analytics{
+		Since:       "2025-03-01T12:00:00Z",
+		Queries:     5,
+		TopQueries:  []core.QueryCount{{Phrase: "robot", Count: 3}},
+		ZeroResults: []core.QueryCount{{Phrase: "qwerty", Count: 1}},
+		Latency:     []core.LatencyStats{{Mode: "search", Count: 5, P50Ms: 2, P90Ms: 10, P99Ms: 12.5}},
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name: "default window",
+			url:  "/api/admin/analytics",
+			mockSetup: func() {
+				mockSearcher.EXPECT().Analytics(gomock.Any(), 24*time.Hour, int32(20)).Return(analytics, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "week window",
+			url:  "/api/admin/analytics?window=168h&limit=5",
+			mockSetup: func() {
+				mockSearcher.EXPECT().Analytics(gomock.Any(), 168*time.Hour, int32(5)).Return(analytics, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid window",
+			url:            "/api/admin/analytics?window=week",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative window",
+			url:            "/api/admin/analytics?window=-1h",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "search error",
+			url:  "/api/admin/analytics",
+			mockSetup: func() {
+				mockSearcher.EXPECT().Analytics(gomock.Any(), 24*time.Hour, int32(20)).Return(core.Analytics{}, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest("GET", tt.url, nil))
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response core.Analytics
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, analytics, response)
+			}
+		})
+	}
+}
+
+func TestNewSynonymsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mockrest.NewMockSearcher(ctrl)
+	handler := NewSynonymsHandler(slog.Default(), mockSearcher)
+
+	t.Run("success", func(t *testing.T) {
+		mockSearcher.EXPECT().Synonyms(gomock.Any()).Return([][]string{{"car", "automobile"}}, nil)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/admin/synonyms", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"groups": [["car", "automobile"]]}`, w.Body.String())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		mockSearcher.EXPECT().Synonyms(gomock.Any()).Return(nil, nil)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/admin/synonyms", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"groups": []}`, w.Body.String())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		mockSearcher.EXPECT().Synonyms(gomock.Any()).Return(nil, core.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/admin/synonyms", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestNewSetSynonymsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mockrest.NewMockSearcher(ctrl)
+	handler := NewSetSynonymsHandler(slog.Default(), mockSearcher)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name: "success",
+			body: `{"groups": [["car", "automobile"], ["cat", "kitten"]]}`,
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					SetSynonyms(gomock.Any(), [][]string{{"car", "automobile"}, {"cat", "kitten"}}).
+					Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "invalid body",
+			body:           `{"groups": "car"}`,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid group",
+			body: `{"groups": [["car"]]}`,
+			mockSetup: func() {
+				mockSearcher.EXPECT().SetSynonyms(gomock.Any(), [][]string{{"car"}}).Return(core.ErrBadArguments)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "search error",
+			body: `{"groups": []}`,
+			mockSetup: func() {
+				mockSearcher.EXPECT().SetSynonyms(gomock.Any(), [][]string{}).Return(errors.New("disk full"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest("PUT", "/api/admin/synonyms", bytes.NewBufferString(tt.body)))
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestNewDictionariesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEditor := mockrest.NewMockDictionaryEditor(ctrl)
+	handler := NewDictionariesHandler(slog.Default(), mockEditor)
+
+	t.Run("empty lists", func(t *testing.T) {
+		mockEditor.EXPECT().Dictionaries(gomock.Any()).Return(core.Dictionaries{}, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/admin/dictionaries", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"stop_words": [], "protected": []}`, w.Body.String())
+	})
+
+	t.Run("words error", func(t *testing.T) {
+		mockEditor.EXPECT().Dictionaries(gomock.Any()).Return(core.Dictionaries{}, errors.New("unavailable"))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/admin/dictionaries", nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestNewSetDictionariesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEditor := mockrest.NewMockDictionaryEditor(ctrl)
+	handler := NewSetDictionariesHandler(slog.Default(), mockEditor)
+
+	tests := []struct {
+		name           string
+		body           string
+		user           string
+		forwarded      string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name:      "success",
+			body:      `{"stop_words": ["comic"], "protected": ["xkcd"]}`,
+			user:      "admin",
+			forwarded: "203.0.113.7",
+			mockSetup: func() {
+				mockEditor.EXPECT().
+					SetDictionaries(gomock.Any(), core.Dictionaries{StopWords: []string{"comic"}, Protected: []string{"xkcd"}}, "admin").
+					Return(core.DictionaryVersion{Version: 2, Hash: "abc", Source: "upload", StopWords: 1, Protected: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "forwarded client without user",
+			body:      `{}`,
+			forwarded: "203.0.113.7, 10.0.0.1",
+			mockSetup: func() {
+				mockEditor.EXPECT().SetDictionaries(gomock.Any(), core.Dictionaries{}, "203.0.113.7").
+					Return(core.DictionaryVersion{Version: 2, Hash: "abc", Source: "upload", StopWords: 1, Protected: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "remote address without user",
+			body: `{}`,
+			mockSetup: func() {
+				mockEditor.EXPECT().SetDictionaries(gomock.Any(), core.Dictionaries{}, "192.0.2.1:1234").
+					Return(core.DictionaryVersion{Version: 2, Hash: "abc", Source: "upload", StopWords: 1, Protected: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid body",
+			body:           `{"stop_words": "comic"}`,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "bad word",
+			body: `{"stop_words": ["two words"]}`,
+			mockSetup: func() {
+				mockEditor.EXPECT().SetDictionaries(gomock.Any(), gomock.Any(), gomock.Any()).Return(core.DictionaryVersion{}, core.ErrBadArguments)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "words error",
+			body: `{}`,
+			mockSetup: func() {
+				mockEditor.EXPECT().SetDictionaries(gomock.Any(), gomock.Any(), gomock.Any()).Return(core.DictionaryVersion{}, errors.New("disk full"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/api/admin/dictionaries", bytes.NewBufferString(tt.body))
+			if tt.user != "" {
+				req = req.WithContext(middleware.WithUser(req.Context(), tt.user))
+			}
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.JSONEq(t, `{"version": 2, "hash": "abc", "updated_at": "", "source": "upload", "stop_words": 1, "protected": 1}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestNewDictionaryVersionHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEditor := mockrest.NewMockDictionaryEditor(ctrl)
+	handler := NewDictionaryVersionHandler(slog.Default(), mockEditor)
+
+	mockEditor.EXPECT().DictionaryVersion(gomock.Any()).Return(core.DictionaryVersion{Version: 1, Source: "startup"}, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/admin/dictionaries/version", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var version core.DictionaryVersion
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &version))
+	assert.Equal(t, uint64(1), version.Version)
+
+	mockEditor.EXPECT().DictionaryVersion(gomock.Any()).Return(core.DictionaryVersion{}, errors.New("unavailable"))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/admin/dictionaries/version", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestNewTermInfoHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mockrest.NewMockSearcher(ctrl)
+	log := slog.Default()
+
+	info := core.TermInfo{
+		TermStats: core.TermStats{Term: "robot", Docs: 2, Total: 3},
+		Form:      "robots",
+		IDF:       0.4,
+		Postings:  []core.Posting{{ID: 1, Freq: 2, Fields: []string{"title"}}, {ID: 4, Freq: 1, Fields: []string{"alt"}}},
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		mockSetup      func()
+		expectedStatus int
+		expectedBody   core.TermInfo
+	}{
+		{
+			name: "successful lookup",
+			path: "/api/index/terms/robots",
+			mockSetup: func() {
+				mockSearcher.EXPECT().TermInfo(gomock.Any(), "robots", int32(100)).Return(info, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   info,
+		},
+		{
+			name: "unknown term",
+			path: "/api/index/terms/laser?limit=5",
+			mockSetup: func() {
+				mockSearcher.EXPECT().TermInfo(gomock.Any(), "laser", int32(5)).Return(core.TermInfo{}, core.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid limit",
+			path:           "/api/index/terms/robot?limit=0",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "search error",
+			path: "/api/index/terms/robot",
+			mockSetup: func() {
+				mockSearcher.EXPECT().TermInfo(gomock.Any(), "robot", int32(100)).Return(core.TermInfo{}, errors.New("search error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/index/terms/{term}", NewTermInfoHandler(log, mockSearcher))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response core.TermInfo
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
+
+func TestNewSearchIndexHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mockrest.NewMockSearcher(ctrl)
+	log := slog.Default()
+
+	tests := []struct {
+		name           string
+		queryParams    map[string]string
+		mockSetup      func()
+		expectedStatus int
+		expectedBody   IndexSearchResponse
+	}{
+		{
+			name: "successful index search",
+			queryParams: map[string]string{
+				"phrase": "test",
+				"limit":  "5",
+			},
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					IndexSearch(gomock.Any(), "test", int32(5), core.SearchOptions{}).
+					Return(core.SearchResult{Comics: []core.Comics{{ID: 1, URL: "Test Comic"}}, Total: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: IndexSearchResponse{
+				Comics: []core.Comics{{ID: 1, URL: "Test Comic"}},
+				Total:  1,
+			},
+		},
+		{
+			name: "explained index search",
+			queryParams: map[string]string{
+				"phrase":  "test",
+				"limit":   "5",
+				"explain": "true",
+			},
+			mockSetup: func() {
+				mockSearcher.EXPECT().
+					IndexSearch(gomock.Any(), "test", int32(5), core.SearchOptions{Explain: true}).
+					Return(core.SearchResult{
+						Comics: []core.Comics{{ID: 1, URL: "Test Comic", Explanation: &core.Explanation{
+							Terms:  []core.TermMatch{{Term: "test", Fields: []string{"title"}, Matched: []string{"title"}, Freq: 1, Weight: 3}},
+							Unique: 1, Weight: 3, Total: 1,
+						}}},
+						Total:   1,
+						Explain: &core.QueryExplanation{Path: "index", Generation: 2, Terms: []string{"test"}},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: IndexSearchResponse{
+				Comics: []core.Comics{{ID: 1, URL: "Test Comic", Explanation: &core.Explanation{
+					Terms:  []core.TermMatch{{Term: "test", Fields: []string{"title"}, Matched: []string{"title"}, Freq: 1, Weight: 3}},
+					Unique: 1, Weight: 3, Total: 1,
+				}}},
+				Total:   1,
+				Explain: &core.QueryExplanation{Path: "index", Generation: 2, Terms: []string{"test"}},
+			},
+		},
+		{
+			name: "invalid explain",
+			queryParams: map[string]string{
+				"phrase":  "test",
+				"explain": "maybe",
+			},
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
 		},
 		{
 			name: "missing phrase",
@@ -634,8 +1657,8 @@ func TestNewSearchIndexHandler(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockSearcher.EXPECT().
-					IndexSearch(gomock.Any(), "test", int32(5)).
-					Return(nil, int32(0), errors.New("search error"))
+					IndexSearch(gomock.Any(), "test", int32(5), core.SearchOptions{}).
+					Return(core.SearchResult{}, errors.New("search error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -647,8 +1670,8 @@ func TestNewSearchIndexHandler(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockSearcher.EXPECT().
-					IndexSearch(gomock.Any(), "test", int32(5)).
-					Return(nil, int32(0), core.ErrBadArguments)
+					IndexSearch(gomock.Any(), "test", int32(5), core.SearchOptions{}).
+					Return(core.SearchResult{}, core.ErrBadArguments)
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
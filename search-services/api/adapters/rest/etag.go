@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"yadro.com/course/api/core"
+)
+
+// Search results change only with the index generation, so the generation
+// is their ETag and revalidation asks the search service for nothing else:
+// the Generation call returns just the number, without the index statistics.
+
+func etag(generation uint64) string {
+	return fmt.Sprintf(`"g%d"`, generation)
+}
+
+// setETag marks a response computed on the index generation, zero means the
+// index is not built yet and the response is not cacheable.
+func setETag(w http.ResponseWriter, generation uint64) {
+	if generation == 0 {
+		return
+	}
+	w.Header().Set("ETag", etag(generation))
+	w.Header().Set("Cache-Control", "no-cache")
+}
+
+// notModified answers 304 if the client's copy was computed on the current
+// index generation. The query is then left out of the query log, which only
+// records searches the search service runs.
+func notModified(w http.ResponseWriter, r *http.Request, log *slog.Logger, client core.Searcher) bool {
+	match := r.Header.Get("If-None-Match")
+	if match == "" {
+		return false
+	}
+
+	generation, err := client.Generation(r.Context())
+	if err != nil {
+		log.Warn("failed to get index generation", "error", err)
+		return false
+	}
+	if generation == 0 || !etagMatches(match, etag(generation)) {
+		return false
+	}
+
+	setETag(w, generation)
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+func etagMatches(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == tag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mockrest "yadro.com/course/api/adapters/rest/mock"
+	"yadro.com/course/api/core"
+)
+
+func TestSearchHandlers_ETag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mockrest.NewMockSearcher(ctrl)
+	mockSearcher.EXPECT().Correct(gomock.Any(), "robot").Return(core.Corrected{Phrase: "robot"}, nil).AnyTimes()
+	log := slog.Default()
+
+	handlers := map[string]http.HandlerFunc{
+		"search":  NewSearchHandler(log, mockSearcher),
+		"isearch": NewSearchIndexHandler(log, mockSearcher),
+	}
+	expectSearch := func(name string, result core.SearchResult, err error) {
+		if name == "search" {
+			mockSearcher.EXPECT().Search(gomock.Any(), "robot", int32(10), core.SearchOptions{}).Return(result, err)
+		} else {
+			mockSearcher.EXPECT().IndexSearch(gomock.Any(), "robot", int32(10), core.SearchOptions{}).Return(result, err)
+		}
+	}
+
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			serve := func(match string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest("GET", "/api/"+name+"?phrase=robot", nil)
+				if match != "" {
+					req.Header.Set("If-None-Match", match)
+				}
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, req)
+				return w
+			}
+
+			expectSearch(name, core.SearchResult{Comics: []core.Comics{{ID: 1}}, Total: 1, Generation: 3}, nil)
+			w := serve("")
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, `"g3"`, w.Header().Get("ETag"))
+
+			// тот же индекс - ответ не изменился, поиск не выполняется
+			mockSearcher.EXPECT().Generation(gomock.Any()).Return(uint64(3), nil)
+			w = serve(`W/"g3"`)
+			assert.Equal(t, http.StatusNotModified, w.Code)
+			assert.Empty(t, w.Body.String())
+
+			mockSearcher.EXPECT().Generation(gomock.Any()).Return(uint64(4), nil)
+			expectSearch(name, core.SearchResult{Generation: 4}, nil)
+			w = serve(`"g3"`)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, `"g4"`, w.Header().Get("ETag"))
+
+			// без индекса кешировать нечего
+			mockSearcher.EXPECT().Generation(gomock.Any()).Return(uint64(0), errors.New("search error"))
+			expectSearch(name, core.SearchResult{}, nil)
+			w = serve(`"g3"`)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestEtagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"g1"`, `"g1"`))
+	assert.True(t, etagMatches(`"g0", W/"g1"`, `"g1"`))
+	assert.True(t, etagMatches(`*`, `"g1"`))
+	assert.False(t, etagMatches(`"g12"`, `"g1"`))
+}
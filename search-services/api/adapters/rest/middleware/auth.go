@@ -1,12 +1,28 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 )
 
 type TokenVerifier interface {
-	Verify(token string) error
+	// Verify checks the token and returns the user it was issued to.
+	Verify(token string) (string, error)
+}
+
+type userKey struct{}
+
+// WithUser returns the context of a request made by the authenticated user.
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// User returns the authenticated user of the request, empty if Auth has not
+// put one on it.
+func User(ctx context.Context) string {
+	user, _ := ctx.Value(userKey{}).(string)
+	return user
 }
 
 func Auth(next http.HandlerFunc, verifier TokenVerifier) http.HandlerFunc {
@@ -29,11 +45,12 @@ func Auth(next http.HandlerFunc, verifier TokenVerifier) http.HandlerFunc {
 			return
 		}
 
-		if err := verifier.Verify(token); err != nil {
+		user, err := verifier.Verify(token)
+		if err != nil {
 			http.Error(w, "Invalid token: "+err.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		next(w, r)
+		next(w, r.WithContext(WithUser(r.Context(), user)))
 	}
 }
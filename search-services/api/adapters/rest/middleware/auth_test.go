@@ -11,11 +11,12 @@ import (
 var errTestInvalidToken = errors.New("invalid token")
 
 type MockTokenVerifier struct {
-	err error
+	user string
+	err  error
 }
 
-func (m *MockTokenVerifier) Verify(token string) error {
-	return m.err
+func (m *MockTokenVerifier) Verify(token string) (string, error) {
+	return m.user, m.err
 }
 
 func TestAuthMiddleware(t *testing.T) {
@@ -62,9 +63,12 @@ func TestAuthMiddleware(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			verifier := &MockTokenVerifier{err: tt.mockError}
+			verifier := &MockTokenVerifier{user: "admin", err: tt.mockError}
 			handler := Auth(
 				func(w http.ResponseWriter, r *http.Request) {
+					if user := User(r.Context()); user != "admin" {
+						t.Errorf("handler got user %q want %q", user, "admin")
+					}
 					w.WriteHeader(http.StatusOK)
 					_, _ = w.Write([]byte("OK"))
 				},
@@ -7,6 +7,7 @@ package mock_rest
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	core "yadro.com/course/api/core"
@@ -74,18 +75,101 @@ func (m *MockNormalizer) EXPECT() *MockNormalizerMockRecorder {
 }
 
 // Norm mocks base method.
-func (m *MockNormalizer) Norm(arg0 context.Context, arg1 string) ([]string, error) {
+func (m *MockNormalizer) Norm(ctx context.Context, phrase, lang, mode string) (core.Normalized, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Norm", arg0, arg1)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "Norm", ctx, phrase, lang, mode)
+	ret0, _ := ret[0].(core.Normalized)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Norm indicates an expected call of Norm.
-func (mr *MockNormalizerMockRecorder) Norm(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockNormalizerMockRecorder) Norm(ctx, phrase, lang, mode interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Norm", reflect.TypeOf((*MockNormalizer)(nil).Norm), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Norm", reflect.TypeOf((*MockNormalizer)(nil).Norm), ctx, phrase, lang, mode)
+}
+
+// NormDetailed mocks base method.
+func (m *MockNormalizer) NormDetailed(ctx context.Context, phrase, lang, mode string) (core.DetailedNormalized, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormDetailed", ctx, phrase, lang, mode)
+	ret0, _ := ret[0].(core.DetailedNormalized)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormDetailed indicates an expected call of NormDetailed.
+func (mr *MockNormalizerMockRecorder) NormDetailed(ctx, phrase, lang, mode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormDetailed", reflect.TypeOf((*MockNormalizer)(nil).NormDetailed), ctx, phrase, lang, mode)
+}
+
+// MockDictionaryEditor is a mock of DictionaryEditor interface.
+type MockDictionaryEditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockDictionaryEditorMockRecorder
+}
+
+// MockDictionaryEditorMockRecorder is the mock recorder for MockDictionaryEditor.
+type MockDictionaryEditorMockRecorder struct {
+	mock *MockDictionaryEditor
+}
+
+// NewMockDictionaryEditor creates a new mock instance.
+func NewMockDictionaryEditor(ctrl *gomock.Controller) *MockDictionaryEditor {
+	mock := &MockDictionaryEditor{ctrl: ctrl}
+	mock.recorder = &MockDictionaryEditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDictionaryEditor) EXPECT() *MockDictionaryEditorMockRecorder {
+	return m.recorder
+}
+
+// Dictionaries mocks base method.
+func (m *MockDictionaryEditor) Dictionaries(arg0 context.Context) (core.Dictionaries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dictionaries", arg0)
+	ret0, _ := ret[0].(core.Dictionaries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dictionaries indicates an expected call of Dictionaries.
+func (mr *MockDictionaryEditorMockRecorder) Dictionaries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dictionaries", reflect.TypeOf((*MockDictionaryEditor)(nil).Dictionaries), arg0)
+}
+
+// DictionaryVersion mocks base method.
+func (m *MockDictionaryEditor) DictionaryVersion(arg0 context.Context) (core.DictionaryVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DictionaryVersion", arg0)
+	ret0, _ := ret[0].(core.DictionaryVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DictionaryVersion indicates an expected call of DictionaryVersion.
+func (mr *MockDictionaryEditorMockRecorder) DictionaryVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DictionaryVersion", reflect.TypeOf((*MockDictionaryEditor)(nil).DictionaryVersion), arg0)
+}
+
+// SetDictionaries mocks base method.
+func (m *MockDictionaryEditor) SetDictionaries(ctx context.Context, dicts core.Dictionaries, author string) (core.DictionaryVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDictionaries", ctx, dicts, author)
+	ret0, _ := ret[0].(core.DictionaryVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDictionaries indicates an expected call of SetDictionaries.
+func (mr *MockDictionaryEditorMockRecorder) SetDictionaries(ctx, dicts, author interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDictionaries", reflect.TypeOf((*MockDictionaryEditor)(nil).SetDictionaries), ctx, dicts, author)
 }
 
 // MockPinger is a mock of Pinger interface.
@@ -229,34 +313,196 @@ func (m *MockSearcher) EXPECT() *MockSearcherMockRecorder {
 	return m.recorder
 }
 
+// Analytics mocks base method.
+func (m *MockSearcher) Analytics(arg0 context.Context, arg1 time.Duration, arg2 int32) (core.Analytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Analytics", arg0, arg1, arg2)
+	ret0, _ := ret[0].(core.Analytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Analytics indicates an expected call of Analytics.
+func (mr *MockSearcherMockRecorder) Analytics(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Analytics", reflect.TypeOf((*MockSearcher)(nil).Analytics), arg0, arg1, arg2)
+}
+
+// Correct mocks base method.
+func (m *MockSearcher) Correct(arg0 context.Context, arg1 string) (core.Corrected, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Correct", arg0, arg1)
+	ret0, _ := ret[0].(core.Corrected)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Correct indicates an expected call of Correct.
+func (mr *MockSearcherMockRecorder) Correct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correct", reflect.TypeOf((*MockSearcher)(nil).Correct), arg0, arg1)
+}
+
+// Generation mocks base method.
+func (m *MockSearcher) Generation(arg0 context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generation", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generation indicates an expected call of Generation.
+func (mr *MockSearcherMockRecorder) Generation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generation", reflect.TypeOf((*MockSearcher)(nil).Generation), arg0)
+}
+
+// GetComic mocks base method.
+func (m *MockSearcher) GetComic(arg0 context.Context, arg1 int) (core.ComicDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComic", arg0, arg1)
+	ret0, _ := ret[0].(core.ComicDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComic indicates an expected call of GetComic.
+func (mr *MockSearcherMockRecorder) GetComic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComic", reflect.TypeOf((*MockSearcher)(nil).GetComic), arg0, arg1)
+}
+
 // IndexSearch mocks base method.
-func (m *MockSearcher) IndexSearch(arg0 context.Context, arg1 string, arg2 int32) ([]core.Comics, int32, error) {
+func (m *MockSearcher) IndexSearch(arg0 context.Context, arg1 string, arg2 int32, arg3 core.SearchOptions) (core.SearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IndexSearch", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]core.Comics)
-	ret1, _ := ret[1].(int32)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "IndexSearch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(core.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // IndexSearch indicates an expected call of IndexSearch.
-func (mr *MockSearcherMockRecorder) IndexSearch(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockSearcherMockRecorder) IndexSearch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexSearch", reflect.TypeOf((*MockSearcher)(nil).IndexSearch), arg0, arg1, arg2, arg3)
+}
+
+// IndexStats mocks base method.
+func (m *MockSearcher) IndexStats(arg0 context.Context, arg1 int32) (core.IndexStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexStats", arg0, arg1)
+	ret0, _ := ret[0].(core.IndexStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexStats indicates an expected call of IndexStats.
+func (mr *MockSearcherMockRecorder) IndexStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexStats", reflect.TypeOf((*MockSearcher)(nil).IndexStats), arg0, arg1)
+}
+
+// RandomComic mocks base method.
+func (m *MockSearcher) RandomComic(arg0 context.Context) (core.ComicDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RandomComic", arg0)
+	ret0, _ := ret[0].(core.ComicDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RandomComic indicates an expected call of RandomComic.
+func (mr *MockSearcherMockRecorder) RandomComic(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexSearch", reflect.TypeOf((*MockSearcher)(nil).IndexSearch), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomComic", reflect.TypeOf((*MockSearcher)(nil).RandomComic), arg0)
 }
 
 // Search mocks base method.
-func (m *MockSearcher) Search(arg0 context.Context, arg1 string, arg2 int32) ([]core.Comics, int32, error) {
+func (m *MockSearcher) Search(arg0 context.Context, arg1 string, arg2 int32, arg3 core.SearchOptions) (core.SearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]core.Comics)
-	ret1, _ := ret[1].(int32)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(core.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockSearcherMockRecorder) Search(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockSearcherMockRecorder) Search(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearcher)(nil).Search), arg0, arg1, arg2, arg3)
+}
+
+// SetSynonyms mocks base method.
+func (m *MockSearcher) SetSynonyms(arg0 context.Context, arg1 [][]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSynonyms", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSynonyms indicates an expected call of SetSynonyms.
+func (mr *MockSearcherMockRecorder) SetSynonyms(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSynonyms", reflect.TypeOf((*MockSearcher)(nil).SetSynonyms), arg0, arg1)
+}
+
+// Similar mocks base method.
+func (m *MockSearcher) Similar(arg0 context.Context, arg1 int, arg2 int32) (core.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Similar", arg0, arg1, arg2)
+	ret0, _ := ret[0].(core.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Similar indicates an expected call of Similar.
+func (mr *MockSearcherMockRecorder) Similar(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Similar", reflect.TypeOf((*MockSearcher)(nil).Similar), arg0, arg1, arg2)
+}
+
+// Suggest mocks base method.
+func (m *MockSearcher) Suggest(arg0 context.Context, arg1 string, arg2 int32) ([]core.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]core.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSearcherMockRecorder) Suggest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSearcher)(nil).Suggest), arg0, arg1, arg2)
+}
+
+// Synonyms mocks base method.
+func (m *MockSearcher) Synonyms(arg0 context.Context) ([][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Synonyms", arg0)
+	ret0, _ := ret[0].([][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Synonyms indicates an expected call of Synonyms.
+func (mr *MockSearcherMockRecorder) Synonyms(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Synonyms", reflect.TypeOf((*MockSearcher)(nil).Synonyms), arg0)
+}
+
+// TermInfo mocks base method.
+func (m *MockSearcher) TermInfo(arg0 context.Context, arg1 string, arg2 int32) (core.TermInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TermInfo", arg0, arg1, arg2)
+	ret0, _ := ret[0].(core.TermInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TermInfo indicates an expected call of TermInfo.
+func (mr *MockSearcherMockRecorder) TermInfo(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearcher)(nil).Search), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TermInfo", reflect.TypeOf((*MockSearcher)(nil).TermInfo), arg0, arg1, arg2)
 }
@@ -37,6 +37,86 @@ func (m *MockSearchClient) EXPECT() *MockSearchClientMockRecorder {
 	return m.recorder
 }
 
+// Analytics mocks base method.
+func (m *MockSearchClient) Analytics(ctx context.Context, in *search.AnalyticsRequest, opts ...grpc.CallOption) (*search.AnalyticsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Analytics", varargs...)
+	ret0, _ := ret[0].(*search.AnalyticsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Analytics indicates an expected call of Analytics.
+func (mr *MockSearchClientMockRecorder) Analytics(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Analytics", reflect.TypeOf((*MockSearchClient)(nil).Analytics), varargs...)
+}
+
+// Correct mocks base method.
+func (m *MockSearchClient) Correct(ctx context.Context, in *search.CorrectRequest, opts ...grpc.CallOption) (*search.CorrectResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Correct", varargs...)
+	ret0, _ := ret[0].(*search.CorrectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Correct indicates an expected call of Correct.
+func (mr *MockSearchClientMockRecorder) Correct(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correct", reflect.TypeOf((*MockSearchClient)(nil).Correct), varargs...)
+}
+
+// Generation mocks base method.
+func (m *MockSearchClient) Generation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*search.GenerationResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Generation", varargs...)
+	ret0, _ := ret[0].(*search.GenerationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generation indicates an expected call of Generation.
+func (mr *MockSearchClientMockRecorder) Generation(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generation", reflect.TypeOf((*MockSearchClient)(nil).Generation), varargs...)
+}
+
+// GetComic mocks base method.
+func (m *MockSearchClient) GetComic(ctx context.Context, in *search.GetComicRequest, opts ...grpc.CallOption) (*search.ComicDetail, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetComic", varargs...)
+	ret0, _ := ret[0].(*search.ComicDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComic indicates an expected call of GetComic.
+func (mr *MockSearchClientMockRecorder) GetComic(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComic", reflect.TypeOf((*MockSearchClient)(nil).GetComic), varargs...)
+}
+
 // IndexSearch mocks base method.
 func (m *MockSearchClient) IndexSearch(ctx context.Context, in *search.IndexSearchRequest, opts ...grpc.CallOption) (*search.SearchResponse, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +137,26 @@ func (mr *MockSearchClientMockRecorder) IndexSearch(ctx, in interface{}, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexSearch", reflect.TypeOf((*MockSearchClient)(nil).IndexSearch), varargs...)
 }
 
+// IndexStats mocks base method.
+func (m *MockSearchClient) IndexStats(ctx context.Context, in *search.IndexStatsRequest, opts ...grpc.CallOption) (*search.IndexStatsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IndexStats", varargs...)
+	ret0, _ := ret[0].(*search.IndexStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexStats indicates an expected call of IndexStats.
+func (mr *MockSearchClientMockRecorder) IndexStats(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexStats", reflect.TypeOf((*MockSearchClient)(nil).IndexStats), varargs...)
+}
+
 // Ping mocks base method.
 func (m *MockSearchClient) Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +177,26 @@ func (mr *MockSearchClientMockRecorder) Ping(ctx, in interface{}, opts ...interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockSearchClient)(nil).Ping), varargs...)
 }
 
+// RandomComic mocks base method.
+func (m *MockSearchClient) RandomComic(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*search.ComicDetail, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RandomComic", varargs...)
+	ret0, _ := ret[0].(*search.ComicDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RandomComic indicates an expected call of RandomComic.
+func (mr *MockSearchClientMockRecorder) RandomComic(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomComic", reflect.TypeOf((*MockSearchClient)(nil).RandomComic), varargs...)
+}
+
 // Search mocks base method.
 func (m *MockSearchClient) Search(ctx context.Context, in *search.SearchRequest, opts ...grpc.CallOption) (*search.SearchResponse, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +217,106 @@ func (mr *MockSearchClientMockRecorder) Search(ctx, in interface{}, opts ...inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchClient)(nil).Search), varargs...)
 }
 
+// SetSynonyms mocks base method.
+func (m *MockSearchClient) SetSynonyms(ctx context.Context, in *search.SynonymGroups, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetSynonyms", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSynonyms indicates an expected call of SetSynonyms.
+func (mr *MockSearchClientMockRecorder) SetSynonyms(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSynonyms", reflect.TypeOf((*MockSearchClient)(nil).SetSynonyms), varargs...)
+}
+
+// Similar mocks base method.
+func (m *MockSearchClient) Similar(ctx context.Context, in *search.SimilarRequest, opts ...grpc.CallOption) (*search.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Similar", varargs...)
+	ret0, _ := ret[0].(*search.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Similar indicates an expected call of Similar.
+func (mr *MockSearchClientMockRecorder) Similar(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Similar", reflect.TypeOf((*MockSearchClient)(nil).Similar), varargs...)
+}
+
+// Suggest mocks base method.
+func (m *MockSearchClient) Suggest(ctx context.Context, in *search.SuggestRequest, opts ...grpc.CallOption) (*search.SuggestResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Suggest", varargs...)
+	ret0, _ := ret[0].(*search.SuggestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSearchClientMockRecorder) Suggest(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSearchClient)(nil).Suggest), varargs...)
+}
+
+// Synonyms mocks base method.
+func (m *MockSearchClient) Synonyms(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*search.SynonymGroups, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Synonyms", varargs...)
+	ret0, _ := ret[0].(*search.SynonymGroups)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Synonyms indicates an expected call of Synonyms.
+func (mr *MockSearchClientMockRecorder) Synonyms(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Synonyms", reflect.TypeOf((*MockSearchClient)(nil).Synonyms), varargs...)
+}
+
+// TermInfo mocks base method.
+func (m *MockSearchClient) TermInfo(ctx context.Context, in *search.TermInfoRequest, opts ...grpc.CallOption) (*search.TermInfoResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TermInfo", varargs...)
+	ret0, _ := ret[0].(*search.TermInfoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TermInfo indicates an expected call of TermInfo.
+func (mr *MockSearchClientMockRecorder) TermInfo(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TermInfo", reflect.TypeOf((*MockSearchClient)(nil).TermInfo), varargs...)
+}
+
 // MockSearchServer is a mock of SearchServer interface.
 type MockSearchServer struct {
 	ctrl     *gomock.Controller
@@ -120,6 +340,66 @@ func (m *MockSearchServer) EXPECT() *MockSearchServerMockRecorder {
 	return m.recorder
 }
 
+// Analytics mocks base method.
+func (m *MockSearchServer) Analytics(arg0 context.Context, arg1 *search.AnalyticsRequest) (*search.AnalyticsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Analytics", arg0, arg1)
+	ret0, _ := ret[0].(*search.AnalyticsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Analytics indicates an expected call of Analytics.
+func (mr *MockSearchServerMockRecorder) Analytics(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Analytics", reflect.TypeOf((*MockSearchServer)(nil).Analytics), arg0, arg1)
+}
+
+// Correct mocks base method.
+func (m *MockSearchServer) Correct(arg0 context.Context, arg1 *search.CorrectRequest) (*search.CorrectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Correct", arg0, arg1)
+	ret0, _ := ret[0].(*search.CorrectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Correct indicates an expected call of Correct.
+func (mr *MockSearchServerMockRecorder) Correct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correct", reflect.TypeOf((*MockSearchServer)(nil).Correct), arg0, arg1)
+}
+
+// Generation mocks base method.
+func (m *MockSearchServer) Generation(arg0 context.Context, arg1 *emptypb.Empty) (*search.GenerationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generation", arg0, arg1)
+	ret0, _ := ret[0].(*search.GenerationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generation indicates an expected call of Generation.
+func (mr *MockSearchServerMockRecorder) Generation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generation", reflect.TypeOf((*MockSearchServer)(nil).Generation), arg0, arg1)
+}
+
+// GetComic mocks base method.
+func (m *MockSearchServer) GetComic(arg0 context.Context, arg1 *search.GetComicRequest) (*search.ComicDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComic", arg0, arg1)
+	ret0, _ := ret[0].(*search.ComicDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComic indicates an expected call of GetComic.
+func (mr *MockSearchServerMockRecorder) GetComic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComic", reflect.TypeOf((*MockSearchServer)(nil).GetComic), arg0, arg1)
+}
+
 // IndexSearch mocks base method.
 func (m *MockSearchServer) IndexSearch(arg0 context.Context, arg1 *search.IndexSearchRequest) (*search.SearchResponse, error) {
 	m.ctrl.T.Helper()
@@ -135,6 +415,21 @@ func (mr *MockSearchServerMockRecorder) IndexSearch(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexSearch", reflect.TypeOf((*MockSearchServer)(nil).IndexSearch), arg0, arg1)
 }
 
+// IndexStats mocks base method.
+func (m *MockSearchServer) IndexStats(arg0 context.Context, arg1 *search.IndexStatsRequest) (*search.IndexStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexStats", arg0, arg1)
+	ret0, _ := ret[0].(*search.IndexStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexStats indicates an expected call of IndexStats.
+func (mr *MockSearchServerMockRecorder) IndexStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexStats", reflect.TypeOf((*MockSearchServer)(nil).IndexStats), arg0, arg1)
+}
+
 // Ping mocks base method.
 func (m *MockSearchServer) Ping(arg0 context.Context, arg1 *emptypb.Empty) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -150,6 +445,21 @@ func (mr *MockSearchServerMockRecorder) Ping(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockSearchServer)(nil).Ping), arg0, arg1)
 }
 
+// RandomComic mocks base method.
+func (m *MockSearchServer) RandomComic(arg0 context.Context, arg1 *emptypb.Empty) (*search.ComicDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RandomComic", arg0, arg1)
+	ret0, _ := ret[0].(*search.ComicDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RandomComic indicates an expected call of RandomComic.
+func (mr *MockSearchServerMockRecorder) RandomComic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomComic", reflect.TypeOf((*MockSearchServer)(nil).RandomComic), arg0, arg1)
+}
+
 // Search mocks base method.
 func (m *MockSearchServer) Search(arg0 context.Context, arg1 *search.SearchRequest) (*search.SearchResponse, error) {
 	m.ctrl.T.Helper()
@@ -165,6 +475,81 @@ func (mr *MockSearchServerMockRecorder) Search(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchServer)(nil).Search), arg0, arg1)
 }
 
+// SetSynonyms mocks base method.
+func (m *MockSearchServer) SetSynonyms(arg0 context.Context, arg1 *search.SynonymGroups) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSynonyms", arg0, arg1)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSynonyms indicates an expected call of SetSynonyms.
+func (mr *MockSearchServerMockRecorder) SetSynonyms(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSynonyms", reflect.TypeOf((*MockSearchServer)(nil).SetSynonyms), arg0, arg1)
+}
+
+// Similar mocks base method.
+func (m *MockSearchServer) Similar(arg0 context.Context, arg1 *search.SimilarRequest) (*search.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Similar", arg0, arg1)
+	ret0, _ := ret[0].(*search.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Similar indicates an expected call of Similar.
+func (mr *MockSearchServerMockRecorder) Similar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Similar", reflect.TypeOf((*MockSearchServer)(nil).Similar), arg0, arg1)
+}
+
+// Suggest mocks base method.
+func (m *MockSearchServer) Suggest(arg0 context.Context, arg1 *search.SuggestRequest) (*search.SuggestResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", arg0, arg1)
+	ret0, _ := ret[0].(*search.SuggestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSearchServerMockRecorder) Suggest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSearchServer)(nil).Suggest), arg0, arg1)
+}
+
+// Synonyms mocks base method.
+func (m *MockSearchServer) Synonyms(arg0 context.Context, arg1 *emptypb.Empty) (*search.SynonymGroups, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Synonyms", arg0, arg1)
+	ret0, _ := ret[0].(*search.SynonymGroups)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Synonyms indicates an expected call of Synonyms.
+func (mr *MockSearchServerMockRecorder) Synonyms(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Synonyms", reflect.TypeOf((*MockSearchServer)(nil).Synonyms), arg0, arg1)
+}
+
+// TermInfo mocks base method.
+func (m *MockSearchServer) TermInfo(arg0 context.Context, arg1 *search.TermInfoRequest) (*search.TermInfoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TermInfo", arg0, arg1)
+	ret0, _ := ret[0].(*search.TermInfoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TermInfo indicates an expected call of TermInfo.
+func (mr *MockSearchServerMockRecorder) TermInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TermInfo", reflect.TypeOf((*MockSearchServer)(nil).TermInfo), arg0, arg1)
+}
+
 // mustEmbedUnimplementedSearchServer mocks base method.
 func (m *MockSearchServer) mustEmbedUnimplementedSearchServer() {
 	m.ctrl.T.Helper()
@@ -3,6 +3,7 @@ package search
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -42,57 +43,384 @@ func (c Client) Ping(ctx context.Context) error {
 	return nil
 }
 
-func (c Client) Search(ctx context.Context, phrase string, limit int32) ([]core.Comics, int32, error) {
-	c.log.Debug("calling Search", "phrase", phrase, "limit", limit)
+func (c Client) Search(ctx context.Context, phrase string, limit int32, opts core.SearchOptions) (core.SearchResult, error) {
+	c.log.Debug("calling Search", "phrase", phrase, "limit", limit, "fuzzy", opts.Fuzzy)
 	resp, err := c.client.Search(ctx, &searchpb.SearchRequest{
-		Phrase: phrase,
-		Limit:  limit,
+		Phrase:  phrase,
+		Limit:   limit,
+		Fuzzy:   opts.Fuzzy,
+		Weights: opts.Weights,
+		From:    formatDate(opts.From),
+		To:      formatDate(opts.To),
+		Sort:    opts.Sort,
+		Explain: opts.Explain,
+		Mode:    opts.Mode,
 	})
 	if err != nil {
 		if status.Code(err) == codes.InvalidArgument {
 			c.log.Warn("invalid argument", "error", err)
-			return nil, 0, core.ErrBadArguments
+			return core.SearchResult{}, core.ErrBadArguments
 		}
 		c.log.Error("error calling Search", "error", err)
-		return nil, 0, err
-	}
-
-	var comics []core.Comics
-	for _, comic := range resp.Comics {
-		comics = append(comics, core.Comics{
-			ID:  int(comic.Id),
-			URL: comic.Url,
-		})
+		return core.SearchResult{}, err
 	}
 
 	c.log.Debug("successfully searched comics", "total", resp.Total)
-	return comics, resp.Total, nil
+	return searchResult(resp), nil
 }
 
-func (c Client) IndexSearch(ctx context.Context, phrase string, limit int32) ([]core.Comics, int32, error) {
-	c.log.Debug("calling IndexSearch", "phrase", phrase, "limit", limit)
+func (c Client) IndexSearch(ctx context.Context, phrase string, limit int32, opts core.SearchOptions) (core.SearchResult, error) {
+	c.log.Debug("calling IndexSearch", "phrase", phrase, "limit", limit, "fuzzy", opts.Fuzzy)
 
 	resp, err := c.client.IndexSearch(ctx, &searchpb.IndexSearchRequest{
-		Phrase: phrase,
-		Limit:  limit,
+		Phrase:  phrase,
+		Limit:   limit,
+		Fuzzy:   opts.Fuzzy,
+		Weights: opts.Weights,
+		From:    formatDate(opts.From),
+		To:      formatDate(opts.To),
+		Sort:    opts.Sort,
+		Explain: opts.Explain,
+		Mode:    opts.Mode,
 	})
 	if err != nil {
 		if status.Code(err) == codes.InvalidArgument {
 			c.log.Warn("invalid argument in IndexSearch", "error", err)
-			return nil, 0, core.ErrBadArguments
+			return core.SearchResult{}, core.ErrBadArguments
 		}
 		c.log.Error("error calling IndexSearch", "error", err)
-		return nil, 0, err
+		return core.SearchResult{}, err
 	}
 
-	var comics []core.Comics
-	for _, comic := range resp.Comics {
-		comics = append(comics, core.Comics{
-			ID:  int(comic.Id),
-			URL: comic.Url,
+	c.log.Debug("successfully searched comics via index", "total", resp.Total)
+	return searchResult(resp), nil
+}
+
+func (c Client) Suggest(ctx context.Context, prefix string, limit int32) ([]core.Suggestion, error) {
+	c.log.Debug("calling Suggest", "prefix", prefix, "limit", limit)
+
+	resp, err := c.client.Suggest(ctx, &searchpb.SuggestRequest{
+		Prefix: prefix,
+		Limit:  limit,
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			c.log.Warn("invalid argument in Suggest", "error", err)
+			return nil, core.ErrBadArguments
+		}
+		c.log.Error("error calling Suggest", "error", err)
+		return nil, err
+	}
+
+	suggestions := make([]core.Suggestion, 0, len(resp.Suggestions))
+	for _, s := range resp.Suggestions {
+		suggestions = append(suggestions, core.Suggestion{
+			Word:  s.Word,
+			Count: int(s.Count),
 		})
 	}
+	return suggestions, nil
+}
 
-	c.log.Debug("successfully searched comics via index", "total", resp.Total)
-	return comics, resp.Total, nil
+func (c Client) Similar(ctx context.Context, id int, limit int32) (core.SearchResult, error) {
+	c.log.Debug("calling Similar", "id", id, "limit", limit)
+
+	resp, err := c.client.Similar(ctx, &searchpb.SimilarRequest{
+		Id:    int32(id),
+		Limit: limit,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			c.log.Warn("invalid argument in Similar", "error", err)
+			return core.SearchResult{}, core.ErrBadArguments
+		case codes.NotFound:
+			c.log.Warn("comic not found", "id", id)
+			return core.SearchResult{}, core.ErrNotFound
+		}
+		c.log.Error("error calling Similar", "error", err)
+		return core.SearchResult{}, err
+	}
+	return searchResult(resp), nil
+}
+
+func (c Client) GetComic(ctx context.Context, id int) (core.ComicDetail, error) {
+	c.log.Debug("calling GetComic", "id", id)
+
+	resp, err := c.client.GetComic(ctx, &searchpb.GetComicRequest{Id: int32(id)})
+	if err != nil {
+		return core.ComicDetail{}, c.lookupError("GetComic", err)
+	}
+	return comicDetail(resp), nil
+}
+
+func (c Client) RandomComic(ctx context.Context) (core.ComicDetail, error) {
+	c.log.Debug("calling RandomComic")
+
+	resp, err := c.client.RandomComic(ctx, &emptypb.Empty{})
+	if err != nil {
+		return core.ComicDetail{}, c.lookupError("RandomComic", err)
+	}
+	return comicDetail(resp), nil
+}
+
+func (c Client) IndexStats(ctx context.Context, limit int32) (core.IndexStats, error) {
+	c.log.Debug("calling IndexStats", "limit", limit)
+
+	resp, err := c.client.IndexStats(ctx, &searchpb.IndexStatsRequest{Limit: limit})
+	if err != nil {
+		c.log.Error("error calling IndexStats", "error", err)
+		return core.IndexStats{}, err
+	}
+	return core.IndexStats{
+		Generation:      resp.Generation,
+		BuiltAt:         resp.BuiltAt,
+		BuildDurationMs: resp.BuildDurationMs,
+		Documents:       int(resp.Documents),
+		Terms:           int(resp.Terms),
+		TopTerms:        termStats(resp.TopTerms),
+		RareTerms:       termStats(resp.RareTerms),
+		Cache: core.CacheStats{
+			Hits:     resp.Cache.GetHits(),
+			Misses:   resp.Cache.GetMisses(),
+			Entries:  int(resp.Cache.GetEntries()),
+			Capacity: int(resp.Cache.GetCapacity()),
+		},
+		WordsClient: core.WordsClientStats{
+			Hits:          resp.WordsClient.GetHits(),
+			Misses:        resp.WordsClient.GetMisses(),
+			Entries:       int(resp.WordsClient.GetEntries()),
+			Capacity:      int(resp.WordsClient.GetCapacity()),
+			Invalidations: resp.WordsClient.GetInvalidations(),
+			Retries:       resp.WordsClient.GetRetries(),
+		},
+	}, nil
+}
+
+func (c Client) Generation(ctx context.Context) (uint64, error) {
+	resp, err := c.client.Generation(ctx, &emptypb.Empty{})
+	if err != nil {
+		c.log.Error("error calling Generation", "error", err)
+		return 0, err
+	}
+	return resp.Generation, nil
+}
+
+func (c Client) TermInfo(ctx context.Context, term string, limit int32) (core.TermInfo, error) {
+	c.log.Debug("calling TermInfo", "term", term, "limit", limit)
+
+	resp, err := c.client.TermInfo(ctx, &searchpb.TermInfoRequest{Term: term, Limit: limit})
+	if err != nil {
+		return core.TermInfo{}, c.lookupError("TermInfo", err)
+	}
+
+	info := core.TermInfo{
+		Form:     resp.Form,
+		IDF:      resp.Idf,
+		Postings: make([]core.Posting, len(resp.Postings)),
+	}
+	if stats := termStats([]*searchpb.TermStats{resp.Stats}); len(stats) > 0 {
+		info.TermStats = stats[0]
+	}
+	for i, p := range resp.Postings {
+		info.Postings[i] = core.Posting{ID: int(p.Id), Freq: int(p.Freq), Fields: p.Fields}
+	}
+	return info, nil
+}
+
+func (c Client) Correct(ctx context.Context, phrase string) (core.Corrected, error) {
+	c.log.Debug("calling Correct", "phrase", phrase)
+
+	resp, err := c.client.Correct(ctx, &searchpb.CorrectRequest{Phrase: phrase})
+	if err != nil {
+		return core.Corrected{}, c.lookupError("Correct", err)
+	}
+
+	corrected := core.Corrected{
+		Phrase:      resp.Phrase,
+		Corrections: make([]core.Correction, len(resp.Corrections)),
+		Generation:  resp.Generation,
+	}
+	for i, c := range resp.Corrections {
+		corrected.Corrections[i] = core.Correction{
+			Word:       c.Word,
+			Correction: c.Correction,
+			Distance:   int(c.Distance),
+			Count:      int(c.Count),
+		}
+	}
+	return corrected, nil
+}
+
+func (c Client) Analytics(ctx context.Context, window time.Duration, limit int32) (core.Analytics, error) {
+	c.log.Debug("calling Analytics", "window", window, "limit", limit)
+
+	resp, err := c.client.Analytics(ctx, &searchpb.AnalyticsRequest{
+		WindowSeconds: int64(window / time.Second),
+		Limit:         limit,
+	})
+	if err != nil {
+		return core.Analytics{}, c.lookupError("Analytics", err)
+	}
+
+	analytics := core.Analytics{
+		Since:       resp.Since,
+		Queries:     int(resp.Queries),
+		TopQueries:  queryCounts(resp.TopQueries),
+		ZeroResults: queryCounts(resp.ZeroResults),
+		Latency:     make([]core.LatencyStats, len(resp.Latency)),
+	}
+	for i, l := range resp.Latency {
+		analytics.Latency[i] = core.LatencyStats{
+			Mode:  l.Mode,
+			Count: int(l.Count),
+			P50Ms: l.P50Ms,
+			P90Ms: l.P90Ms,
+			P99Ms: l.P99Ms,
+		}
+	}
+	return analytics, nil
+}
+
+func (c Client) Synonyms(ctx context.Context) ([][]string, error) {
+	c.log.Debug("calling Synonyms")
+
+	resp, err := c.client.Synonyms(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, c.lookupError("Synonyms", err)
+	}
+	groups := make([][]string, len(resp.Groups))
+	for i, g := range resp.Groups {
+		groups[i] = g.Words
+	}
+	return groups, nil
+}
+
+func (c Client) SetSynonyms(ctx context.Context, groups [][]string) error {
+	c.log.Debug("calling SetSynonyms", "groups", len(groups))
+
+	req := &searchpb.SynonymGroups{Groups: make([]*searchpb.SynonymGroup, len(groups))}
+	for i, words := range groups {
+		req.Groups[i] = &searchpb.SynonymGroup{Words: words}
+	}
+	if _, err := c.client.SetSynonyms(ctx, req); err != nil {
+		return c.lookupError("SetSynonyms", err)
+	}
+	return nil
+}
+
+func queryCounts(counts []*searchpb.QueryCount) []core.QueryCount {
+	result := make([]core.QueryCount, len(counts))
+	for i, c := range counts {
+		result[i] = core.QueryCount{Phrase: c.Phrase, Count: int(c.Count)}
+	}
+	return result
+}
+
+func termStats(terms []*searchpb.TermStats) []core.TermStats {
+	stats := make([]core.TermStats, 0, len(terms))
+	for _, t := range terms {
+		if t == nil {
+			continue
+		}
+		stats = append(stats, core.TermStats{Term: t.Term, Docs: int(t.Docs), Total: int(t.Total)})
+	}
+	return stats
+}
+
+func (c Client) lookupError(method string, err error) error {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		c.log.Warn("invalid argument in "+method, "error", err)
+		return core.ErrBadArguments
+	case codes.NotFound:
+		c.log.Warn("not found in "+method, "error", err)
+		return core.ErrNotFound
+	}
+	c.log.Error("error calling "+method, "error", err)
+	return err
+}
+
+func comicDetail(resp *searchpb.ComicDetail) core.ComicDetail {
+	comic := core.ComicDetail{
+		ID:              int(resp.Id),
+		URL:             resp.Url,
+		Title:           resp.Title,
+		Alt:             resp.Alt,
+		Transcript:      resp.Transcript,
+		Date:            resp.Date,
+		Words:           resp.Words,
+		Forms:           resp.Forms,
+		TitleWords:      resp.TitleWords,
+		AltWords:        resp.AltWords,
+		TranscriptWords: resp.TranscriptWords,
+		Bigrams:         resp.Bigrams,
+		DialogueWords:   resp.DialogueWords,
+		SceneWords:      resp.SceneWords,
+		Speakers:        resp.Speakers,
+		Scenes:          resp.Scenes,
+		Prev:            int(resp.Prev),
+		Next:            int(resp.Next),
+	}
+	for _, line := range resp.Dialogue {
+		comic.Dialogue = append(comic.Dialogue, core.Line{Speaker: line.Speaker, Text: line.Text, Words: line.Words})
+	}
+	return comic
+}
+
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(time.DateOnly)
+}
+
+func searchResult(resp *searchpb.SearchResponse) core.SearchResult {
+	var comics []core.Comics
+	for _, comic := range resp.Comics {
+		c := core.Comics{
+			ID:    int(comic.Id),
+			URL:   comic.Url,
+			Date:  comic.Date,
+			Score: comic.Score,
+			Terms: comic.Terms,
+		}
+		if s := comic.Snippet; s != nil {
+			c.Snippet = &core.Snippet{Field: s.Field, Text: s.Text, Highlights: []core.Span{}}
+			for _, h := range s.Highlights {
+				c.Snippet.Highlights = append(c.Snippet.Highlights, core.Span{Start: int(h.Start), End: int(h.End)})
+			}
+		}
+		if e := comic.Explanation; e != nil {
+			c.Explanation = &core.Explanation{
+				Terms:   make([]core.TermMatch, 0, len(e.Terms)),
+				Unique:  int(e.Unique),
+				Phrases: e.Phrases,
+				Weight:  e.Weight,
+				Total:   int(e.Total),
+				Rank:    e.Rank,
+			}
+			for _, t := range e.Terms {
+				c.Explanation.Terms = append(c.Explanation.Terms, core.TermMatch{
+					Term:    t.Term,
+					Origin:  t.Origin,
+					Fields:  t.Fields,
+					Matched: t.Matched,
+					Freq:    int(t.Freq),
+					Weight:  t.Weight,
+				})
+			}
+		}
+		comics = append(comics, c)
+	}
+	result := core.SearchResult{
+		Comics:     comics,
+		Total:      resp.Total,
+		Suggestion: resp.Suggestion,
+		Generation: resp.Generation,
+	}
+	if e := resp.Explain; e != nil {
+		result.Explain = &core.QueryExplanation{Path: e.Path, Generation: e.Generation, Terms: e.Terms, Phrases: e.Phrases}
+	}
+	return result
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -81,12 +82,13 @@ func TestClient_Search_Success(t *testing.T) {
 		Search(gomock.Any(), req).
 		Return(resp, nil)
 
-	comics, total, err := client.Search(context.Background(), "xkcd", 10)
+	result, err := client.Search(context.Background(), "xkcd", 10, core.SearchOptions{})
 	assert.NoError(t, err)
-	assert.Equal(t, int32(2), total)
-	assert.Len(t, comics, 2)
-	assert.Equal(t, 1, comics[0].ID)
-	assert.Equal(t, "http://example.com/1", comics[0].URL)
+	assert.Equal(t, int32(2), result.Total)
+	assert.Len(t, result.Comics, 2)
+	assert.Equal(t, 1, result.Comics[0].ID)
+	assert.Equal(t, "http://example.com/1", result.Comics[0].URL)
+	assert.Empty(t, result.Suggestion)
 }
 
 func TestClient_Search_InvalidArgument(t *testing.T) {
@@ -103,7 +105,7 @@ func TestClient_Search_InvalidArgument(t *testing.T) {
 		Search(gomock.Any(), gomock.Any()).
 		Return(nil, status.Error(codes.InvalidArgument, "bad phrase"))
 
-	_, _, err := client.Search(context.Background(), "", 10)
+	_, err := client.Search(context.Background(), "", 10, core.SearchOptions{})
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, core.ErrBadArguments))
 }
@@ -119,25 +121,51 @@ func TestClient_IndexSearch_Success(t *testing.T) {
 	}
 
 	req := &searchpb.IndexSearchRequest{
-		Phrase: "xkcd",
-		Limit:  5,
+		Phrase:  "xkcb",
+		Limit:   5,
+		Fuzzy:   true,
+		Weights: map[string]float64{"title": 4},
+		From:    "2008-01-01",
+		Sort:    "oldest",
 	}
 	resp := &searchpb.SearchResponse{
-		Comics: []*searchpb.Comic{
-			{Id: 3, Url: "http://example.com/3"},
-		},
-		Total: 1,
+		Comics: []*searchpb.Comic{{
+			Id:    3,
+			Url:   "http://example.com/3",
+			Date:  "2008-02-03",
+			Terms: []string{"xkcd"},
+			Snippet: &searchpb.Snippet{
+				Field:      "title",
+				Text:       "About xkcd",
+				Highlights: []*searchpb.Highlight{{Start: 6, End: 10}},
+			},
+		}},
+		Total:      1,
+		Suggestion: "xkcd",
 	}
 
 	mockClient.EXPECT().
 		IndexSearch(gomock.Any(), req).
 		Return(resp, nil)
 
-	comics, total, err := client.IndexSearch(context.Background(), "xkcd", 5)
+	result, err := client.IndexSearch(context.Background(), "xkcb", 5, core.SearchOptions{
+		Fuzzy:   true,
+		Weights: map[string]float64{"title": 4},
+		From:    time.Date(2008, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Sort:    "oldest",
+	})
 	assert.NoError(t, err)
-	assert.Equal(t, int32(1), total)
-	assert.Len(t, comics, 1)
-	assert.Equal(t, 3, comics[0].ID)
+	assert.Equal(t, int32(1), result.Total)
+	assert.Len(t, result.Comics, 1)
+	assert.Equal(t, 3, result.Comics[0].ID)
+	assert.Equal(t, "2008-02-03", result.Comics[0].Date)
+	assert.Equal(t, []string{"xkcd"}, result.Comics[0].Terms)
+	assert.Equal(t, &core.Snippet{
+		Field:      "title",
+		Text:       "About xkcd",
+		Highlights: []core.Span{{Start: 6, End: 10}},
+	}, result.Comics[0].Snippet)
+	assert.Equal(t, "xkcd", result.Suggestion)
 }
 
 func TestClient_IndexSearch_Error(t *testing.T) {
@@ -154,7 +182,392 @@ func TestClient_IndexSearch_Error(t *testing.T) {
 		IndexSearch(gomock.Any(), gomock.Any()).
 		Return(nil, status.Error(codes.Internal, "indexing failed"))
 
-	_, _, err := client.IndexSearch(context.Background(), "xkcd", 5)
+	_, err := client.IndexSearch(context.Background(), "xkcd", 5, core.SearchOptions{})
 	assert.Error(t, err)
 	assert.Equal(t, codes.Internal, status.Code(err))
 }
+
+func TestClient_IndexSearch_Explain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocksearch.NewMockSearchClient(ctrl)
+	client := &Client{
+		client: mockClient,
+		log:    slog.Default(),
+	}
+
+	mockClient.EXPECT().
+		IndexSearch(gomock.Any(), &searchpb.IndexSearchRequest{Phrase: "robot", Limit: 1, Explain: true}).
+		Return(&searchpb.SearchResponse{
+			Comics: []*searchpb.Comic{{Id: 1, Url: "u1", Explanation: &searchpb.Explanation{
+				Terms:  []*searchpb.TermMatch{{Term: "robot", Fields: []string{"title", "alt", "transcript"}, Matched: []string{"alt"}, Freq: 1, Weight: 1.5}},
+				Unique: 1, Phrases: []string{"robot laser"}, Weight: 1.5, Total: 1,
+			}}},
+			Total:   1,
+			Explain: &searchpb.QueryExplanation{Path: "index", Generation: 3, Terms: []string{"robot"}, Phrases: []string{"robot laser"}},
+		}, nil)
+
+	result, err := client.IndexSearch(context.Background(), "robot", 1, core.SearchOptions{Explain: true})
+	assert.NoError(t, err)
+	assert.Equal(t, core.SearchResult{
+		Comics: []core.Comics{{ID: 1, URL: "u1", Explanation: &core.Explanation{
+			Terms:  []core.TermMatch{{Term: "robot", Fields: []string{"title", "alt", "transcript"}, Matched: []string{"alt"}, Freq: 1, Weight: 1.5}},
+			Unique: 1, Phrases: []string{"robot laser"}, Weight: 1.5, Total: 1,
+		}}},
+		Total:   1,
+		Explain: &core.QueryExplanation{Path: "index", Generation: 3, Terms: []string{"robot"}, Phrases: []string{"robot laser"}},
+	}, result)
+}
+
+func TestClient_Suggest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocksearch.NewMockSearchClient(ctrl)
+	client := &Client{
+		client: mockClient,
+		log:    slog.Default(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockClient.EXPECT().
+			Suggest(gomock.Any(), &searchpb.SuggestRequest{Prefix: "prog", Limit: 5}).
+			Return(&searchpb.SuggestResponse{
+				Suggestions: []*searchpb.Suggestion{{Word: "programming", Count: 12}},
+			}, nil)
+
+		suggestions, err := client.Suggest(context.Background(), "prog", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []core.Suggestion{{Word: "programming", Count: 12}}, suggestions)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		mockClient.EXPECT().
+			Suggest(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.InvalidArgument, "empty prefix"))
+
+		_, err := client.Suggest(context.Background(), "", 5)
+		assert.ErrorIs(t, err, core.ErrBadArguments)
+	})
+}
+
+func TestClient_Similar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocksearch.NewMockSearchClient(ctrl)
+	client := &Client{
+		client: mockClient,
+		log:    slog.Default(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockClient.EXPECT().
+			Similar(gomock.Any(), &searchpb.SimilarRequest{Id: 7, Limit: 3}).
+			Return(&searchpb.SearchResponse{
+				Comics: []*searchpb.Comic{{Id: 8, Url: "http://example.com/8", Score: 0.25}},
+				Total:  2,
+			}, nil)
+
+		result, err := client.Similar(context.Background(), 7, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, core.SearchResult{
+			Comics: []core.Comics{{ID: 8, URL: "http://example.com/8", Score: 0.25}},
+			Total:  2,
+		}, result)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockClient.EXPECT().
+			Similar(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.NotFound, "not found"))
+
+		_, err := client.Similar(context.Background(), 42, 3)
+		assert.ErrorIs(t, err, core.ErrNotFound)
+	})
+}
+
+func TestClient_GetComic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocksearch.NewMockSearchClient(ctrl)
+	client := &Client{
+		client: mockClient,
+		log:    slog.Default(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockClient.EXPECT().
+			GetComic(gomock.Any(), &searchpb.GetComicRequest{Id: 7}).
+			Return(&searchpb.ComicDetail{
+				Id: 7, Url: "http://example.com/7", Title: "Robots", Date: "2006-01-02",
+				Words: []string{"robot"}, Prev: 6, Next: 9,
+				Dialogue:      []*searchpb.Line{{Speaker: "Cueball", Text: "Robots!", Words: []string{"robot"}}},
+				DialogueWords: []string{"robot"},
+				Speakers:      []string{"Cueball"},
+			}, nil)
+
+		comic, err := client.GetComic(context.Background(), 7)
+		assert.NoError(t, err)
+		assert.Equal(t, core.ComicDetail{
+			ID: 7, URL: "http://example.com/7", Title: "Robots", Date: "2006-01-02",
+			Words: []string{"robot"}, Prev: 6, Next: 9,
+			Dialogue:      []core.Line{{Speaker: "Cueball", Text: "Robots!", Words: []string{"robot"}}},
+			DialogueWords: []string{"robot"},
+			Speakers:      []string{"Cueball"},
+		}, comic)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockClient.EXPECT().
+			GetComic(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.NotFound, "not found"))
+
+		_, err := client.GetComic(context.Background(), 42)
+		assert.ErrorIs(t, err, core.ErrNotFound)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		mockClient.EXPECT().
+			GetComic(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.InvalidArgument, "bad id"))
+
+		_, err := client.GetComic(context.Background(), 0)
+		assert.ErrorIs(t, err, core.ErrBadArguments)
+	})
+}
+
+func TestClient_IndexStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocksearch.NewMockSearchClient(ctrl)
+	client := &Client{
+		client: mockClient,
+		log:    slog.Default(),
+	}
+
+	mockClient.EXPECT().
+		IndexStats(gomock.Any(), &searchpb.IndexStatsRequest{Limit: 3}).
+		Return(&searchpb.IndexStatsResponse{
+			Generation: 4,
+			Documents:  10,
+			Terms:      20,
+			TopTerms:   []*searchpb.TermStats{{Term: "robot", Docs: 7, Total: 9}},
+			WordsClient: &searchpb.WordsClientStats{
+				Hits: 8, Misses: 3, Entries: 3, Capacity: 1000, Invalidations: 1, Retries: 2,
+			},
+		}, nil)
+
+	stats, err := client.IndexStats(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, core.IndexStats{
+		Generation: 4,
+		Documents:  10,
+		Terms:      20,
+		TopTerms:   []core.TermStats{{Term: "robot", Docs: 7, Total: 9}},
+		RareTerms:  []core.TermStats{},
+		WordsClient: core.WordsClientStats{
+			Hits: 8, Misses: 3, Entries: 3, Capacity: 1000, Invalidations: 1, Retries: 2,
+		},
+	}, stats)
+}
+
+func TestClient_Generation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocksearch.NewMockSearchClient(ctrl)
+	client := &Client{
+		client: mockClient,
+		log:    slog.Default(),
+	}
+
+	mockClient.EXPECT().Generation(gomock.Any(), gomock.Any()).Return(&searchpb.GenerationResponse{Generation: 7}, nil)
+	generation, err := client.Generation(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), generation)
+
+	mockClient.EXPECT().Generation(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "down"))
+	_, err = client.Generation(context.Background())
+	assert.Error(t, err)
+}
+
+func TestClient_Analytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocksearch.NewMockSearchClient(ctrl)
+	client := &Client{
+		client: mockClient,
+		log:    slog.Default(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockClient.EXPECT().
+			Analytics(gomock.Any(), &searchpb.AnalyticsRequest{WindowSeconds: 3600, Limit: 10}).
+			Return(&searchpb.AnalyticsResponse{
+				Since:       "2025-03-01T12:00:00Z",
+				Queries:     5,
+				TopQueries:  []*searchpb.QueryCount{{Phrase: "robot", Count: 3}},
+				ZeroResults: []*searchpb.QueryCount{{Phrase: "qwerty", Count: 1}},
+				Latency:     []*searchpb.LatencyStats{{Mode: "isearch", Count: 5, P50Ms: 0.5, P90Ms: 1, P99Ms: 2}},
+			}, nil)
+
+		analytics, err := client.Analytics(context.Background(), time.Hour, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, core.Analytics{
+			Since:       "2025-03-01T12:00:00Z",
+			Queries:     5,
+			TopQueries:  []core.QueryCount{{Phrase: "robot", Count: 3}},
+			ZeroResults: []core.QueryCount{{Phrase: "qwerty", Count: 1}},
+			Latency:     []core.LatencyStats{{Mode: "isearch", Count: 5, P50Ms: 0.5, P90Ms: 1, P99Ms: 2}},
+		}, analytics)
+	})
+
+	t.Run("bad arguments", func(t *testing.T) {
+		mockClient.EXPECT().Analytics(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.InvalidArgument, "bad window"))
+
+		_, err := client.Analytics(context.Background(), 0, 10)
+		assert.ErrorIs(t, err, core.ErrBadArguments)
+	})
+}
+
+func TestClient_Correct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocksearch.NewMockSearchClient(ctrl)
+	client := &Client{
+		client: mockClient,
+		log:    slog.Default(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockClient.EXPECT().
+			Correct(gomock.Any(), &searchpb.CorrectRequest{Phrase: "blak hat"}).
+			Return(&searchpb.CorrectResponse{
+				Phrase:      "black hat",
+				Corrections: []*searchpb.Correction{{Word: "blak", Correction: "black", Distance: 1, Count: 40}},
+				Generation:  2,
+			}, nil)
+
+		corrected, err := client.Correct(context.Background(), "blak hat")
+		assert.NoError(t, err)
+		assert.Equal(t, core.Corrected{
+			Phrase:      "black hat",
+			Corrections: []core.Correction{{Word: "blak", Correction: "black", Distance: 1, Count: 40}},
+			Generation:  2,
+		}, corrected)
+	})
+
+	t.Run("bad arguments", func(t *testing.T) {
+		mockClient.EXPECT().Correct(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.InvalidArgument, "empty phrase"))
+
+		_, err := client.Correct(context.Background(), " ")
+		assert.ErrorIs(t, err, core.ErrBadArguments)
+	})
+}
+
+func TestClient_TermInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocksearch.NewMockSearchClient(ctrl)
+	client := &Client{
+		client: mockClient,
+		log:    slog.Default(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockClient.EXPECT().
+			TermInfo(gomock.Any(), &searchpb.TermInfoRequest{Term: "robots", Limit: 10}).
+			Return(&searchpb.TermInfoResponse{
+				Stats:    &searchpb.TermStats{Term: "robot", Docs: 1, Total: 2},
+				Form:     "robots",
+				Idf:      1.5,
+				Postings: []*searchpb.Posting{{Id: 3, Freq: 2, Fields: []string{"title"}}},
+			}, nil)
+
+		info, err := client.TermInfo(context.Background(), "robots", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, core.TermInfo{
+			TermStats: core.TermStats{Term: "robot", Docs: 1, Total: 2},
+			Form:      "robots",
+			IDF:       1.5,
+			Postings:  []core.Posting{{ID: 3, Freq: 2, Fields: []string{"title"}}},
+		}, info)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockClient.EXPECT().
+			TermInfo(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.NotFound, "not found"))
+
+		_, err := client.TermInfo(context.Background(), "laser", 10)
+		assert.ErrorIs(t, err, core.ErrNotFound)
+	})
+}
+
+func TestClient_RandomComic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocksearch.NewMockSearchClient(ctrl)
+	client := &Client{
+		client: mockClient,
+		log:    slog.Default(),
+	}
+
+	mockClient.EXPECT().
+		RandomComic(gomock.Any(), &emptypb.Empty{}).
+		Return(&searchpb.ComicDetail{Id: 3, Url: "http://example.com/3", Next: 4}, nil)
+
+	comic, err := client.RandomComic(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, core.ComicDetail{ID: 3, URL: "http://example.com/3", Next: 4}, comic)
+}
+
+func TestClient_Synonyms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocksearch.NewMockSearchClient(ctrl)
+	client := &Client{
+		client: mockClient,
+		log:    slog.Default(),
+	}
+
+	t.Run("get", func(t *testing.T) {
+		mockClient.EXPECT().Synonyms(gomock.Any(), &emptypb.Empty{}).
+			Return(&searchpb.SynonymGroups{
+				Groups: []*searchpb.SynonymGroup{{Words: []string{"car", "automobile"}}},
+			}, nil)
+
+		groups, err := client.Synonyms(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"car", "automobile"}}, groups)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		mockClient.EXPECT().
+			SetSynonyms(gomock.Any(), &searchpb.SynonymGroups{
+				Groups: []*searchpb.SynonymGroup{{Words: []string{"cat", "kitten"}}},
+			}).
+			Return(&emptypb.Empty{}, nil)
+
+		assert.NoError(t, client.SetSynonyms(context.Background(), [][]string{{"cat", "kitten"}}))
+	})
+
+	t.Run("set invalid", func(t *testing.T) {
+		mockClient.EXPECT().SetSynonyms(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.InvalidArgument, "single word group"))
+
+		err := client.SetSynonyms(context.Background(), [][]string{{"cat"}})
+		assert.ErrorIs(t, err, core.ErrBadArguments)
+	})
+}
@@ -2,16 +2,15 @@ package words
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
-	"google.golang.org/grpc/credentials/insecure"
-
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"yadro.com/course/api/core"
 	wordspb "yadro.com/course/proto/words"
+	"yadro.com/course/wordsclient"
 )
 
 type Client struct {
@@ -19,8 +18,8 @@ type Client struct {
 	Client wordspb.WordsClient
 }
 
-func NewClient(address string, log *slog.Logger) (*Client, error) {
-	connection, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewClient(address string, cfg wordsclient.Config, log *slog.Logger) (*Client, error) {
+	client, err := wordsclient.Dial(log, address, cfg)
 	if err != nil {
 		log.Error("failed to connect to server", "error", err)
 		return nil, err
@@ -28,23 +27,114 @@ func NewClient(address string, log *slog.Logger) (*Client, error) {
 
 	return &Client{
 		Log:    log,
-		Client: wordspb.NewWordsClient(connection),
+		Client: client,
 	}, nil
 }
 
-func (c Client) Norm(ctx context.Context, phrase string) ([]string, error) {
-	c.Log.Debug("calling Norm", "phrase", phrase)
-	resp, err := c.Client.Norm(ctx, &wordspb.WordsRequest{Phrase: phrase})
+func (c Client) Norm(ctx context.Context, phrase, lang, mode string) (core.Normalized, error) {
+	c.Log.Debug("calling Norm", "phrase", phrase, "lang", lang, "mode", mode)
+	resp, err := c.Client.Norm(ctx, &wordspb.WordsRequest{Phrase: phrase, Lang: lang, Mode: mode})
 	if err != nil {
-		if status.Code(err) == codes.ResourceExhausted {
+		switch status.Code(err) {
+		case codes.ResourceExhausted:
 			c.Log.Warn("resource exhausted", "error", err)
-			return nil, core.ErrBadArguments
+			return core.Normalized{}, core.ErrBadArguments
+		case codes.InvalidArgument:
+			c.Log.Warn("invalid argument in Norm", "error", err)
+			return core.Normalized{}, core.ErrBadArguments
 		}
 		c.Log.Error("error calling Norm", "error", err)
-		return nil, err
+		return core.Normalized{}, err
+	}
+	c.Log.Debug("successfully normalized phrase", "words", resp.Words, "lang", resp.Lang)
+	return core.Normalized{Words: resp.Words, Lang: resp.Lang, Mode: resp.Mode}, nil
+}
+
+func (c Client) NormDetailed(ctx context.Context, phrase, lang, mode string) (core.DetailedNormalized, error) {
+	c.Log.Debug("calling NormDetailed", "phrase", phrase, "lang", lang, "mode", mode)
+	resp, err := c.Client.NormDetailed(ctx, &wordspb.WordsRequest{Phrase: phrase, Lang: lang, Mode: mode})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.ResourceExhausted, codes.InvalidArgument:
+			c.Log.Warn("bad arguments in NormDetailed", "error", err)
+			return core.DetailedNormalized{}, core.ErrBadArguments
+		}
+		c.Log.Error("error calling NormDetailed", "error", err)
+		return core.DetailedNormalized{}, err
+	}
+
+	detailed := core.DetailedNormalized{
+		Tokens: make([]core.Token, len(resp.Tokens)),
+		Terms:  make([]core.TermFrequency, len(resp.Terms)),
+		Lang:   resp.Lang,
+		Mode:   resp.Mode,
+	}
+	for i, t := range resp.Tokens {
+		detailed.Tokens[i] = core.Token{
+			Text:     t.Text,
+			Start:    int(t.Start),
+			End:      int(t.End),
+			Position: int(t.Position),
+			Stem:     t.Stem,
+			Lemma:    t.Lemma,
+			Term:     t.Term,
+			Stop:     t.Stop,
+		}
+	}
+	for i, t := range resp.Terms {
+		detailed.Terms[i] = core.TermFrequency{Term: t.Term, Count: int(t.Count)}
+	}
+	c.Log.Debug("successfully normalized phrase in detail", "tokens", len(detailed.Tokens), "lang", resp.Lang)
+	return detailed, nil
+}
+
+func (c Client) Dictionaries(ctx context.Context) (core.Dictionaries, error) {
+	c.Log.Debug("calling Dictionaries")
+	resp, err := c.Client.Dictionaries(ctx, &emptypb.Empty{})
+	if err != nil {
+		c.Log.Error("error calling Dictionaries", "error", err)
+		return core.Dictionaries{}, err
+	}
+	return core.Dictionaries{StopWords: resp.StopWords, Protected: resp.Protected}, nil
+}
+
+func (c Client) SetDictionaries(ctx context.Context, dicts core.Dictionaries, author string) (core.DictionaryVersion, error) {
+	c.Log.Debug("calling SetDictionaries", "stop_words", len(dicts.StopWords), "protected", len(dicts.Protected))
+	resp, err := c.Client.SetDictionaries(ctx, &wordspb.DictionaryWords{
+		StopWords: dicts.StopWords,
+		Protected: dicts.Protected,
+		Author:    author,
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			c.Log.Warn("invalid argument in SetDictionaries", "error", err)
+			return core.DictionaryVersion{}, fmt.Errorf("%w: %s", core.ErrBadArguments, status.Convert(err).Message())
+		}
+		c.Log.Error("error calling SetDictionaries", "error", err)
+		return core.DictionaryVersion{}, err
+	}
+	return dictionaryVersion(resp), nil
+}
+
+func (c Client) DictionaryVersion(ctx context.Context) (core.DictionaryVersion, error) {
+	c.Log.Debug("calling Version")
+	resp, err := c.Client.Version(ctx, &emptypb.Empty{})
+	if err != nil {
+		c.Log.Error("error calling Version", "error", err)
+		return core.DictionaryVersion{}, err
+	}
+	return dictionaryVersion(resp), nil
+}
+
+func dictionaryVersion(v *wordspb.DictionaryVersion) core.DictionaryVersion {
+	return core.DictionaryVersion{
+		Version:   v.Version,
+		Hash:      v.Hash,
+		UpdatedAt: v.UpdatedAt,
+		Source:    v.Source,
+		StopWords: int(v.StopWords),
+		Protected: int(v.Protected),
 	}
-	c.Log.Debug("successfully normalized phrase", "words", resp.Words)
-	return resp.Words, nil
 }
 
 func (c Client) Ping(ctx context.Context) error {
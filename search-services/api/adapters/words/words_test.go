@@ -17,11 +17,12 @@ import (
 	mockwords "yadro.com/course/api/adapters/words/mock"
 	"yadro.com/course/api/core"
 	wordspb "yadro.com/course/proto/words"
+	"yadro.com/course/wordsclient"
 )
 
 func TestNewClient(t *testing.T) {
 	t.Run("successful creation", func(t *testing.T) {
-		client, err := words.NewClient("localhost:50051", slog.Default())
+		client, err := words.NewClient("localhost:50051", wordsclient.Config{}, slog.Default())
 		assert.NoError(t, err)
 		assert.NotNil(t, client)
 	})
@@ -31,9 +32,10 @@ func TestClient_Norm(t *testing.T) {
 	tests := []struct {
 		name         string
 		phrase       string
+		lang         string
 		mockResponse *wordspb.WordsReply
 		mockError    error
-		expected     []string
+		expected     core.Normalized
 		expectedErr  error
 	}{
 		{
@@ -41,8 +43,26 @@ func TestClient_Norm(t *testing.T) {
 			phrase: "test phrase",
 			mockResponse: &wordspb.WordsReply{
 				Words: []string{"test", "phrase"},
+				Lang:  "en",
 			},
-			expected: []string{"test", "phrase"},
+			expected: core.Normalized{Words: []string{"test", "phrase"}, Lang: "en"},
+		},
+		{
+			name:   "requested language",
+			phrase: "роботы",
+			lang:   "ru",
+			mockResponse: &wordspb.WordsReply{
+				Words: []string{"робот"},
+				Lang:  "ru",
+			},
+			expected: core.Normalized{Words: []string{"робот"}, Lang: "ru"},
+		},
+		{
+			name:        "unsupported language",
+			phrase:      "test",
+			lang:        "de",
+			mockError:   status.Error(codes.InvalidArgument, "unsupported language"),
+			expectedErr: core.ErrBadArguments,
 		},
 		{
 			name:        "resource exhausted",
@@ -65,7 +85,7 @@ func TestClient_Norm(t *testing.T) {
 
 			mockClient := mockwords.NewMockWordsClient(ctrl)
 			mockClient.EXPECT().
-				Norm(gomock.Any(), &wordspb.WordsRequest{Phrase: tt.phrase}, gomock.Any()).
+				Norm(gomock.Any(), &wordspb.WordsRequest{Phrase: tt.phrase, Lang: tt.lang}, gomock.Any()).
 				Return(tt.mockResponse, tt.mockError)
 
 			c := &words.Client{
@@ -73,7 +93,7 @@ func TestClient_Norm(t *testing.T) {
 				Log:    slog.Default(),
 			}
 
-			result, err := c.Norm(context.Background(), tt.phrase)
+			result, err := c.Norm(context.Background(), tt.phrase, tt.lang, "")
 
 			if tt.expectedErr != nil {
 				require.Error(t, err)
@@ -86,6 +106,102 @@ func TestClient_Norm(t *testing.T) {
 	}
 }
 
+func TestClient_NormDetailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mockwords.NewMockWordsClient(ctrl)
+	c := &words.Client{Client: mockClient, Log: slog.Default()}
+
+	t.Run("tokens and terms", func(t *testing.T) {
+		mockClient.EXPECT().
+			NormDetailed(gomock.Any(), &wordspb.WordsRequest{Phrase: "the studies", Mode: "lemma"}).
+			Return(&wordspb.DetailedReply{
+				Tokens: []*wordspb.Token{
+					{Text: "the", Start: 0, End: 3, Position: 0, Stem: "the", Lemma: "the", Term: "the", Stop: true},
+					{Text: "studies", Start: 4, End: 11, Position: 1, Stem: "studi", Lemma: "study", Term: "study"},
+				},
+				Terms: []*wordspb.TermFrequency{{Term: "study", Count: 1}},
+				Lang:  "en",
+				Mode:  "lemma",
+			}, nil)
+
+		result, err := c.NormDetailed(context.Background(), "the studies", "", "lemma")
+		require.NoError(t, err)
+		assert.Equal(t, core.DetailedNormalized{
+			Tokens: []core.Token{
+				{Text: "the", Start: 0, End: 3, Position: 0, Stem: "the", Lemma: "the", Term: "the", Stop: true},
+				{Text: "studies", Start: 4, End: 11, Position: 1, Stem: "studi", Lemma: "study", Term: "study"},
+			},
+			Terms: []core.TermFrequency{{Term: "study", Count: 1}},
+			Lang:  "en",
+			Mode:  "lemma",
+		}, result)
+	})
+
+	t.Run("bad arguments", func(t *testing.T) {
+		mockClient.EXPECT().
+			NormDetailed(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.ResourceExhausted, "too large"))
+
+		_, err := c.NormDetailed(context.Background(), "long", "", "")
+		assert.ErrorIs(t, err, core.ErrBadArguments)
+	})
+
+	t.Run("grpc error", func(t *testing.T) {
+		mockClient.EXPECT().
+			NormDetailed(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("unavailable"))
+
+		_, err := c.NormDetailed(context.Background(), "robots", "", "")
+		assert.EqualError(t, err, "unavailable")
+	})
+}
+
+func TestClient_Dictionaries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mockwords.NewMockWordsClient(ctrl)
+	c := &words.Client{Client: mockClient, Log: slog.Default()}
+	reply := &wordspb.DictionaryVersion{Version: 3, Hash: "abc", UpdatedAt: "2026-01-02T03:04:05Z", Source: "upload", StopWords: 1}
+	expected := core.DictionaryVersion{Version: 3, Hash: "abc", UpdatedAt: "2026-01-02T03:04:05Z", Source: "upload", StopWords: 1}
+
+	t.Run("get", func(t *testing.T) {
+		mockClient.EXPECT().Dictionaries(gomock.Any(), gomock.Any()).
+			Return(&wordspb.DictionaryWords{StopWords: []string{"comic"}, Protected: []string{"xkcd"}}, nil)
+		dicts, err := c.Dictionaries(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, core.Dictionaries{StopWords: []string{"comic"}, Protected: []string{"xkcd"}}, dicts)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		mockClient.EXPECT().SetDictionaries(gomock.Any(), &wordspb.DictionaryWords{StopWords: []string{"comic"}, Author: "admin"}).
+			Return(reply, nil)
+		version, err := c.SetDictionaries(context.Background(), core.Dictionaries{StopWords: []string{"comic"}}, "admin")
+		require.NoError(t, err)
+		assert.Equal(t, expected, version)
+	})
+
+	t.Run("set bad word", func(t *testing.T) {
+		mockClient.EXPECT().SetDictionaries(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.InvalidArgument, `bad word: "a b"`))
+		_, err := c.SetDictionaries(context.Background(), core.Dictionaries{StopWords: []string{"a b"}}, "admin")
+		assert.ErrorIs(t, err, core.ErrBadArguments)
+	})
+
+	t.Run("version", func(t *testing.T) {
+		mockClient.EXPECT().Version(gomock.Any(), gomock.Any()).Return(reply, nil)
+		version, err := c.DictionaryVersion(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, expected, version)
+
+		mockClient.EXPECT().Version(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+		_, err = c.DictionaryVersion(context.Background())
+		assert.Error(t, err)
+	})
+}
+
 func TestClient_Ping(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"yadro.com/course/wordsclient"
 )
 
 type HTTPConfig struct {
@@ -13,15 +14,18 @@ type HTTPConfig struct {
 }
 
 type Config struct {
-	LogLevel          string        `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
-	SearchConcurrency int           `yaml:"search_concurrency" env:"SEARCH_CONCURRENCY" env-default:"1"`
-	SearchRate        int           `yaml:"search_rate" env:"SEARCH_RATE" env-default:"1"`
-	HTTPConfig        HTTPConfig    `yaml:"api_server"`
-	WordsAddress      string        `yaml:"words_address" env:"WORDS_ADDRESS" env-default:"words:81"`
-	UpdateAddress     string        `yaml:"update_address" env:"UPDATE_ADDRESS" env-default:"update:82"`
-	SearchAddress     string        `yaml:"search_address" env:"SEARCH_ADDRESS" env-default:"search:83"`
-	TokenTTL          time.Duration `yaml:"token_ttl" env:"TOKEN_TTL" env-default:"24h"`
-	YoloAddress       string        `yaml:"yolo_address" env:"YOLO_ADDRESS"`
+	LogLevel          string             `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
+	SearchConcurrency int                `yaml:"search_concurrency" env:"SEARCH_CONCURRENCY" env-default:"1"`
+	SearchRate        int                `yaml:"search_rate" env:"SEARCH_RATE" env-default:"1"`
+	SuggestRate       int                `yaml:"suggest_rate" env:"SUGGEST_RATE" env-default:"20"`
+	SimilarRate       int                `yaml:"similar_rate" env:"SIMILAR_RATE" env-default:"10"`
+	HTTPConfig        HTTPConfig         `yaml:"api_server"`
+	WordsAddress      string             `yaml:"words_address" env:"WORDS_ADDRESS" env-default:"words:81"`
+	WordsClient       wordsclient.Config `yaml:"words_client"`
+	UpdateAddress     string             `yaml:"update_address" env:"UPDATE_ADDRESS" env-default:"update:82"`
+	SearchAddress     string             `yaml:"search_address" env:"SEARCH_ADDRESS" env-default:"search:83"`
+	TokenTTL          time.Duration      `yaml:"token_ttl" env:"TOKEN_TTL" env-default:"24h"`
+	YoloAddress       string             `yaml:"yolo_address" env:"YOLO_ADDRESS"`
 }
 
 func MustLoad(configPath string) Config {
@@ -15,6 +15,8 @@ func TestMustLoad(t *testing.T) {
 log_level: INFO
 search_concurrency: 2
 search_rate: 3
+suggest_rate: 7
+similar_rate: 4
 api_server:
   address: ":8080"
   timeout: 10s
@@ -38,6 +40,8 @@ token_ttl: 12h
 		assert.Equal(t, "INFO", cfg.LogLevel)
 		assert.Equal(t, 2, cfg.SearchConcurrency)
 		assert.Equal(t, 3, cfg.SearchRate)
+		assert.Equal(t, 7, cfg.SuggestRate)
+		assert.Equal(t, 4, cfg.SimilarRate)
 		assert.Equal(t, ":8080", cfg.HTTPConfig.Address)
 		assert.Equal(t, 10*time.Second, cfg.HTTPConfig.Timeout)
 		assert.Equal(t, "words-service:81", cfg.WordsAddress)
@@ -82,6 +86,8 @@ token_ttl: 12h
 		assert.Equal(t, "DEBUG", cfg.LogLevel)
 		assert.Equal(t, 1, cfg.SearchConcurrency)
 		assert.Equal(t, 1, cfg.SearchRate)
+		assert.Equal(t, 20, cfg.SuggestRate)
+		assert.Equal(t, 10, cfg.SimilarRate)
 		assert.Equal(t, "localhost:80", cfg.HTTPConfig.Address)
 		assert.Equal(t, 5*time.Second, cfg.HTTPConfig.Timeout)
 		assert.Equal(t, "words:81", cfg.WordsAddress)
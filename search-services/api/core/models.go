@@ -1,5 +1,7 @@
 package core
 
+import "time"
+
 type UpdateStatus string
 
 const (
@@ -15,10 +17,267 @@ type UpdateStats struct {
 	ComicsTotal   int
 }
 
+// Normalized are the stems of a phrase and the language it was normalized
+// in, the requested or the detected one.
+type Normalized struct {
+	Words []string
+	Lang  string
+	Mode  string
+}
+
+// Token is a word of a phrase with its byte offsets, position among the words
+// of the phrase, stem and lemma. Term is the one of them the mode of the
+// normalization picks. Stop words are kept with Stop set.
+type Token struct {
+	Text     string `json:"text"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	Position int    `json:"position"`
+	Stem     string `json:"stem"`
+	Lemma    string `json:"lemma"`
+	Term     string `json:"term"`
+	Stop     bool   `json:"stop"`
+}
+
+type TermFrequency struct {
+	Term  string `json:"term"`
+	Count int    `json:"count"`
+}
+
+// DetailedNormalized is the normalization of a phrase word by word, the
+// terms are in the order they first appear.
+type DetailedNormalized struct {
+	Tokens []Token
+	Terms  []TermFrequency
+	Lang   string
+	Mode   string
+}
+
 type Comics struct {
-	ID    int
-	URL   string
-	Score int
+	ID      int
+	URL     string
+	Date    string   `json:"date,omitempty"`
+	Score   float64  `json:"score,omitempty"`
+	Terms   []string `json:"terms,omitempty"`
+	Snippet *Snippet `json:"snippet,omitempty"`
+	// Explanation is filled in for explain=true searches
+	Explanation *Explanation `json:"explanation,omitempty"`
+}
+
+// QueryExplanation tells which search path ("db" or "index") and index
+// generation served a query and what it was normalized to.
+type QueryExplanation struct {
+	Path       string   `json:"path"`
+	Generation uint64   `json:"generation"`
+	Terms      []string `json:"terms"`
+	Phrases    []string `json:"phrases,omitempty"`
+}
+
+// Explanation is the ranking of a hit: hits are ordered by unique, then by
+// the number of phrases, then by weight and then by total. The full-text
+// search of the database orders them by rank instead.
+type Explanation struct {
+	Terms   []TermMatch `json:"terms"`
+	Unique  int         `json:"unique"`
+	Phrases []string    `json:"phrases,omitempty"`
+	Weight  float64     `json:"weight"`
+	Total   int         `json:"total"`
+	Rank    float64     `json:"rank,omitempty"`
+}
+
+type TermMatch struct {
+	Term    string   `json:"term"`
+	Origin  string   `json:"origin,omitempty"`
+	Fields  []string `json:"fields"`
+	Matched []string `json:"matched"`
+	Freq    int      `json:"freq"`
+	Weight  float64  `json:"weight"`
+}
+
+// ComicDetail is a stored comic with the IDs of its neighbours in the index,
+// zero at the ends.
+type ComicDetail struct {
+	ID              int               `json:"id"`
+	URL             string            `json:"url"`
+	Title           string            `json:"title"`
+	Alt             string            `json:"alt"`
+	Transcript      string            `json:"transcript"`
+	Date            string            `json:"date,omitempty"`
+	Words           []string          `json:"words"`
+	Forms           map[string]string `json:"forms,omitempty"`
+	TitleWords      []string          `json:"title_words,omitempty"`
+	AltWords        []string          `json:"alt_words,omitempty"`
+	TranscriptWords []string          `json:"transcript_words,omitempty"`
+	Bigrams         []string          `json:"bigrams,omitempty"`
+	// Dialogue, Speakers, Scenes and their words are empty for comics
+	// stored before transcripts were parsed
+	Dialogue      []Line   `json:"dialogue,omitempty"`
+	Speakers      []string `json:"speakers,omitempty"`
+	Scenes        []string `json:"scenes,omitempty"`
+	DialogueWords []string `json:"dialogue_words,omitempty"`
+	SceneWords    []string `json:"scene_words,omitempty"`
+	Prev          int      `json:"prev,omitempty"`
+	Next          int      `json:"next,omitempty"`
+}
+
+// Line is a line of dialogue with its speaker as the transcript names them.
+type Line struct {
+	Speaker string   `json:"speaker"`
+	Text    string   `json:"text"`
+	Words   []string `json:"words,omitempty"`
+}
+
+type IndexStats struct {
+	Generation      uint64      `json:"generation"`
+	BuiltAt         string      `json:"built_at,omitempty"`
+	BuildDurationMs int64       `json:"build_duration_ms"`
+	Documents       int         `json:"documents"`
+	Terms           int         `json:"terms"`
+	TopTerms        []TermStats `json:"top_terms"`
+	RareTerms       []TermStats `json:"rare_terms"`
+	Cache           CacheStats  `json:"cache"`
+	// WordsClient are the counters of the words client of the search service
+	WordsClient WordsClientStats `json:"words_client"`
+}
+
+// Analytics summarizes the search queries logged since Since.
+type Analytics struct {
+	Since       string         `json:"since"`
+	Queries     int            `json:"queries"`
+	TopQueries  []QueryCount   `json:"top_queries"`
+	ZeroResults []QueryCount   `json:"zero_results"`
+	Latency     []LatencyStats `json:"latency"`
+}
+
+// SynonymGroups are the groups of words the search expands queries with.
+type SynonymGroups struct {
+	Groups [][]string `json:"groups"`
+}
+
+// Dictionaries are the stop words and protected words of the words service.
+type Dictionaries struct {
+	StopWords []string `json:"stop_words"`
+	Protected []string `json:"protected"`
+}
+
+// DictionaryVersion identifies the active dictionaries of the words service.
+type DictionaryVersion struct {
+	Version   uint64 `json:"version"`
+	Hash      string `json:"hash"`
+	UpdatedAt string `json:"updated_at"`
+	Source    string `json:"source"`
+	StopWords int    `json:"stop_words"`
+	Protected int    `json:"protected"`
+}
+
+type QueryCount struct {
+	Phrase string `json:"phrase"`
+	Count  int    `json:"count"`
+}
+
+// LatencyStats are the latency percentiles of one search mode.
+type LatencyStats struct {
+	Mode  string  `json:"mode"`
+	Count int     `json:"count"`
+	P50Ms float64 `json:"p50_ms"`
+	P90Ms float64 `json:"p90_ms"`
+	P99Ms float64 `json:"p99_ms"`
+}
+
+// CacheStats are the counters of the search result cache.
+type CacheStats struct {
+	Hits     uint64 `json:"hits"`
+	Misses   uint64 `json:"misses"`
+	Entries  int    `json:"entries"`
+	Capacity int    `json:"capacity"`
+}
+
+// WordsClientStats are the counters of the cache of normalized phrases of a
+// words client and of the calls it repeated while the service was down.
+type WordsClientStats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Entries       int    `json:"entries"`
+	Capacity      int    `json:"capacity"`
+	Invalidations uint64 `json:"invalidations"`
+	Retries       uint64 `json:"retries"`
+}
+
+// TermStats is the number of comics with a term and of its occurrences.
+type TermStats struct {
+	Term  string `json:"term"`
+	Docs  int    `json:"docs"`
+	Total int    `json:"total"`
+}
+
+type TermInfo struct {
+	TermStats
+	Form     string    `json:"form"`
+	IDF      float64   `json:"idf"`
+	Postings []Posting `json:"postings"`
+}
+
+type Posting struct {
+	ID     int      `json:"id"`
+	Freq   int      `json:"freq"`
+	Fields []string `json:"fields"`
+}
+
+// Snippet is a piece of comic text with byte ranges of matched words.
+type Snippet struct {
+	Field      string `json:"field"`
+	Text       string `json:"text"`
+	Highlights []Span `json:"highlights"`
+}
+
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+type Suggestion struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// Corrected is a phrase with the words unknown to the index replaced.
+type Corrected struct {
+	Phrase      string       `json:"phrase"`
+	Corrections []Correction `json:"corrections"`
+	Generation  uint64       `json:"generation"`
+}
+
+type Correction struct {
+	Word       string `json:"word"`
+	Correction string `json:"correction"`
+	Distance   int    `json:"distance"`
+	Count      int    `json:"count"`
+}
+
+// ModeDetect is the query log mode of searches by the objects detected on
+// a picture.
+const ModeDetect = "detect"
+
+type SearchOptions struct {
+	Fuzzy bool
+	// веса полей title, alt и transcript поверх настроек сервиса
+	Weights map[string]float64
+	// даты публикации (нулевые - без ограничения) и порядок результатов
+	From time.Time
+	To   time.Time
+	Sort string
+	// объяснение ранжирования в ответе
+	Explain bool
+	// режим запроса в журнале запросов, detect для поиска по картинке
+	Mode string
+}
+
+type SearchResult struct {
+	Comics     []Comics
+	Total      int32
+	Suggestion string
+	Explain    *QueryExplanation
+	Generation uint64
 }
 
 type Yolo struct {
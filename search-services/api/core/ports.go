@@ -1,9 +1,19 @@
 package core
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Normalizer interface {
-	Norm(context.Context, string) ([]string, error)
+	Norm(ctx context.Context, phrase, lang, mode string) (Normalized, error)
+	NormDetailed(ctx context.Context, phrase, lang, mode string) (DetailedNormalized, error)
+}
+
+type DictionaryEditor interface {
+	Dictionaries(context.Context) (Dictionaries, error)
+	SetDictionaries(ctx context.Context, dicts Dictionaries, author string) (DictionaryVersion, error)
+	DictionaryVersion(context.Context) (DictionaryVersion, error)
 }
 
 type Pinger interface {
@@ -18,8 +28,19 @@ type Updater interface {
 }
 
 type Searcher interface {
-	Search(context.Context, string, int32) ([]Comics, int32, error)
-	IndexSearch(context.Context, string, int32) ([]Comics, int32, error)
+	Search(context.Context, string, int32, SearchOptions) (SearchResult, error)
+	IndexSearch(context.Context, string, int32, SearchOptions) (SearchResult, error)
+	Suggest(context.Context, string, int32) ([]Suggestion, error)
+	Similar(context.Context, int, int32) (SearchResult, error)
+	GetComic(context.Context, int) (ComicDetail, error)
+	RandomComic(context.Context) (ComicDetail, error)
+	IndexStats(context.Context, int32) (IndexStats, error)
+	Generation(context.Context) (uint64, error)
+	TermInfo(context.Context, string, int32) (TermInfo, error)
+	Analytics(context.Context, time.Duration, int32) (Analytics, error)
+	Synonyms(context.Context) ([][]string, error)
+	SetSynonyms(context.Context, [][]string) error
+	Correct(context.Context, string) (Corrected, error)
 }
 
 type YoloDetector interface {
@@ -59,7 +59,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	wordsClient, err := words.NewClient(cfg.WordsAddress, log)
+	wordsClient, err := words.NewClient(cfg.WordsAddress, cfg.WordsClient, log)
 	if err != nil {
 		log.Error("cannot init words adapter", "error", err)
 		os.Exit(1)
@@ -96,6 +96,36 @@ func main() {
 		aaaService,
 	))
 
+	mux.Handle("GET /api/admin/analytics", middleware.Auth(
+		rest.NewAnalyticsHandler(log, searchClient),
+		aaaService,
+	))
+
+	mux.Handle("GET /api/admin/synonyms", middleware.Auth(
+		rest.NewSynonymsHandler(log, searchClient),
+		aaaService,
+	))
+
+	mux.Handle("PUT /api/admin/synonyms", middleware.Auth(
+		rest.NewSetSynonymsHandler(log, searchClient),
+		aaaService,
+	))
+
+	mux.Handle("GET /api/admin/dictionaries", middleware.Auth(
+		rest.NewDictionariesHandler(log, wordsClient),
+		aaaService,
+	))
+
+	mux.Handle("PUT /api/admin/dictionaries", middleware.Auth(
+		rest.NewSetDictionariesHandler(log, wordsClient),
+		aaaService,
+	))
+
+	mux.Handle("GET /api/admin/dictionaries/version", middleware.Auth(
+		rest.NewDictionaryVersionHandler(log, wordsClient),
+		aaaService,
+	))
+
 	mux.Handle("GET /api/search", middleware.Concurrency(
 		rest.NewSearchHandler(log, searchClient),
 		concurrencyLimit,
@@ -105,6 +135,26 @@ func main() {
 		rest.NewSearchIndexHandler(log, searchClient),
 		rateLimit,
 	))
+
+	mux.Handle("GET /api/suggest", middleware.Rate(
+		rest.NewSuggestHandler(log, searchClient),
+		cfg.SuggestRate,
+	))
+
+	mux.Handle("GET /api/correct", middleware.Rate(
+		rest.NewCorrectHandler(log, searchClient),
+		cfg.SuggestRate,
+	))
+
+	mux.Handle("GET /api/comics/{id}/similar", middleware.Rate(
+		rest.NewSimilarHandler(log, searchClient),
+		cfg.SimilarRate,
+	))
+
+	mux.Handle("GET /api/comics/{id}", rest.NewComicHandler(log, searchClient))
+	mux.Handle("GET /api/comics/random", rest.NewRandomComicHandler(log, searchClient))
+	mux.Handle("GET /api/index/stats", rest.NewIndexStatsHandler(log, searchClient))
+	mux.Handle("GET /api/index/terms/{term}", rest.NewTermInfoHandler(log, searchClient))
 	server := http.Server{
 		Addr:        cfg.HTTPConfig.Address,
 		ReadTimeout: cfg.HTTPConfig.Timeout,
@@ -11,6 +11,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -18,6 +19,48 @@ type Comic struct {
 	ID    int    `json:"id"`
 	URL   string `json:"url"`
 	Score int    `json:"score"`
+	Date  string `json:"date"`
+	Terms []string
+	Field string
+	// Snippet is split into plain and matched parts so that the template
+	// can wrap matches into <mark> while still escaping the text.
+	Snippet []Segment
+}
+
+type Segment struct {
+	Text string
+	Mark bool
+}
+
+type snippet struct {
+	Field      string `json:"field"`
+	Text       string `json:"text"`
+	Highlights []struct {
+		Start int `json:"start"`
+		End   int `json:"end"`
+	} `json:"highlights"`
+}
+
+func (s *snippet) segments() []Segment {
+	if s == nil {
+		return nil
+	}
+	var segments []Segment
+	pos := 0
+	for _, h := range s.Highlights {
+		if h.Start < pos || h.End > len(s.Text) || h.Start >= h.End {
+			continue
+		}
+		if h.Start > pos {
+			segments = append(segments, Segment{Text: s.Text[pos:h.Start]})
+		}
+		segments = append(segments, Segment{Text: s.Text[h.Start:h.End], Mark: true})
+		pos = h.End
+	}
+	if pos < len(s.Text) {
+		segments = append(segments, Segment{Text: s.Text[pos:]})
+	}
+	return segments
 }
 
 type UpdateStats struct {
@@ -27,6 +70,41 @@ type UpdateStats struct {
 	ComicsTotal   int `json:"comics_total"`
 }
 
+// Analytics is the search query report of the api admin endpoint.
+type Analytics struct {
+	Since       string         `json:"since"`
+	Queries     int            `json:"queries"`
+	TopQueries  []QueryCount   `json:"top_queries"`
+	ZeroResults []QueryCount   `json:"zero_results"`
+	Latency     []LatencyStats `json:"latency"`
+}
+
+type QueryCount struct {
+	Phrase string `json:"phrase"`
+	Count  int    `json:"count"`
+}
+
+type LatencyStats struct {
+	Mode  string  `json:"mode"`
+	Count int     `json:"count"`
+	P50Ms float64 `json:"p50_ms"`
+	P90Ms float64 `json:"p90_ms"`
+	P99Ms float64 `json:"p99_ms"`
+}
+
+type AnalyticsWindow struct {
+	Value string
+	Label string
+}
+
+// analyticsWindows are the periods the admin panel reports queries for.
+var analyticsWindows = []AnalyticsWindow{
+	{"1h", "Last hour"},
+	{"24h", "Last 24 hours"},
+	{"168h", "Last 7 days"},
+	{"720h", "Last 30 days"},
+}
+
 type UpdateStatus struct {
 	Status string `json:"status"`
 }
@@ -157,6 +235,8 @@ func (h *Handler) Detect(w http.ResponseWriter, r *http.Request) {
 		SearchTime     string
 		Limit          string
 		Fast           bool
+		Fuzzy          bool
+		Suggestion     string
 	}{
 		Phrase:         "Image search",
 		IsImageResults: true,
@@ -195,6 +275,10 @@ func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 
 	limit := r.URL.Query().Get("limit")
 	fastSearch := r.URL.Query().Get("fast") == "true"
+	fuzzy := r.URL.Query().Get("fuzzy") == "true"
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	sort := r.URL.Query().Get("sort")
 	isImageResults := r.URL.Query().Get("image_results") == "true"
 
 	endpoint := "/api/search"
@@ -206,6 +290,18 @@ func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 	if limit != "" {
 		apiURL += "&limit=" + url.QueryEscape(limit)
 	}
+	if fuzzy {
+		apiURL += "&fuzzy=true"
+	}
+	if from != "" {
+		apiURL += "&from=" + url.QueryEscape(from)
+	}
+	if to != "" {
+		apiURL += "&to=" + url.QueryEscape(to)
+	}
+	if sort != "" {
+		apiURL += "&sort=" + url.QueryEscape(sort)
+	}
 
 	resp, err := h.client.Get(apiURL)
 	if err != nil {
@@ -219,11 +315,15 @@ func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 
 	var result struct {
 		Comics []struct {
-			ID    int     `json:"id"`
-			URL   string  `json:"url"`
-			Score float64 `json:"score"`
+			ID      int      `json:"id"`
+			URL     string   `json:"url"`
+			Date    string   `json:"date"`
+			Score   float64  `json:"score"`
+			Terms   []string `json:"terms"`
+			Snippet *snippet `json:"snippet"`
 		} `json:"comics"`
-		Total int `json:"total"`
+		Total      int    `json:"total"`
+		Suggestion string `json:"suggestion"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
@@ -238,7 +338,12 @@ func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 		Phrase         string
 		Limit          string
 		Fast           bool
+		Fuzzy          bool
+		From           string
+		To             string
+		Sort           string
 		Total          int
+		Suggestion     string
 		Comics         []Comic
 		SearchTime     string
 		IsImageResults bool
@@ -246,7 +351,12 @@ func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 		Phrase:         query,
 		Limit:          limit,
 		Fast:           fastSearch,
+		Fuzzy:          fuzzy,
+		From:           from,
+		To:             to,
+		Sort:           sort,
 		Total:          result.Total,
+		Suggestion:     result.Suggestion,
 		Comics:         make([]Comic, len(result.Comics)),
 		SearchTime:     fmt.Sprintf("%.2fms", float64(searchTime.Microseconds())/1000),
 		IsImageResults: isImageResults,
@@ -254,9 +364,15 @@ func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 
 	for i, c := range result.Comics {
 		data.Comics[i] = Comic{
-			ID:    c.ID,
-			URL:   c.URL,
-			Score: int(c.Score * 100),
+			ID:      c.ID,
+			URL:     c.URL,
+			Date:    c.Date,
+			Score:   int(c.Score * 100),
+			Terms:   c.Terms,
+			Snippet: c.Snippet.segments(),
+		}
+		if c.Snippet != nil {
+			data.Comics[i].Field = c.Snippet.Field
 		}
 	}
 
@@ -266,6 +382,131 @@ func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Suggest proxies autocomplete requests of the search form to the API.
+func (h *Handler) Suggest(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	if prefix == "" {
+		http.Error(w, "prefix is required", http.StatusBadRequest)
+		return
+	}
+
+	apiURL := h.apiURL + "/api/suggest?prefix=" + url.QueryEscape(prefix)
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		apiURL += "&limit=" + url.QueryEscape(limit)
+	}
+
+	resp, err := h.client.Get(apiURL)
+	if err != nil {
+		h.log.Error("suggest API call failed", "url", apiURL, "error", err)
+		http.Error(w, "Search service unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		h.log.Error("failed to copy suggestions", "error", err)
+	}
+}
+
+// Similar proxies requests for comics related to a search result to the API.
+func (h *Handler) Similar(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id is required", http.StatusBadRequest)
+		return
+	}
+
+	apiURL := h.apiURL + "/api/comics/" + url.PathEscape(id) + "/similar"
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		apiURL += "?limit=" + url.QueryEscape(limit)
+	}
+
+	resp, err := h.client.Get(apiURL)
+	if err != nil {
+		h.log.Error("similar API call failed", "url", apiURL, "error", err)
+		http.Error(w, "Search service unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	defer resp.Body.Close()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		h.log.Error("failed to copy similar comics", "error", err)
+	}
+}
+
+// ComicDetail is the comic shown on its own page.
+type ComicDetail struct {
+	ID         int      `json:"id"`
+	URL        string   `json:"url"`
+	Title      string   `json:"title"`
+	Alt        string   `json:"alt"`
+	Transcript string   `json:"transcript"`
+	Date       string   `json:"date"`
+	Words      []string `json:"words"`
+	Prev       int      `json:"prev"`
+	Next       int      `json:"next"`
+}
+
+// Comic renders the page of a single comic with links to its neighbours.
+func (h *Handler) Comic(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id < 1 {
+		http.Error(w, "Invalid comic id", http.StatusBadRequest)
+		return
+	}
+
+	comic, status, err := h.getComic(h.apiURL + "/api/comics/" + strconv.Itoa(id))
+	if err != nil {
+		h.log.Error("failed to get comic", "id", id, "error", err)
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "comic.html", comic); err != nil {
+		h.log.Error("failed to render comic", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// RandomComic redirects to the page of a random comic, so that the page
+// itself always has a stable link.
+func (h *Handler) RandomComic(w http.ResponseWriter, r *http.Request) {
+	comic, status, err := h.getComic(h.apiURL + "/api/comics/random")
+	if err != nil {
+		h.log.Error("failed to get random comic", "error", err)
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	http.Redirect(w, r, "/comics/"+strconv.Itoa(comic.ID), http.StatusSeeOther)
+}
+
+// getComic returns the comic and the status code to answer with on error.
+func (h *Handler) getComic(apiURL string) (ComicDetail, int, error) {
+	resp, err := h.client.Get(apiURL)
+	if err != nil {
+		return ComicDetail{}, http.StatusServiceUnavailable, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusBadRequest:
+		return ComicDetail{}, resp.StatusCode, fmt.Errorf("comic request failed with code %d", resp.StatusCode)
+	default:
+		return ComicDetail{}, http.StatusBadGateway, fmt.Errorf("comic request failed with code %d", resp.StatusCode)
+	}
+
+	var comic ComicDetail
+	if err := json.NewDecoder(resp.Body).Decode(&comic); err != nil {
+		return ComicDetail{}, http.StatusInternalServerError, err
+	}
+	return comic, http.StatusOK, nil
+}
+
 func (h *Handler) Admin(w http.ResponseWriter, r *http.Request) {
 	token, err := r.Cookie("admin_token")
 	if err != nil || token.Value == "" {
@@ -287,12 +528,30 @@ func (h *Handler) Admin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	window := "24h"
+	for _, w := range analyticsWindows {
+		if r.URL.Query().Get("window") == w.Value {
+			window = w.Value
+		}
+	}
+	// журнал запросов живёт в сервисе поиска, без него панель всё равно нужна
+	analytics, err := h.getAnalytics(token.Value, window)
+	if err != nil {
+		h.log.Warn("failed to get analytics", "error", err)
+	}
+
 	data := struct {
-		Status UpdateStatus
-		Stats  UpdateStats
+		Status    UpdateStatus
+		Stats     UpdateStats
+		Analytics *Analytics
+		Window    string
+		Windows   []AnalyticsWindow
 	}{
-		Status: status,
-		Stats:  stats,
+		Status:    status,
+		Stats:     stats,
+		Analytics: analytics,
+		Window:    window,
+		Windows:   analyticsWindows,
 	}
 
 	if err := h.templates.ExecuteTemplate(w, "admin.html", data); err != nil {
@@ -349,6 +608,30 @@ func (h *Handler) getStats(token string) (UpdateStats, error) {
 	return stats, nil
 }
 
+func (h *Handler) getAnalytics(token, window string) (*Analytics, error) {
+	req, err := http.NewRequest("GET", h.apiURL+"/api/admin/analytics?window="+url.QueryEscape(window), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Authorization", "Token "+token)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("analytics request failed with code %d", resp.StatusCode)
+	}
+
+	var analytics Analytics
+	if err := json.NewDecoder(resp.Body).Decode(&analytics); err != nil {
+		return nil, err
+	}
+	return &analytics, nil
+}
+
 func (h *Handler) AdminLogin(w http.ResponseWriter, r *http.Request) {
 	err := h.templates.ExecuteTemplate(w, "login.html", map[string]interface{}{
 		"Error": r.URL.Query().Get("error") == "1",
@@ -28,6 +28,10 @@ func main() {
 
 	mux.HandleFunc("GET /{$}", handler.Index)
 	mux.HandleFunc("GET /search", handler.Search)
+	mux.HandleFunc("GET /suggest", handler.Suggest)
+	mux.HandleFunc("GET /similar", handler.Similar)
+	mux.HandleFunc("GET /comics/{id}", handler.Comic)
+	mux.HandleFunc("GET /comics/random", handler.RandomComic)
 	mux.HandleFunc("GET /image-search", handler.ImageSearch)
 	mux.HandleFunc("POST /detect", handler.Detect)
 
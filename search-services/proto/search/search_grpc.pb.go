@@ -22,6 +22,17 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Search_Search_FullMethodName      = "/search.Search/Search"
 	Search_IndexSearch_FullMethodName = "/search.Search/IndexSearch"
+	Search_Suggest_FullMethodName     = "/search.Search/Suggest"
+	Search_Similar_FullMethodName     = "/search.Search/Similar"
+	Search_GetComic_FullMethodName    = "/search.Search/GetComic"
+	Search_RandomComic_FullMethodName = "/search.Search/RandomComic"
+	Search_IndexStats_FullMethodName  = "/search.Search/IndexStats"
+	Search_Generation_FullMethodName  = "/search.Search/Generation"
+	Search_TermInfo_FullMethodName    = "/search.Search/TermInfo"
+	Search_Analytics_FullMethodName   = "/search.Search/Analytics"
+	Search_Synonyms_FullMethodName    = "/search.Search/Synonyms"
+	Search_SetSynonyms_FullMethodName = "/search.Search/SetSynonyms"
+	Search_Correct_FullMethodName     = "/search.Search/Correct"
 	Search_Ping_FullMethodName        = "/search.Search/Ping"
 )
 
@@ -31,6 +42,17 @@ const (
 type SearchClient interface {
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 	IndexSearch(ctx context.Context, in *IndexSearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error)
+	Similar(ctx context.Context, in *SimilarRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	GetComic(ctx context.Context, in *GetComicRequest, opts ...grpc.CallOption) (*ComicDetail, error)
+	RandomComic(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ComicDetail, error)
+	IndexStats(ctx context.Context, in *IndexStatsRequest, opts ...grpc.CallOption) (*IndexStatsResponse, error)
+	Generation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GenerationResponse, error)
+	TermInfo(ctx context.Context, in *TermInfoRequest, opts ...grpc.CallOption) (*TermInfoResponse, error)
+	Analytics(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AnalyticsResponse, error)
+	Synonyms(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SynonymGroups, error)
+	SetSynonyms(ctx context.Context, in *SynonymGroups, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Correct(ctx context.Context, in *CorrectRequest, opts ...grpc.CallOption) (*CorrectResponse, error)
 	Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
@@ -62,6 +84,116 @@ func (c *searchClient) IndexSearch(ctx context.Context, in *IndexSearchRequest,
 	return out, nil
 }
 
+func (c *searchClient) Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestResponse)
+	err := c.cc.Invoke(ctx, Search_Suggest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchClient) Similar(ctx context.Context, in *SimilarRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, Search_Similar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchClient) GetComic(ctx context.Context, in *GetComicRequest, opts ...grpc.CallOption) (*ComicDetail, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComicDetail)
+	err := c.cc.Invoke(ctx, Search_GetComic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchClient) RandomComic(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ComicDetail, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComicDetail)
+	err := c.cc.Invoke(ctx, Search_RandomComic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchClient) IndexStats(ctx context.Context, in *IndexStatsRequest, opts ...grpc.CallOption) (*IndexStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IndexStatsResponse)
+	err := c.cc.Invoke(ctx, Search_IndexStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchClient) Generation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GenerationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerationResponse)
+	err := c.cc.Invoke(ctx, Search_Generation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchClient) TermInfo(ctx context.Context, in *TermInfoRequest, opts ...grpc.CallOption) (*TermInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TermInfoResponse)
+	err := c.cc.Invoke(ctx, Search_TermInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchClient) Analytics(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AnalyticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalyticsResponse)
+	err := c.cc.Invoke(ctx, Search_Analytics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchClient) Synonyms(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SynonymGroups, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SynonymGroups)
+	err := c.cc.Invoke(ctx, Search_Synonyms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchClient) SetSynonyms(ctx context.Context, in *SynonymGroups, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Search_SetSynonyms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchClient) Correct(ctx context.Context, in *CorrectRequest, opts ...grpc.CallOption) (*CorrectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CorrectResponse)
+	err := c.cc.Invoke(ctx, Search_Correct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *searchClient) Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -78,6 +210,17 @@ func (c *searchClient) Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc
 type SearchServer interface {
 	Search(context.Context, *SearchRequest) (*SearchResponse, error)
 	IndexSearch(context.Context, *IndexSearchRequest) (*SearchResponse, error)
+	Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error)
+	Similar(context.Context, *SimilarRequest) (*SearchResponse, error)
+	GetComic(context.Context, *GetComicRequest) (*ComicDetail, error)
+	RandomComic(context.Context, *emptypb.Empty) (*ComicDetail, error)
+	IndexStats(context.Context, *IndexStatsRequest) (*IndexStatsResponse, error)
+	Generation(context.Context, *emptypb.Empty) (*GenerationResponse, error)
+	TermInfo(context.Context, *TermInfoRequest) (*TermInfoResponse, error)
+	Analytics(context.Context, *AnalyticsRequest) (*AnalyticsResponse, error)
+	Synonyms(context.Context, *emptypb.Empty) (*SynonymGroups, error)
+	SetSynonyms(context.Context, *SynonymGroups) (*emptypb.Empty, error)
+	Correct(context.Context, *CorrectRequest) (*CorrectResponse, error)
 	Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	mustEmbedUnimplementedSearchServer()
 }
@@ -95,6 +238,39 @@ func (UnimplementedSearchServer) Search(context.Context, *SearchRequest) (*Searc
 func (UnimplementedSearchServer) IndexSearch(context.Context, *IndexSearchRequest) (*SearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IndexSearch not implemented")
 }
+func (UnimplementedSearchServer) Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suggest not implemented")
+}
+func (UnimplementedSearchServer) Similar(context.Context, *SimilarRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Similar not implemented")
+}
+func (UnimplementedSearchServer) GetComic(context.Context, *GetComicRequest) (*ComicDetail, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComic not implemented")
+}
+func (UnimplementedSearchServer) RandomComic(context.Context, *emptypb.Empty) (*ComicDetail, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RandomComic not implemented")
+}
+func (UnimplementedSearchServer) IndexStats(context.Context, *IndexStatsRequest) (*IndexStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IndexStats not implemented")
+}
+func (UnimplementedSearchServer) Generation(context.Context, *emptypb.Empty) (*GenerationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generation not implemented")
+}
+func (UnimplementedSearchServer) TermInfo(context.Context, *TermInfoRequest) (*TermInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TermInfo not implemented")
+}
+func (UnimplementedSearchServer) Analytics(context.Context, *AnalyticsRequest) (*AnalyticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Analytics not implemented")
+}
+func (UnimplementedSearchServer) Synonyms(context.Context, *emptypb.Empty) (*SynonymGroups, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Synonyms not implemented")
+}
+func (UnimplementedSearchServer) SetSynonyms(context.Context, *SynonymGroups) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSynonyms not implemented")
+}
+func (UnimplementedSearchServer) Correct(context.Context, *CorrectRequest) (*CorrectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Correct not implemented")
+}
 func (UnimplementedSearchServer) Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
@@ -155,6 +331,204 @@ func _Search_IndexSearch_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Search_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Search_Suggest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServer).Suggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Search_Similar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimilarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServer).Similar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Search_Similar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServer).Similar(ctx, req.(*SimilarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Search_GetComic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetComicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServer).GetComic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Search_GetComic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServer).GetComic(ctx, req.(*GetComicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Search_RandomComic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServer).RandomComic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Search_RandomComic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServer).RandomComic(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Search_IndexStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IndexStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServer).IndexStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Search_IndexStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServer).IndexStats(ctx, req.(*IndexStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Search_Generation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServer).Generation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Search_Generation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServer).Generation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Search_TermInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TermInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServer).TermInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Search_TermInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServer).TermInfo(ctx, req.(*TermInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Search_Analytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServer).Analytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Search_Analytics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServer).Analytics(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Search_Synonyms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServer).Synonyms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Search_Synonyms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServer).Synonyms(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Search_SetSynonyms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SynonymGroups)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServer).SetSynonyms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Search_SetSynonyms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServer).SetSynonyms(ctx, req.(*SynonymGroups))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Search_Correct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CorrectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServer).Correct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Search_Correct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServer).Correct(ctx, req.(*CorrectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Search_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -188,6 +562,50 @@ var Search_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IndexSearch",
 			Handler:    _Search_IndexSearch_Handler,
 		},
+		{
+			MethodName: "Suggest",
+			Handler:    _Search_Suggest_Handler,
+		},
+		{
+			MethodName: "Similar",
+			Handler:    _Search_Similar_Handler,
+		},
+		{
+			MethodName: "GetComic",
+			Handler:    _Search_GetComic_Handler,
+		},
+		{
+			MethodName: "RandomComic",
+			Handler:    _Search_RandomComic_Handler,
+		},
+		{
+			MethodName: "IndexStats",
+			Handler:    _Search_IndexStats_Handler,
+		},
+		{
+			MethodName: "Generation",
+			Handler:    _Search_Generation_Handler,
+		},
+		{
+			MethodName: "TermInfo",
+			Handler:    _Search_TermInfo_Handler,
+		},
+		{
+			MethodName: "Analytics",
+			Handler:    _Search_Analytics_Handler,
+		},
+		{
+			MethodName: "Synonyms",
+			Handler:    _Search_Synonyms_Handler,
+		},
+		{
+			MethodName: "SetSynonyms",
+			Handler:    _Search_SetSynonyms_Handler,
+		},
+		{
+			MethodName: "Correct",
+			Handler:    _Search_Correct_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _Search_Ping_Handler,
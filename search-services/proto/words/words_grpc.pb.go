@@ -20,8 +20,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Words_Ping_FullMethodName = "/words.Words/Ping"
-	Words_Norm_FullMethodName = "/words.Words/Norm"
+	Words_Ping_FullMethodName            = "/words.Words/Ping"
+	Words_Norm_FullMethodName            = "/words.Words/Norm"
+	Words_NormDetailed_FullMethodName    = "/words.Words/NormDetailed"
+	Words_NormBatch_FullMethodName       = "/words.Words/NormBatch"
+	Words_NormStream_FullMethodName      = "/words.Words/NormStream"
+	Words_Version_FullMethodName         = "/words.Words/Version"
+	Words_Dictionaries_FullMethodName    = "/words.Words/Dictionaries"
+	Words_SetDictionaries_FullMethodName = "/words.Words/SetDictionaries"
 )
 
 // WordsClient is the client API for Words service.
@@ -33,6 +39,19 @@ type WordsClient interface {
 	Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Send name, receive greeting
 	Norm(ctx context.Context, in *WordsRequest, opts ...grpc.CallOption) (*WordsReply, error)
+	// NormDetailed returns every word of the phrase with its offsets and stem
+	// along with the term frequencies
+	NormDetailed(ctx context.Context, in *WordsRequest, opts ...grpc.CallOption) (*DetailedReply, error)
+	// NormBatch normalizes many phrases in one call, a failed item does not
+	// fail the others
+	NormBatch(ctx context.Context, in *NormBatchRequest, opts ...grpc.CallOption) (*NormBatchReply, error)
+	// NormStream replies to every item in the order they are sent
+	NormStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[NormItem, NormResult], error)
+	// Version is the version of the active stop and protected words
+	Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DictionaryVersion, error)
+	Dictionaries(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DictionaryWords, error)
+	// SetDictionaries replaces the stop and protected words and saves them
+	SetDictionaries(ctx context.Context, in *DictionaryWords, opts ...grpc.CallOption) (*DictionaryVersion, error)
 }
 
 type wordsClient struct {
@@ -63,6 +82,69 @@ func (c *wordsClient) Norm(ctx context.Context, in *WordsRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *wordsClient) NormDetailed(ctx context.Context, in *WordsRequest, opts ...grpc.CallOption) (*DetailedReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetailedReply)
+	err := c.cc.Invoke(ctx, Words_NormDetailed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wordsClient) NormBatch(ctx context.Context, in *NormBatchRequest, opts ...grpc.CallOption) (*NormBatchReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NormBatchReply)
+	err := c.cc.Invoke(ctx, Words_NormBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wordsClient) NormStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[NormItem, NormResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Words_ServiceDesc.Streams[0], Words_NormStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[NormItem, NormResult]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Words_NormStreamClient = grpc.BidiStreamingClient[NormItem, NormResult]
+
+func (c *wordsClient) Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DictionaryVersion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DictionaryVersion)
+	err := c.cc.Invoke(ctx, Words_Version_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wordsClient) Dictionaries(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DictionaryWords, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DictionaryWords)
+	err := c.cc.Invoke(ctx, Words_Dictionaries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wordsClient) SetDictionaries(ctx context.Context, in *DictionaryWords, opts ...grpc.CallOption) (*DictionaryVersion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DictionaryVersion)
+	err := c.cc.Invoke(ctx, Words_SetDictionaries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WordsServer is the server API for Words service.
 // All implementations must embed UnimplementedWordsServer
 // for forward compatibility.
@@ -72,6 +154,19 @@ type WordsServer interface {
 	Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	// Send name, receive greeting
 	Norm(context.Context, *WordsRequest) (*WordsReply, error)
+	// NormDetailed returns every word of the phrase with its offsets and stem
+	// along with the term frequencies
+	NormDetailed(context.Context, *WordsRequest) (*DetailedReply, error)
+	// NormBatch normalizes many phrases in one call, a failed item does not
+	// fail the others
+	NormBatch(context.Context, *NormBatchRequest) (*NormBatchReply, error)
+	// NormStream replies to every item in the order they are sent
+	NormStream(grpc.BidiStreamingServer[NormItem, NormResult]) error
+	// Version is the version of the active stop and protected words
+	Version(context.Context, *emptypb.Empty) (*DictionaryVersion, error)
+	Dictionaries(context.Context, *emptypb.Empty) (*DictionaryWords, error)
+	// SetDictionaries replaces the stop and protected words and saves them
+	SetDictionaries(context.Context, *DictionaryWords) (*DictionaryVersion, error)
 	mustEmbedUnimplementedWordsServer()
 }
 
@@ -88,6 +183,24 @@ func (UnimplementedWordsServer) Ping(context.Context, *emptypb.Empty) (*emptypb.
 func (UnimplementedWordsServer) Norm(context.Context, *WordsRequest) (*WordsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Norm not implemented")
 }
+func (UnimplementedWordsServer) NormDetailed(context.Context, *WordsRequest) (*DetailedReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormDetailed not implemented")
+}
+func (UnimplementedWordsServer) NormBatch(context.Context, *NormBatchRequest) (*NormBatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormBatch not implemented")
+}
+func (UnimplementedWordsServer) NormStream(grpc.BidiStreamingServer[NormItem, NormResult]) error {
+	return status.Errorf(codes.Unimplemented, "method NormStream not implemented")
+}
+func (UnimplementedWordsServer) Version(context.Context, *emptypb.Empty) (*DictionaryVersion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+func (UnimplementedWordsServer) Dictionaries(context.Context, *emptypb.Empty) (*DictionaryWords, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Dictionaries not implemented")
+}
+func (UnimplementedWordsServer) SetDictionaries(context.Context, *DictionaryWords) (*DictionaryVersion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDictionaries not implemented")
+}
 func (UnimplementedWordsServer) mustEmbedUnimplementedWordsServer() {}
 func (UnimplementedWordsServer) testEmbeddedByValue()               {}
 
@@ -145,6 +258,103 @@ func _Words_Norm_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Words_NormDetailed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordsServer).NormDetailed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Words_NormDetailed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordsServer).NormDetailed(ctx, req.(*WordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Words_NormBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NormBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordsServer).NormBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Words_NormBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordsServer).NormBatch(ctx, req.(*NormBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Words_NormStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WordsServer).NormStream(&grpc.GenericServerStream[NormItem, NormResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Words_NormStreamServer = grpc.BidiStreamingServer[NormItem, NormResult]
+
+func _Words_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordsServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Words_Version_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordsServer).Version(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Words_Dictionaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordsServer).Dictionaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Words_Dictionaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordsServer).Dictionaries(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Words_SetDictionaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DictionaryWords)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordsServer).SetDictionaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Words_SetDictionaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordsServer).SetDictionaries(ctx, req.(*DictionaryWords))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Words_ServiceDesc is the grpc.ServiceDesc for Words service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -160,7 +370,34 @@ var Words_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Norm",
 			Handler:    _Words_Norm_Handler,
 		},
+		{
+			MethodName: "NormDetailed",
+			Handler:    _Words_NormDetailed_Handler,
+		},
+		{
+			MethodName: "NormBatch",
+			Handler:    _Words_NormBatch_Handler,
+		},
+		{
+			MethodName: "Version",
+			Handler:    _Words_Version_Handler,
+		},
+		{
+			MethodName: "Dictionaries",
+			Handler:    _Words_Dictionaries_Handler,
+		},
+		{
+			MethodName: "SetDictionaries",
+			Handler:    _Words_SetDictionaries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "NormStream",
+			Handler:       _Words_NormStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/words/words.proto",
 }
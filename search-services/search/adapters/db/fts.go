@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/lib/pq"
+	"yadro.com/course/search/core"
+)
+
+// FTS searches comics with PostgreSQL full-text search. The tsv column is
+// generated from the stems of title, alt and transcript the words service
+// stored, with weights A, B and C, and is covered by a GIN index, so the
+// search does not scan the whole table.
+type FTS struct {
+	*DB
+}
+
+func NewFTS(log *slog.Logger, address string) (*FTS, error) {
+	db, err := New(log, address)
+	if err != nil {
+		return nil, err
+	}
+	return &FTS{DB: db}, nil
+}
+
+// rank is the relevance of a comic to the query with the field weights.
+const rank = "rank DESC, c.id"
+
+var ftsOrder = map[core.Sort]string{
+	core.SortRelevance: rank,
+	core.SortNewest:    "c.published DESC NULLS LAST, " + rank,
+	core.SortOldest:    "c.published ASC NULLS LAST, " + rank,
+	core.SortID:        "c.id",
+}
+
+// SearchComics matches comics containing any of the terms and ranks them by
+// cover density with the field weights. The tsv column and the terms hold the
+// same stems of the words service, so the terms are passed to the query in
+// the simple configuration as lexemes, labelled with the weights of the
+// fields they are restricted to. A query of speakers only finds the comics
+// they speak in.
+func (s *FTS) SearchComics(ctx context.Context, terms []core.Term, limit int, weights core.FieldWeights, filter core.Filter) ([]core.Comics, error) {
+	if len(terms) == 0 {
+		return s.speakerComics(ctx, limit, filter)
+	}
+	var hits []hit
+	err := s.conn.SelectContext(ctx, &hits, fmt.Sprintf(`
+        SELECT c.id, c.url, c.published, ts_rank_cd($2::float4[], c.tsv, q) AS rank
+        FROM comics c, to_tsquery('simple', $1) AS q
+        WHERE c.tsv @@ q
+          AND ($4::date IS NULL OR c.published >= $4)
+          AND ($5::date IS NULL OR c.published <= $5)
+          AND (%s)
+        ORDER BY %s
+        LIMIT $3
+    `, speakerFilter("$6"), order(ftsOrder, filter.Sort)),
+		tsQuery(terms), pq.Array(rankWeights(weights)), limit, date(filter.From), date(filter.To), pq.Array(filter.Speakers))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search comics: %w", err)
+	}
+	return toComics(hits), nil
+}
+
+// tsQuery joins the terms with OR. Title, alt and transcript are stored with
+// weights A, B and C, a term restricted to some fields gets their labels.
+// Dialogue and scene descriptions are not told apart from the rest of the
+// transcript there.
+func tsQuery(terms []core.Term) string {
+	parts := make([]string, 0, len(terms))
+	for _, t := range terms {
+		lexeme := "'" + strings.ReplaceAll(strings.ReplaceAll(t.Word, `\`, `\\`), "'", "''") + "'"
+		if t.Fields != core.AllFields {
+			lexeme += ":"
+			if t.Fields&core.FieldTitle != 0 {
+				lexeme += "A"
+			}
+			if t.Fields&core.FieldAlt != 0 {
+				lexeme += "B"
+			}
+			if t.Fields&(core.FieldTranscript|core.FieldDialogue|core.FieldScene) != 0 {
+				lexeme += "C"
+			}
+		}
+		parts = append(parts, lexeme)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// rankWeights converts field weights to the {D, C, B, A} array of ts_rank_cd,
+// which accepts weights from 0 to 1 only.
+func rankWeights(w core.FieldWeights) []float64 {
+	top := max(w.Title, w.Alt, w.Transcript)
+	if top == 0 {
+		return []float64{0.1, 0.1, 0.1, 0.1}
+	}
+	return []float64{0.1, w.Transcript / top, w.Alt / top, w.Title / top}
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+	"yadro.com/course/search/core"
+)
+
+func TestFTS_SearchComics(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	d := &FTS{DB: &DB{
+		conn: db,
+		log:  slog.Default(),
+	}}
+
+	t.Run("successful search", func(t *testing.T) {
+		rows := sqlxmock.NewRows([]string{"id", "url", "published", "rank"}).
+			AddRow(2, "http://example.com/2", nil, 0.5).
+			AddRow(1, "http://example.com/1", nil, 0.1)
+
+		mock.ExpectQuery(`SELECT c.id, c.url, c.published, ts_rank_cd\(\$2::float4\[\], c.tsv, q\) AS rank FROM comics c, to_tsquery\('simple', \$1\) AS q WHERE c.tsv @@ q .* ANY\(\$6\).* ORDER BY rank DESC, c.id LIMIT \$3`).
+			WithArgs("'robot' | 'comput':AC", pq.Array([]float64{0.1, 0.5, 0.25, 1}), 10, nil, nil, pq.Array([]string{"blackhat"})).
+			WillReturnRows(rows)
+
+		result, err := d.SearchComics(context.Background(), []core.Term{
+			{Word: "robot", Fields: core.AllFields},
+			{Word: "comput", Fields: core.FieldTitle | core.FieldDialogue},
+		}, 10, core.FieldWeights{Title: 4, Alt: 1, Transcript: 2}, core.Filter{Speakers: []string{"blackhat"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []core.Comics{
+			{ID: 2, URL: "http://example.com/2", Explanation: &core.Explanation{Rank: 0.5}},
+			{ID: 1, URL: "http://example.com/1", Explanation: &core.Explanation{Rank: 0.1}},
+		}, result)
+	})
+
+	t.Run("speakers only", func(t *testing.T) {
+		rows := sqlxmock.NewRows([]string{"id", "url", "published"}).
+			AddRow(5, "http://example.com/5", nil)
+
+		mock.ExpectQuery(`SELECT c.id, c.url, c.published FROM comics c WHERE .* ANY\(\$4\).* ORDER BY c.published DESC NULLS LAST, c.id LIMIT \$1`).
+			WithArgs(10, nil, nil, pq.Array([]string{"blackhat"})).
+			WillReturnRows(rows)
+
+		result, err := d.SearchComics(context.Background(), nil, 10, core.DefaultWeights,
+			core.Filter{Speakers: []string{"blackhat"}, Sort: core.SortNewest})
+		assert.NoError(t, err)
+		assert.Equal(t, []core.Comics{{ID: 5, URL: "http://example.com/5", Explanation: &core.Explanation{}}}, result)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		from := time.Date(2008, time.January, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`SELECT c.id, c.url, c.published, ts_rank_cd.* FROM comics c, to_tsquery.* ORDER BY c.published ASC NULLS LAST, rank DESC`).
+			WithArgs("'it''s'", pq.Array([]float64{0.1, 1.0 / 3, 0.5, 1}), 10, from, nil, pq.Array([]string(nil))).
+			WillReturnError(errors.New("query failed"))
+
+		_, err := d.SearchComics(context.Background(), []core.Term{{Word: "it's", Fields: core.AllFields}}, 10,
+			core.DefaultWeights, core.Filter{From: from, Sort: core.SortOldest})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,225 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"yadro.com/course/search/core"
+)
+
+const (
+	flushSize     = 100
+	flushInterval = time.Second
+	purgeInterval = time.Hour
+)
+
+// QueryLog writes served queries to the queries table in batches, so that
+// logging never slows down the search. Queries are dropped when the buffer
+// is full and kept for retention.
+type QueryLog struct {
+	log       *slog.Logger
+	conn      *sqlx.DB
+	retention time.Duration
+	queries   chan core.Query
+	dropped   atomic.Uint64
+}
+
+func NewQueryLog(log *slog.Logger, address string, retention time.Duration, buffer int) (*QueryLog, error) {
+	db, err := sqlx.Connect("pgx", address)
+	if err != nil {
+		log.Error("connection problem", "address", address, "error", err)
+		return nil, err
+	}
+	return newQueryLog(log, db, retention, buffer), nil
+}
+
+func newQueryLog(log *slog.Logger, conn *sqlx.DB, retention time.Duration, buffer int) *QueryLog {
+	return &QueryLog{
+		log:       log,
+		conn:      conn,
+		retention: retention,
+		queries:   make(chan core.Query, buffer),
+	}
+}
+
+func (l *QueryLog) Record(q core.Query) {
+	select {
+	case l.queries <- q:
+	default:
+		if n := l.dropped.Add(1); n%1000 == 1 {
+			l.log.Warn("query log buffer is full, dropping queries", "dropped", n)
+		}
+	}
+}
+
+// Run writes recorded queries and removes expired ones until the context is
+// done, then writes what is left in the buffer.
+func (l *QueryLog) Run(ctx context.Context) {
+	flush := time.NewTicker(flushInterval)
+	defer flush.Stop()
+	purge := time.NewTicker(purgeInterval)
+	defer purge.Stop()
+
+	l.purge(ctx)
+	batch := make([]core.Query, 0, flushSize)
+	for {
+		select {
+		case q := <-l.queries:
+			batch = append(batch, q)
+			if len(batch) == flushSize {
+				batch = l.flush(ctx, batch)
+			}
+		case <-flush.C:
+			batch = l.flush(ctx, batch)
+		case <-purge.C:
+			l.purge(ctx)
+		case <-ctx.Done():
+			for {
+				select {
+				case q := <-l.queries:
+					batch = append(batch, q)
+				default:
+					l.flush(context.WithoutCancel(ctx), batch)
+					l.log.Info("Query log stopped")
+					return
+				}
+			}
+		}
+	}
+}
+
+// queryRow is a query as it is stored in the queries table.
+type queryRow struct {
+	CreatedAt time.Time      `db:"created_at"`
+	Phrase    string         `db:"phrase"`
+	Terms     pq.StringArray `db:"terms"`
+	Mode      string         `db:"mode"`
+	Total     int            `db:"total"`
+	LatencyMs float64        `db:"latency_ms"`
+}
+
+// flush inserts the batch and returns it emptied for reuse.
+func (l *QueryLog) flush(ctx context.Context, batch []core.Query) []core.Query {
+	if len(batch) == 0 {
+		return batch
+	}
+	rows := make([]queryRow, len(batch))
+	for i, q := range batch {
+		terms := q.Terms
+		if terms == nil {
+			terms = []string{}
+		}
+		rows[i] = queryRow{
+			CreatedAt: q.Time,
+			Phrase:    q.Phrase,
+			Terms:     terms,
+			Mode:      q.Mode,
+			Total:     q.Total,
+			LatencyMs: float64(q.Latency) / float64(time.Millisecond),
+		}
+	}
+	_, err := l.conn.NamedExecContext(ctx, `
+		INSERT INTO queries (created_at, phrase, terms, mode, total, latency_ms)
+		VALUES (:created_at, :phrase, :terms, :mode, :total, :latency_ms)`, rows)
+	if err != nil {
+		l.log.Error("failed to write queries", "count", len(batch), "error", err)
+	}
+	return batch[:0]
+}
+
+func (l *QueryLog) purge(ctx context.Context) {
+	res, err := l.conn.ExecContext(ctx,
+		"DELETE FROM queries WHERE created_at < $1", time.Now().Add(-l.retention))
+	if err != nil {
+		l.log.Error("failed to remove expired queries", "error", err)
+		return
+	}
+	if n, err := res.RowsAffected(); err == nil && n > 0 {
+		l.log.Debug("Expired queries removed", "count", n)
+	}
+}
+
+type queryCount struct {
+	Phrase string `db:"phrase"`
+	Count  int    `db:"count"`
+}
+
+type latencyRow struct {
+	Mode  string  `db:"mode"`
+	Count int     `db:"count"`
+	P50   float64 `db:"p50"`
+	P90   float64 `db:"p90"`
+	P99   float64 `db:"p99"`
+}
+
+// topQueries groups phrases case-insensitively, so "Robot" and "robot" are
+// counted together.
+const topQueries = `
+	SELECT lower(phrase) AS phrase, count(*) AS count
+	FROM queries
+	WHERE created_at >= $1 %s
+	GROUP BY lower(phrase)
+	ORDER BY count DESC, phrase
+	LIMIT $2`
+
+func (l *QueryLog) Analytics(ctx context.Context, since time.Time, limit int) (core.Analytics, error) {
+	analytics := core.Analytics{Since: since}
+
+	err := l.conn.GetContext(ctx, &analytics.Queries,
+		"SELECT count(*) FROM queries WHERE created_at >= $1", since)
+	if err != nil {
+		return core.Analytics{}, fmt.Errorf("failed to count queries: %w", err)
+	}
+
+	var top, zero []queryCount
+	if err := l.conn.SelectContext(ctx, &top, fmt.Sprintf(topQueries, ""), since, limit); err != nil {
+		return core.Analytics{}, fmt.Errorf("failed to get top queries: %w", err)
+	}
+	if err := l.conn.SelectContext(ctx, &zero, fmt.Sprintf(topQueries, "AND total = 0"), since, limit); err != nil {
+		return core.Analytics{}, fmt.Errorf("failed to get zero result queries: %w", err)
+	}
+	analytics.TopQueries = toQueryCounts(top)
+	analytics.ZeroResults = toQueryCounts(zero)
+
+	var latency []latencyRow
+	err = l.conn.SelectContext(ctx, &latency, `
+		SELECT mode, count(*) AS count,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY latency_ms) AS p50,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY latency_ms) AS p90,
+			percentile_cont(0.99) WITHIN GROUP (ORDER BY latency_ms) AS p99
+		FROM queries
+		WHERE created_at >= $1
+		GROUP BY mode
+		ORDER BY mode`, since)
+	if err != nil {
+		return core.Analytics{}, fmt.Errorf("failed to get latency: %w", err)
+	}
+	analytics.Latency = make([]core.LatencyStats, len(latency))
+	for i, r := range latency {
+		analytics.Latency[i] = core.LatencyStats{
+			Mode:  r.Mode,
+			Count: r.Count,
+			P50:   milliseconds(r.P50),
+			P90:   milliseconds(r.P90),
+			P99:   milliseconds(r.P99),
+		}
+	}
+	return analytics, nil
+}
+
+func toQueryCounts(rows []queryCount) []core.QueryCount {
+	counts := make([]core.QueryCount, len(rows))
+	for i, r := range rows {
+		counts[i] = core.QueryCount{Phrase: r.Phrase, Count: r.Count}
+	}
+	return counts
+}
+
+func milliseconds(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
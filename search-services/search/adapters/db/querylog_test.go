@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+	"yadro.com/course/search/core"
+)
+
+func TestQueryLog_Record(t *testing.T) {
+	db, _, err := sqlxmock.Newx()
+	require.NoError(t, err)
+	defer db.Close()
+
+	l := newQueryLog(slog.Default(), db, time.Hour, 2)
+	for range 3 {
+		l.Record(core.Query{Phrase: "robot"})
+	}
+	assert.Len(t, l.queries, 2)
+	assert.Equal(t, uint64(1), l.dropped.Load())
+}
+
+func TestQueryLog_Run(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	require.NoError(t, err)
+	defer db.Close()
+
+	l := newQueryLog(slog.Default(), db, time.Hour, 10)
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	l.Record(core.Query{Time: at, Phrase: "robots", Terms: []string{"robot"}, Mode: core.ModeSearch, Total: 2, Latency: 1500 * time.Microsecond})
+	l.Record(core.Query{Time: at, Phrase: "qwerty", Mode: core.ModeIndexSearch})
+
+	mock.ExpectExec(`INSERT INTO queries \(created_at, phrase, terms, mode, total, latency_ms\)`).
+		WithArgs(
+			at, "robots", pq.StringArray{"robot"}, "search", 2, 1.5,
+			at, "qwerty", pq.StringArray{}, "isearch", 0, 0.0,
+		).
+		WillReturnResult(sqlxmock.NewResult(0, 2))
+
+	// остановленный журнал дописывает очередь перед выходом
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l.Run(ctx)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryLog_Purge(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	require.NoError(t, err)
+	defer db.Close()
+
+	l := newQueryLog(slog.Default(), db, 24*time.Hour, 10)
+	mock.ExpectExec(`DELETE FROM queries WHERE created_at <`).
+		WithArgs(sqlxmock.AnyArg()).
+		WillReturnResult(sqlxmock.NewResult(0, 3))
+
+	l.purge(context.Background())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryLog_Analytics(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	require.NoError(t, err)
+	defer db.Close()
+
+	l := newQueryLog(slog.Default(), db, time.Hour, 10)
+	since := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM queries WHERE created_at >= \$1`).
+			WithArgs(since).
+			WillReturnRows(sqlxmock.NewRows([]string{"count"}).AddRow(5))
+		mock.ExpectQuery(`SELECT lower\(phrase\) AS phrase.* WHERE created_at >= \$1\s+GROUP BY lower\(phrase\)`).
+			WithArgs(since, 10).
+			WillReturnRows(sqlxmock.NewRows([]string{"phrase", "count"}).AddRow("robot", 3).AddRow("qwerty", 2))
+		mock.ExpectQuery(`SELECT lower\(phrase\) AS phrase.* AND total = 0`).
+			WithArgs(since, 10).
+			WillReturnRows(sqlxmock.NewRows([]string{"phrase", "count"}).AddRow("qwerty", 2))
+		mock.ExpectQuery(`percentile_cont\(0.5\).* GROUP BY mode`).
+			WithArgs(since).
+			WillReturnRows(sqlxmock.NewRows([]string{"mode", "count", "p50", "p90", "p99"}).
+				AddRow("isearch", 3, 0.5, 1.0, 2.5).
+				AddRow("search", 2, 4.0, 8.0, 10.0))
+
+		analytics, err := l.Analytics(context.Background(), since, 10)
+		require.NoError(t, err)
+		assert.Equal(t, core.Analytics{
+			Since:       since,
+			Queries:     5,
+			TopQueries:  []core.QueryCount{{Phrase: "robot", Count: 3}, {Phrase: "qwerty", Count: 2}},
+			ZeroResults: []core.QueryCount{{Phrase: "qwerty", Count: 2}},
+			Latency: []core.LatencyStats{
+				{Mode: "isearch", Count: 3, P50: 500 * time.Microsecond, P90: time.Millisecond, P99: 2500 * time.Microsecond},
+				{Mode: "search", Count: 2, P50: 4 * time.Millisecond, P90: 8 * time.Millisecond, P99: 10 * time.Millisecond},
+			},
+		}, analytics)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM queries`).
+			WillReturnError(errors.New("query failed"))
+
+		_, err := l.Analytics(context.Background(), since, 10)
+		assert.ErrorContains(t, err, "failed to count queries")
+	})
+}
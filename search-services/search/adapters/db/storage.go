@@ -2,8 +2,11 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
@@ -29,50 +32,199 @@ func New(log *slog.Logger, address string) (*DB, error) {
 	}, nil
 }
 
-func (s *DB) SearchComics(ctx context.Context, words []string, limit int) ([]core.Comics, error) {
-	var comics []core.Comics
-	err := s.conn.SelectContext(ctx, &comics, `
-        WITH search_words AS (
-            SELECT unnest($1::text[]) AS word
+// relevance orders comics of the array search by match counts and weights.
+const relevance = `
+            -- Приоритет 1: комиксы с наибольшим количеством уникальных совпадений
+            unique_matches DESC,
+            -- Приоритет 2: совпадения в более весомых полях
+            weighted_matches DESC,
+            -- Приоритет 3: комиксы с наибольшим абсолютным количеством совпадений
+            total_matches DESC`
+
+var searchOrder = map[core.Sort]string{
+	core.SortRelevance: relevance,
+	core.SortNewest:    "published DESC NULLS LAST," + relevance,
+	core.SortOldest:    "published ASC NULLS LAST," + relevance,
+	core.SortID:        "id",
+}
+
+// speakerFilter keeps comics any of the speakers passed as the parameter has
+// a line in, with names reduced to their letters and digits as the keys of
+// the query are. The database does not tell who says which word, unlike the
+// index.
+func speakerFilter(param string) string {
+	return fmt.Sprintf(`COALESCE(cardinality(%[1]s::text[]), 0) = 0 OR EXISTS (
+                    SELECT 1 FROM unnest(c.speakers) AS speaker
+                    WHERE regexp_replace(lower(speaker), '[^[:alnum:]]', '', 'g') = ANY(%[1]s)
+                )`, param)
+}
+
+// hit is a found comic as the search queries return it, with the numbers
+// it was ordered by: the match counts of the array search or the rank of the
+// full-text one.
+type hit struct {
+	ID        int          `db:"id"`
+	URL       string       `db:"url"`
+	Published sql.NullTime `db:"published"`
+	Unique    int          `db:"unique_matches"`
+	Weight    float64      `db:"weighted_matches"`
+	Total     int          `db:"total_matches"`
+	Rank      float64      `db:"rank"`
+}
+
+func toComics(hits []hit) []core.Comics {
+	comics := make([]core.Comics, len(hits))
+	for i, h := range hits {
+		comics[i] = core.Comics{
+			ID:          h.ID,
+			URL:         h.URL,
+			Date:        h.Published.Time,
+			Explanation: &core.Explanation{Unique: h.Unique, Weight: h.Weight, Total: h.Total, Rank: h.Rank},
+		}
+	}
+	return comics
+}
+
+// date passes a zero date bound as NULL, which disables it.
+func date(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func order(orders map[core.Sort]string, sort core.Sort) string {
+	if o, ok := orders[sort]; ok {
+		return o
+	}
+	return orders[core.SortRelevance]
+}
+
+// SearchComics ranks comics having any of the terms by the number of distinct
+// terms, the weights of the fields they are found in and their occurrences.
+// A query of speakers only finds the comics they speak in.
+func (s *DB) SearchComics(ctx context.Context, terms []core.Term, limit int, weights core.FieldWeights, filter core.Filter) ([]core.Comics, error) {
+	if len(terms) == 0 {
+		return s.speakerComics(ctx, limit, filter)
+	}
+	words := make([]string, len(terms))
+	fields := make([]int32, len(terms))
+	concepts := make([]string, len(terms))
+	boosts := make([]float64, len(terms))
+	for i, t := range terms {
+		words[i] = t.Word
+		fields[i] = int32(t.Fields)
+		// синоним считается совпадением исходного слова с меньшим весом
+		concepts[i], boosts[i] = t.Word, 1
+		if t.Origin != "" {
+			concepts[i], boosts[i] = t.Origin, t.Weight
+		}
+	}
+
+	var hits []hit
+	err := s.conn.SelectContext(ctx, &hits, fmt.Sprintf(`
+        WITH search_terms AS (
+            SELECT * FROM unnest($1::text[], $2::int[], $9::text[], $10::float8[]) AS t(word, fields, concept, boost)
         ),
-        comic_matches AS (
+        term_fields AS (
             SELECT 
                 c.id,
                 c.url,
-                -- Количество уникальных совпадающих слов
-                COUNT(DISTINCT sw.word) AS unique_matches,
-                -- Общее количество совпадений (с учетом частоты)
-                SUM(
-                    (SELECT COUNT(*) 
-                     FROM unnest(c.words) AS comic_word 
-                     WHERE comic_word = sw.word)
-                ) AS total_matches
+                c.published,
+                st.word,
+                st.concept,
+                st.boost,
+                -- Поля комикса, в которых слово найдено и в которых его ищут
+                (CASE WHEN st.fields & 1 <> 0 AND st.word = ANY(c.title_words) THEN 1 ELSE 0 END |
+                 CASE WHEN st.fields & 2 <> 0 AND st.word = ANY(c.alt_words) THEN 2 ELSE 0 END |
+                 CASE WHEN st.fields & 4 <> 0 AND st.word = ANY(c.transcript_words) THEN 4 ELSE 0 END |
+                 CASE WHEN st.fields & 8 <> 0 AND st.word = ANY(c.dialogue_words) THEN 8 ELSE 0 END |
+                 CASE WHEN st.fields & 16 <> 0 AND st.word = ANY(c.scene_words) THEN 16 ELSE 0 END) AS found,
+                (SELECT COUNT(*) 
+                 FROM unnest(c.words) AS comic_word 
+                 WHERE comic_word = st.word) AS occurrences
             FROM 
                 comics c
             CROSS JOIN 
-                search_words sw
+                search_terms st
             WHERE 
                 c.words && $1
+                -- Диапазон дат публикации, NULL - без ограничения
+                AND ($7::date IS NULL OR c.published >= $7)
+                AND ($8::date IS NULL OR c.published <= $8)
+                AND (`+speakerFilter("$11")+`)
+        ),
+        comic_matches AS (
+            SELECT 
+                id,
+                url,
+                published,
+                -- Количество уникальных совпадающих слов запроса
+                COUNT(DISTINCT concept) AS unique_matches,
+                -- Сумма весов полей, в которых найдены слова
+                SUM((
+                    CASE WHEN found & 1 <> 0 THEN $3::float8 ELSE 0 END +
+                    CASE WHEN found & 2 <> 0 THEN $4::float8 ELSE 0 END +
+                    -- реплики и описания сцен - части транскрипта
+                    CASE WHEN found & 28 <> 0 THEN $5::float8 ELSE 0 END
+                ) * boost) AS weighted_matches,
+                -- Общее количество совпадений (с учетом частоты)
+                SUM(occurrences) AS total_matches
+            FROM 
+                term_fields
+            WHERE 
+                found <> 0
             GROUP BY 
-                c.id, c.url
+                id, url, published
         )
         SELECT 
             id,
-            url
+            url,
+            published,
+            unique_matches,
+            weighted_matches,
+            total_matches::bigint AS total_matches
         FROM 
             comic_matches
-        ORDER BY
-            -- Приоритет 1: комиксы с наибольшим количеством уникальных совпадений
-            unique_matches DESC,
-            -- Приоритет 2: комиксы с наибольшим абсолютным количеством совпадений
-            total_matches DESC
-        LIMIT $2
-    `, pq.Array(words), limit)
-
+        ORDER BY %s
+        LIMIT $6
+    `, order(searchOrder, filter.Sort)), pq.Array(words), pq.Array(fields), weights.Title, weights.Alt, weights.Transcript, limit,
+		date(filter.From), date(filter.To), pq.Array(concepts), pq.Array(boosts), pq.Array(filter.Speakers))
 	if err != nil {
 		return nil, fmt.Errorf("failed to search comics: %w", err)
 	}
-	return comics, nil
+	return toComics(hits), nil
+}
+
+var speakerOrder = map[core.Sort]string{
+	core.SortRelevance: "c.id",
+	core.SortNewest:    "c.published DESC NULLS LAST, c.id",
+	core.SortOldest:    "c.published ASC NULLS LAST, c.id",
+	core.SortID:        "c.id",
+}
+
+// speakerComics returns the comics the speakers of the filter speak in, the
+// way the index answers a query without terms. Without speakers either
+// nothing is searched for.
+func (s *DB) speakerComics(ctx context.Context, limit int, filter core.Filter) ([]core.Comics, error) {
+	if len(filter.Speakers) == 0 {
+		return []core.Comics{}, nil
+	}
+	var hits []hit
+	err := s.conn.SelectContext(ctx, &hits, fmt.Sprintf(`
+        SELECT c.id, c.url, c.published
+        FROM comics c
+        WHERE ($2::date IS NULL OR c.published >= $2)
+          AND ($3::date IS NULL OR c.published <= $3)
+          AND (%s)
+        ORDER BY %s
+        LIMIT $1
+    `, speakerFilter("$4"), order(speakerOrder, filter.Sort)),
+		limit, date(filter.From), date(filter.To), pq.Array(filter.Speakers))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search comics of speakers: %w", err)
+	}
+	return toComics(hits), nil
 }
 
 func (s *DB) Stats(ctx context.Context) (core.DBStats, error) {
@@ -100,67 +252,149 @@ func (s *DB) Stats(ctx context.Context) (core.DBStats, error) {
 	return stats, nil
 }
 
-func (s *DB) AllComics(ctx context.Context) ([]core.Comics, error) {
-	var dbComics []struct {
-		ID    int            `db:"id"`
-		URL   string         `db:"url"`
-		Words pq.StringArray `db:"words"`
+// comicRow is a full row of the comics table.
+type comicRow struct {
+	ID              int            `db:"id"`
+	URL             string         `db:"url"`
+	Words           pq.StringArray `db:"words"`
+	Forms           []byte         `db:"forms"`
+	Title           string         `db:"title"`
+	Alt             string         `db:"alt"`
+	Transcript      string         `db:"transcript"`
+	TitleWords      pq.StringArray `db:"title_words"`
+	AltWords        pq.StringArray `db:"alt_words"`
+	TranscriptWords pq.StringArray `db:"transcript_words"`
+	Published       sql.NullTime   `db:"published"`
+	Bigrams         pq.StringArray `db:"bigrams"`
+	Dialogue        []byte         `db:"dialogue"`
+	Speakers        pq.StringArray `db:"speakers"`
+	Scenes          pq.StringArray `db:"scenes"`
+	DialogueWords   pq.StringArray `db:"dialogue_words"`
+	SceneWords      pq.StringArray `db:"scene_words"`
+	Tokens          []byte         `db:"tokens"`
+}
+
+const comicColumns = `id, url, words, forms, title, alt, transcript,
+               title_words, alt_words, transcript_words, published, bigrams,
+               dialogue, speakers, scenes, dialogue_words, scene_words, tokens`
+
+// line is a line of dialogue as the dialogue column stores it.
+type line struct {
+	Speaker string   `json:"speaker"`
+	Text    string   `json:"text"`
+	Words   []string `json:"words"`
+}
+
+// token is a word of a field as the tokens column stores it.
+type token struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Term  string `json:"term"`
+}
+
+// fieldTokens are the words of the searched fields of a comic.
+type fieldTokens struct {
+	Title      []token `json:"title"`
+	Alt        []token `json:"alt"`
+	Transcript []token `json:"transcript"`
+}
+
+func fromTokens(stored []token) []core.Token {
+	var tokens []core.Token
+	for _, t := range stored {
+		tokens = append(tokens, core.Token{Start: t.Start, End: t.End, Term: t.Term})
+	}
+	return tokens
+}
+
+func (c comicRow) comic() (core.Comics, error) {
+	comic := core.Comics{
+		ID:         c.ID,
+		URL:        c.URL,
+		Words:      []string(c.Words),
+		Title:      c.Title,
+		Alt:        c.Alt,
+		Transcript: c.Transcript,
+		Date:       c.Published.Time,
+
+		TitleWords:      []string(c.TitleWords),
+		AltWords:        []string(c.AltWords),
+		TranscriptWords: []string(c.TranscriptWords),
+		Bigrams:         []string(c.Bigrams),
+		Speakers:        []string(c.Speakers),
+		Scenes:          []string(c.Scenes),
+		DialogueWords:   []string(c.DialogueWords),
+		SceneWords:      []string(c.SceneWords),
 	}
+	if len(c.Forms) > 0 {
+		if err := json.Unmarshal(c.Forms, &comic.Forms); err != nil {
+			return core.Comics{}, fmt.Errorf("failed to decode forms of comics %d: %w", c.ID, err)
+		}
+	}
+	if len(c.Dialogue) > 0 {
+		var lines []line
+		if err := json.Unmarshal(c.Dialogue, &lines); err != nil {
+			return core.Comics{}, fmt.Errorf("failed to decode dialogue of comics %d: %w", c.ID, err)
+		}
+		for _, l := range lines {
+			comic.Dialogue = append(comic.Dialogue, core.Line{Speaker: l.Speaker, Text: l.Text, Words: l.Words})
+		}
+	}
+	if len(c.Tokens) > 0 {
+		var tokens fieldTokens
+		if err := json.Unmarshal(c.Tokens, &tokens); err != nil {
+			return core.Comics{}, fmt.Errorf("failed to decode tokens of comics %d: %w", c.ID, err)
+		}
+		comic.TitleTokens = fromTokens(tokens.Title)
+		comic.AltTokens = fromTokens(tokens.Alt)
+		comic.TranscriptTokens = fromTokens(tokens.Transcript)
+	}
+	return comic, nil
+}
 
-	err := s.conn.SelectContext(ctx, &dbComics, `
-        SELECT id, url, words 
+func toFullComics(rows []comicRow) ([]core.Comics, error) {
+	comics := make([]core.Comics, len(rows))
+	for i, row := range rows {
+		var err error
+		if comics[i], err = row.comic(); err != nil {
+			return nil, err
+		}
+	}
+	return comics, nil
+}
+
+func (s *DB) AllComics(ctx context.Context) ([]core.Comics, error) {
+	var rows []comicRow
+	err := s.conn.SelectContext(ctx, &rows, `
+        SELECT `+comicColumns+`
         FROM comics
         ORDER BY id
     `)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch all comics: %w", err)
 	}
-
-	comics := make([]core.Comics, len(dbComics))
-	for i, c := range dbComics {
-		comics[i] = core.Comics{
-			ID:    c.ID,
-			URL:   c.URL,
-			Words: []string(c.Words),
-		}
-	}
-
-	return comics, nil
+	return toFullComics(rows)
 }
 
 func (s *DB) Ping(ctx context.Context) error {
 	return s.conn.PingContext(ctx)
 }
 
+// GetComicsByIDs returns everything stored about the comics in ID order.
 func (s *DB) GetComicsByIDs(ctx context.Context, ids []int) ([]core.Comics, error) {
 	if len(ids) == 0 {
 		return []core.Comics{}, nil
 	}
 
-	var rawComics []struct {
-		ID    int            `db:"id"`
-		URL   string         `db:"url"`
-		Words pq.StringArray `db:"words"`
-	}
-
-	query := `
-        SELECT id, url, words 
+	var rows []comicRow
+	err := s.conn.SelectContext(ctx, &rows, `
+        SELECT `+comicColumns+`
         FROM comics 
         WHERE id = ANY($1)
-    `
-	err := s.conn.SelectContext(ctx, &rawComics, query, pq.Array(ids))
+        ORDER BY id
+    `, pq.Array(ids))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comics: %w", err)
 	}
-
-	comics := make([]core.Comics, len(rawComics))
-	for i, raw := range rawComics {
-		comics[i] = core.Comics{
-			ID:    raw.ID,
-			URL:   raw.URL,
-			Words: []string(raw.Words),
-		}
-	}
-
-	return comics, nil
+	return toFullComics(rows)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"testing"
+	"time"
 
 	"yadro.com/course/search/core"
 
@@ -60,12 +61,111 @@ func TestSearchComics(t *testing.T) {
 		log:  slog.Default(),
 	}
 
+	t.Run("weighted search", func(t *testing.T) {
+		rows := sqlxmock.NewRows([]string{"id", "url", "published", "unique_matches", "weighted_matches", "total_matches"}).
+			AddRow(2, "http://example.com/2", time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC), 2, 4.0, 3).
+			AddRow(1, "http://example.com/1", nil, 1, 3.0, 1)
+
+		mock.ExpectQuery(`WITH search_terms AS .* unnest\(\$1::text\[\], \$2::int\[\], \$9::text\[\], \$10::float8\[\]\) .* ORDER BY .*unique_matches DESC, .*weighted_matches DESC, .*total_matches DESC LIMIT \$6`).
+			WithArgs(pq.Array([]string{"robot", "laser"}), pq.Array([]int32{7, 1}), 3.0, 1.5, 1.0, 10, nil, nil,
+				pq.Array([]string{"robot", "laser"}), pq.Array([]float64{1, 1}), pq.Array([]string(nil))).
+			WillReturnRows(rows)
+
+		result, err := d.SearchComics(context.Background(), []core.Term{
+			{Word: "robot", Fields: core.AllFields},
+			{Word: "laser", Fields: core.FieldTitle},
+		}, 10, core.DefaultWeights, core.Filter{})
+		assert.NoError(t, err)
+		assert.Equal(t, []core.Comics{
+			{ID: 2, URL: "http://example.com/2", Date: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				Explanation: &core.Explanation{Unique: 2, Weight: 4, Total: 3}},
+			{ID: 1, URL: "http://example.com/1", Explanation: &core.Explanation{Unique: 1, Weight: 3, Total: 1}},
+		}, result)
+	})
+
+	t.Run("synonyms count as the query word", func(t *testing.T) {
+		rows := sqlxmock.NewRows([]string{"id", "url", "published", "unique_matches", "weighted_matches", "total_matches"}).
+			AddRow(3, "http://example.com/3", nil, 1, 1.5, 2)
+
+		mock.ExpectQuery(`unnest\(\$1::text\[\], \$2::int\[\], \$9::text\[\], \$10::float8\[\]\) .* COUNT\(DISTINCT concept\)`).
+			WithArgs(pq.Array([]string{"car", "automobil"}), pq.Array([]int32{7, 7}), 3.0, 1.5, 1.0, 10, nil, nil,
+				pq.Array([]string{"car", "car"}), pq.Array([]float64{1, 0.5}), pq.Array([]string(nil))).
+			WillReturnRows(rows)
+
+		result, err := d.SearchComics(context.Background(), []core.Term{
+			{Word: "car", Fields: core.AllFields},
+			{Word: "automobil", Fields: core.AllFields, Origin: "car", Weight: 0.5},
+		}, 10, core.DefaultWeights, core.Filter{})
+		assert.NoError(t, err)
+		assert.Equal(t, []core.Comics{
+			{ID: 3, URL: "http://example.com/3", Explanation: &core.Explanation{Unique: 1, Weight: 1.5, Total: 2}},
+		}, result)
+	})
+
+	t.Run("date range newest first", func(t *testing.T) {
+		from := time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2010, time.December, 31, 0, 0, 0, 0, time.UTC)
+		rows := sqlxmock.NewRows([]string{"id", "url", "published", "unique_matches", "weighted_matches", "total_matches"}).
+			AddRow(700, "http://example.com/700", time.Date(2010, time.March, 5, 0, 0, 0, 0, time.UTC), 1, 1.0, 1)
+
+		mock.ExpectQuery(`c.published >= \$7.* c.published <= \$8.* ORDER BY published DESC NULLS LAST, .*unique_matches DESC`).
+			WithArgs(pq.Array([]string{"robot"}), pq.Array([]int32{7}), 3.0, 1.5, 1.0, 10, from, to,
+				pq.Array([]string{"robot"}), pq.Array([]float64{1}), pq.Array([]string(nil))).
+			WillReturnRows(rows)
+
+		result, err := d.SearchComics(context.Background(), []core.Term{{Word: "robot", Fields: core.AllFields}}, 10,
+			core.DefaultWeights, core.Filter{From: from, To: to, Sort: core.SortNewest})
+		assert.NoError(t, err)
+		assert.Equal(t, []core.Comics{
+			{ID: 700, URL: "http://example.com/700", Date: time.Date(2010, time.March, 5, 0, 0, 0, 0, time.UTC),
+				Explanation: &core.Explanation{Unique: 1, Weight: 1, Total: 1}},
+		}, result)
+	})
+
+	t.Run("dialogue of speakers", func(t *testing.T) {
+		rows := sqlxmock.NewRows([]string{"id", "url", "published", "unique_matches", "weighted_matches", "total_matches"}).
+			AddRow(5, "http://example.com/5", nil, 1, 1.0, 1)
+
+		mock.ExpectQuery(`st.fields & 8 <> 0 AND st.word = ANY\(c.dialogue_words\).* unnest\(c.speakers\) .* ANY\(\$11\)`).
+			WithArgs(pq.Array([]string{"chess"}), pq.Array([]int32{8}), 3.0, 1.5, 1.0, 10, nil, nil,
+				pq.Array([]string{"chess"}), pq.Array([]float64{1}), pq.Array([]string{"blackhat"})).
+			WillReturnRows(rows)
+
+		result, err := d.SearchComics(context.Background(), []core.Term{{Word: "chess", Fields: core.FieldDialogue}}, 10,
+			core.DefaultWeights, core.Filter{Speakers: []string{"blackhat"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []core.Comics{
+			{ID: 5, URL: "http://example.com/5", Explanation: &core.Explanation{Unique: 1, Weight: 1, Total: 1}},
+		}, result)
+	})
+
+	t.Run("speakers only", func(t *testing.T) {
+		rows := sqlxmock.NewRows([]string{"id", "url", "published"}).
+			AddRow(5, "http://example.com/5", nil)
+
+		mock.ExpectQuery(`SELECT c.id, c.url, c.published FROM comics c WHERE .* unnest\(c.speakers\) .* ANY\(\$4\).* ORDER BY c.id LIMIT \$1`).
+			WithArgs(10, nil, nil, pq.Array([]string{"blackhat"})).
+			WillReturnRows(rows)
+
+		result, err := d.SearchComics(context.Background(), nil, 10, core.DefaultWeights, core.Filter{Speakers: []string{"blackhat"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []core.Comics{{ID: 5, URL: "http://example.com/5", Explanation: &core.Explanation{}}}, result)
+	})
+
+	t.Run("no terms", func(t *testing.T) {
+		result, err := d.SearchComics(context.Background(), nil, 10, core.DefaultWeights, core.Filter{})
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
 	t.Run("query error", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT id, url FROM comics WHERE words && \$1.*`).
-			WithArgs(pq.Array([]string{"test"})).
+		mock.ExpectQuery(`WITH search_terms AS .* ORDER BY id LIMIT \$6`).
+			WithArgs(pq.Array([]string{"test"}), pq.Array([]int32{7}), 3.0, 1.5, 1.0, 10, nil, nil,
+				pq.Array([]string{"test"}), pq.Array([]float64{1}), pq.Array([]string(nil))).
 			WillReturnError(errors.New("query failed"))
 
-		_, err := d.SearchComics(context.Background(), []string{"test"}, 10)
+		_, err := d.SearchComics(context.Background(), []core.Term{{Word: "test", Fields: core.AllFields}}, 10,
+			core.DefaultWeights, core.Filter{Sort: core.SortID})
 		assert.Error(t, err)
 	})
 }
@@ -126,15 +226,37 @@ func TestAllComics(t *testing.T) {
 
 	t.Run("successful fetch", func(t *testing.T) {
 		expected := []core.Comics{
-			{ID: 1, URL: "http://example.com/1", Words: []string{"test", "comic"}},
-			{ID: 2, URL: "http://example.com/2", Words: []string{"example"}},
+			{
+				ID: 1, URL: "http://example.com/1", Words: []string{"test", "comic"},
+				Forms: map[string]string{"test": "testing", "comic": "comics"},
+				Title: "Testing", Alt: "Comics are fun", Transcript: "",
+				Date:       time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+				TitleWords: []string{"test"}, AltWords: []string{"comic"}, TranscriptWords: []string{},
+				TitleTokens: []core.Token{{Start: 0, End: 7, Term: "test"}},
+				Bigrams:     []string{"test comic"},
+				Dialogue:    []core.Line{{Speaker: "Black Hat", Text: "Testing.", Words: []string{"test"}}},
+				Speakers:    []string{"Black Hat"}, Scenes: []string{"A lab."},
+				DialogueWords: []string{"test"}, SceneWords: []string{},
+			},
+			{
+				ID: 2, URL: "http://example.com/2", Words: []string{"example"}, Forms: map[string]string{},
+				TitleWords: []string{}, AltWords: []string{}, TranscriptWords: []string{"example"},
+				Bigrams: []string{}, Speakers: []string{}, Scenes: []string{},
+				DialogueWords: []string{}, SceneWords: []string{},
+			},
 		}
 
-		rows := sqlxmock.NewRows([]string{"id", "url", "words"}).
-			AddRow(1, "http://example.com/1", pq.Array([]string{"test", "comic"})).
-			AddRow(2, "http://example.com/2", pq.Array([]string{"example"}))
-
-		mock.ExpectQuery(`SELECT id, url, words FROM comics ORDER BY id`).
+		rows := sqlxmock.NewRows(allComicsColumns).
+			AddRow(1, "http://example.com/1", pq.Array([]string{"test", "comic"}), []byte(`{"test": "testing", "comic": "comics"}`), "Testing", "Comics are fun", "",
+				pq.Array([]string{"test"}), pq.Array([]string{"comic"}), pq.Array([]string{}), time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+				pq.Array([]string{"test comic"}), []byte(`[{"speaker": "Black Hat", "text": "Testing.", "words": ["test"]}]`),
+				pq.Array([]string{"Black Hat"}), pq.Array([]string{"A lab."}), pq.Array([]string{"test"}), pq.Array([]string{}),
+				[]byte(`{"title": [{"start": 0, "end": 7, "term": "test"}], "alt": [], "transcript": []}`)).
+			AddRow(2, "http://example.com/2", pq.Array([]string{"example"}), []byte(`{}`), "", "", "",
+				pq.Array([]string{}), pq.Array([]string{}), pq.Array([]string{"example"}), nil, pq.Array([]string{}),
+				[]byte(`[]`), pq.Array([]string{}), pq.Array([]string{}), pq.Array([]string{}), pq.Array([]string{}), []byte(`{}`))
+
+		mock.ExpectQuery(`SELECT id, url, words, forms, title, alt, transcript, title_words, alt_words, transcript_words, published, bigrams, dialogue, speakers, scenes, dialogue_words, scene_words, tokens FROM comics ORDER BY id`).
 			WillReturnRows(rows)
 
 		result, err := d.AllComics(context.Background())
@@ -143,8 +265,8 @@ func TestAllComics(t *testing.T) {
 	})
 
 	t.Run("empty result", func(t *testing.T) {
-		rows := sqlxmock.NewRows([]string{"id", "url", "words"})
-		mock.ExpectQuery(`SELECT id, url, words FROM comics ORDER BY id`).
+		rows := sqlxmock.NewRows(allComicsColumns)
+		mock.ExpectQuery(`SELECT id, url, words, forms, title, alt, transcript, title_words, alt_words, transcript_words, published, bigrams, dialogue, speakers, scenes, dialogue_words, scene_words, tokens FROM comics ORDER BY id`).
 			WillReturnRows(rows)
 
 		result, err := d.AllComics(context.Background())
@@ -166,14 +288,23 @@ func TestGetComicsByIDs(t *testing.T) {
 	}
 
 	t.Run("successful fetch", func(t *testing.T) {
-		expected := []core.Comics{
-			{ID: 1, URL: "http://example.com/1", Words: []string{"test"}},
-		}
-
-		rows := sqlxmock.NewRows([]string{"id", "url", "words"}).
-			AddRow(1, "http://example.com/1", pq.Array([]string{"test"}))
-
-		mock.ExpectQuery(`SELECT id, url, words FROM comics WHERE id = ANY\(\$1\)`).
+		expected := []core.Comics{{
+			ID: 1, URL: "http://example.com/1", Words: []string{"test"},
+			Forms: map[string]string{"test": "testing"},
+			Title: "Testing", Alt: "Alt", Transcript: "[[A test]]",
+			Date:       time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+			TitleWords: []string{"test"}, AltWords: []string{}, TranscriptWords: []string{"test"},
+			Bigrams: []string{}, Speakers: []string{}, Scenes: []string{"A test"},
+			DialogueWords: []string{}, SceneWords: []string{"test"},
+		}}
+
+		rows := sqlxmock.NewRows(allComicsColumns).
+			AddRow(1, "http://example.com/1", pq.Array([]string{"test"}), []byte(`{"test": "testing"}`), "Testing", "Alt", "[[A test]]",
+				pq.Array([]string{"test"}), pq.Array([]string{}), pq.Array([]string{"test"}), time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+				pq.Array([]string{}), []byte(`[]`), pq.Array([]string{}), pq.Array([]string{"A test"}),
+				pq.Array([]string{}), pq.Array([]string{"test"}), []byte(`{}`))
+
+		mock.ExpectQuery(`SELECT id, url, words, forms, .* published, bigrams, dialogue, speakers, scenes, dialogue_words, scene_words, tokens FROM comics WHERE id = ANY\(\$1\) ORDER BY id`).
 			WithArgs(pq.Array([]int{1})).
 			WillReturnRows(rows)
 
@@ -189,7 +320,7 @@ func TestGetComicsByIDs(t *testing.T) {
 	})
 
 	t.Run("query error", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT id, url, words FROM comics WHERE id = ANY\(\$1\)`).
+		mock.ExpectQuery(`SELECT id, url, words, .* FROM comics WHERE id = ANY\(\$1\)`).
 			WithArgs(pq.Array([]int{1})).
 			WillReturnError(errors.New("query failed"))
 
@@ -198,4 +329,10 @@ func TestGetComicsByIDs(t *testing.T) {
 	})
 }
 
+var allComicsColumns = []string{
+	"id", "url", "words", "forms", "title", "alt", "transcript",
+	"title_words", "alt_words", "transcript_words", "published", "bigrams",
+	"dialogue", "speakers", "scenes", "dialogue_words", "scene_words", "tokens",
+}
+
 var sqlxConnect = sqlx.Connect
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	core "yadro.com/course/search/core"
@@ -35,34 +36,198 @@ func (m *MockSearcher) EXPECT() *MockSearcherMockRecorder {
 	return m.recorder
 }
 
+// Analytics mocks base method.
+func (m *MockSearcher) Analytics(ctx context.Context, window time.Duration, limit int) (core.Analytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Analytics", ctx, window, limit)
+	ret0, _ := ret[0].(core.Analytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Analytics indicates an expected call of Analytics.
+func (mr *MockSearcherMockRecorder) Analytics(ctx, window, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Analytics", reflect.TypeOf((*MockSearcher)(nil).Analytics), ctx, window, limit)
+}
+
+// Correct mocks base method.
+func (m *MockSearcher) Correct(ctx context.Context, phrase string) (core.Corrected, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Correct", ctx, phrase)
+	ret0, _ := ret[0].(core.Corrected)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Correct indicates an expected call of Correct.
+func (mr *MockSearcherMockRecorder) Correct(ctx, phrase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correct", reflect.TypeOf((*MockSearcher)(nil).Correct), ctx, phrase)
+}
+
+// Generation mocks base method.
+func (m *MockSearcher) Generation(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generation", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generation indicates an expected call of Generation.
+func (mr *MockSearcherMockRecorder) Generation(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generation", reflect.TypeOf((*MockSearcher)(nil).Generation), ctx)
+}
+
+// GetComic mocks base method.
+func (m *MockSearcher) GetComic(ctx context.Context, id int) (core.ComicDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComic", ctx, id)
+	ret0, _ := ret[0].(core.ComicDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComic indicates an expected call of GetComic.
+func (mr *MockSearcherMockRecorder) GetComic(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComic", reflect.TypeOf((*MockSearcher)(nil).GetComic), ctx, id)
+}
+
 // IndexSearch mocks base method.
-func (m *MockSearcher) IndexSearch(ctx context.Context, phrase string, limit int) (core.SearchResult, error) {
+func (m *MockSearcher) IndexSearch(ctx context.Context, phrase string, limit int, opts core.SearchOptions) (core.SearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IndexSearch", ctx, phrase, limit)
+	ret := m.ctrl.Call(m, "IndexSearch", ctx, phrase, limit, opts)
 	ret0, _ := ret[0].(core.SearchResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // IndexSearch indicates an expected call of IndexSearch.
-func (mr *MockSearcherMockRecorder) IndexSearch(ctx, phrase, limit interface{}) *gomock.Call {
+func (mr *MockSearcherMockRecorder) IndexSearch(ctx, phrase, limit, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexSearch", reflect.TypeOf((*MockSearcher)(nil).IndexSearch), ctx, phrase, limit, opts)
+}
+
+// IndexStats mocks base method.
+func (m *MockSearcher) IndexStats(ctx context.Context, limit int) (core.IndexStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexStats", ctx, limit)
+	ret0, _ := ret[0].(core.IndexStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexStats indicates an expected call of IndexStats.
+func (mr *MockSearcherMockRecorder) IndexStats(ctx, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexSearch", reflect.TypeOf((*MockSearcher)(nil).IndexSearch), ctx, phrase, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexStats", reflect.TypeOf((*MockSearcher)(nil).IndexStats), ctx, limit)
+}
+
+// RandomComic mocks base method.
+func (m *MockSearcher) RandomComic(ctx context.Context) (core.ComicDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RandomComic", ctx)
+	ret0, _ := ret[0].(core.ComicDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RandomComic indicates an expected call of RandomComic.
+func (mr *MockSearcherMockRecorder) RandomComic(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomComic", reflect.TypeOf((*MockSearcher)(nil).RandomComic), ctx)
 }
 
 // Search mocks base method.
-func (m *MockSearcher) Search(ctx context.Context, phrase string, limit int) (core.SearchResult, error) {
+func (m *MockSearcher) Search(ctx context.Context, phrase string, limit int, opts core.SearchOptions) (core.SearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, phrase, limit)
+	ret := m.ctrl.Call(m, "Search", ctx, phrase, limit, opts)
 	ret0, _ := ret[0].(core.SearchResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockSearcherMockRecorder) Search(ctx, phrase, limit interface{}) *gomock.Call {
+func (mr *MockSearcherMockRecorder) Search(ctx, phrase, limit, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearcher)(nil).Search), ctx, phrase, limit, opts)
+}
+
+// SetSynonyms mocks base method.
+func (m *MockSearcher) SetSynonyms(ctx context.Context, groups [][]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSynonyms", ctx, groups)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSynonyms indicates an expected call of SetSynonyms.
+func (mr *MockSearcherMockRecorder) SetSynonyms(ctx, groups interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSynonyms", reflect.TypeOf((*MockSearcher)(nil).SetSynonyms), ctx, groups)
+}
+
+// Similar mocks base method.
+func (m *MockSearcher) Similar(ctx context.Context, id, limit int) (core.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Similar", ctx, id, limit)
+	ret0, _ := ret[0].(core.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Similar indicates an expected call of Similar.
+func (mr *MockSearcherMockRecorder) Similar(ctx, id, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Similar", reflect.TypeOf((*MockSearcher)(nil).Similar), ctx, id, limit)
+}
+
+// Suggest mocks base method.
+func (m *MockSearcher) Suggest(ctx context.Context, prefix string, limit int) ([]core.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, prefix, limit)
+	ret0, _ := ret[0].([]core.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSearcherMockRecorder) Suggest(ctx, prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSearcher)(nil).Suggest), ctx, prefix, limit)
+}
+
+// Synonyms mocks base method.
+func (m *MockSearcher) Synonyms(ctx context.Context) ([][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Synonyms", ctx)
+	ret0, _ := ret[0].([][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Synonyms indicates an expected call of Synonyms.
+func (mr *MockSearcherMockRecorder) Synonyms(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Synonyms", reflect.TypeOf((*MockSearcher)(nil).Synonyms), ctx)
+}
+
+// TermInfo mocks base method.
+func (m *MockSearcher) TermInfo(ctx context.Context, term string, limit int) (core.TermInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TermInfo", ctx, term, limit)
+	ret0, _ := ret[0].(core.TermInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TermInfo indicates an expected call of TermInfo.
+func (mr *MockSearcherMockRecorder) TermInfo(ctx, term, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearcher)(nil).Search), ctx, phrase, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TermInfo", reflect.TypeOf((*MockSearcher)(nil).TermInfo), ctx, term, limit)
 }
 
 // MockIndexer is a mock of Indexer interface.
@@ -103,10 +268,10 @@ func (mr *MockIndexerMockRecorder) BuildIndex(ctx interface{}) *gomock.Call {
 }
 
 // GetIndex mocks base method.
-func (m *MockIndexer) GetIndex(ctx context.Context) core.Index {
+func (m *MockIndexer) GetIndex(ctx context.Context) *core.Index {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetIndex", ctx)
-	ret0, _ := ret[0].(core.Index)
+	ret0, _ := ret[0].(*core.Index)
 	return ret0
 }
 
@@ -170,18 +335,18 @@ func (mr *MockDBMockRecorder) GetComicsByIDs(ctx, ids interface{}) *gomock.Call
 }
 
 // SearchComics mocks base method.
-func (m *MockDB) SearchComics(ctx context.Context, words []string, limit int) ([]core.Comics, error) {
+func (m *MockDB) SearchComics(ctx context.Context, terms []core.Term, limit int, weights core.FieldWeights, filter core.Filter) ([]core.Comics, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchComics", ctx, words, limit)
+	ret := m.ctrl.Call(m, "SearchComics", ctx, terms, limit, weights, filter)
 	ret0, _ := ret[0].([]core.Comics)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchComics indicates an expected call of SearchComics.
-func (mr *MockDBMockRecorder) SearchComics(ctx, words, limit interface{}) *gomock.Call {
+func (mr *MockDBMockRecorder) SearchComics(ctx, terms, limit, weights, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchComics", reflect.TypeOf((*MockDB)(nil).SearchComics), ctx, words, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchComics", reflect.TypeOf((*MockDB)(nil).SearchComics), ctx, terms, limit, weights, filter)
 }
 
 // Stats mocks base method.
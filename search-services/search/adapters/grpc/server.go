@@ -2,6 +2,9 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -20,44 +23,378 @@ type Server struct {
 }
 
 func (s *Server) Search(ctx context.Context, req *searchpb.SearchRequest) (*searchpb.SearchResponse, error) {
-	result, err := s.service.Search(ctx, req.Phrase, int(req.Limit))
+	filter, err := toFilter(req.From, req.To, req.Sort)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-
-	var comics []*searchpb.Comic
-	for _, comic := range result.Comics {
-		comics = append(comics, &searchpb.Comic{
-			Id:  int32(comic.ID),
-			Url: comic.URL,
-		})
+	result, err := s.service.Search(ctx, req.Phrase, int(req.Limit), core.SearchOptions{
+		Fuzzy:   req.Fuzzy,
+		Weights: req.Weights,
+		Filter:  filter,
+		Explain: req.Explain,
+		Mode:    req.Mode,
+	})
+	if err != nil {
+		if errors.Is(err, core.ErrBadArguments) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return &searchpb.SearchResponse{
-		Comics: comics,
-		Total:  int32(result.Total),
+		Comics:     toComics(result.Comics),
+		Total:      int32(result.Total),
+		Suggestion: result.Suggestion,
+		Explain:    toQueryExplanation(result.Explain),
+		Generation: result.Generation,
 	}, nil
 }
 
 func (s *Server) IndexSearch(ctx context.Context, req *searchpb.IndexSearchRequest) (*searchpb.SearchResponse, error) {
-	result, err := s.service.IndexSearch(ctx, req.Phrase, int(req.Limit))
+	filter, err := toFilter(req.From, req.To, req.Sort)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	result, err := s.service.IndexSearch(ctx, req.Phrase, int(req.Limit), core.SearchOptions{
+		Fuzzy:   req.Fuzzy,
+		Weights: req.Weights,
+		Filter:  filter,
+		Explain: req.Explain,
+		Mode:    req.Mode,
+	})
 	if err != nil {
+		if errors.Is(err, core.ErrBadArguments) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	var comics []*searchpb.Comic
-	for _, comic := range result.Comics {
-		comics = append(comics, &searchpb.Comic{
-			Id:  int32(comic.ID),
-			Url: comic.URL,
-		})
+	return &searchpb.SearchResponse{
+		Comics:     toComics(result.Comics),
+		Total:      int32(result.Total),
+		Suggestion: result.Suggestion,
+		Explain:    toQueryExplanation(result.Explain),
+		Generation: result.Generation,
+	}, nil
+}
+
+func (s *Server) Similar(ctx context.Context, req *searchpb.SimilarRequest) (*searchpb.SearchResponse, error) {
+	result, err := s.service.Similar(ctx, int(req.Id), int(req.Limit))
+	if err != nil {
+		switch {
+		case errors.Is(err, core.ErrBadArguments):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, core.ErrNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
 	}
+
 	return &searchpb.SearchResponse{
-		Comics: comics,
-		Total:  int32(result.Total),
+		Comics:     toComics(result.Comics),
+		Total:      int32(result.Total),
+		Generation: result.Generation,
 	}, nil
 }
 
+func (s *Server) Suggest(ctx context.Context, req *searchpb.SuggestRequest) (*searchpb.SuggestResponse, error) {
+	suggestions, err := s.service.Suggest(ctx, req.Prefix, int(req.Limit))
+	if err != nil {
+		if errors.Is(err, core.ErrBadArguments) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &searchpb.SuggestResponse{}
+	for _, suggestion := range suggestions {
+		resp.Suggestions = append(resp.Suggestions, &searchpb.Suggestion{
+			Word:  suggestion.Word,
+			Count: int32(suggestion.Count),
+		})
+	}
+	return resp, nil
+}
+
 func (s *Server) Ping(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
 	return &emptypb.Empty{}, nil
 }
+
+func (s *Server) GetComic(ctx context.Context, req *searchpb.GetComicRequest) (*searchpb.ComicDetail, error) {
+	comic, err := s.service.GetComic(ctx, int(req.Id))
+	if err != nil {
+		return nil, lookupError(err)
+	}
+	return toComicDetail(comic), nil
+}
+
+func (s *Server) RandomComic(ctx context.Context, _ *emptypb.Empty) (*searchpb.ComicDetail, error) {
+	comic, err := s.service.RandomComic(ctx)
+	if err != nil {
+		return nil, lookupError(err)
+	}
+	return toComicDetail(comic), nil
+}
+
+func (s *Server) IndexStats(ctx context.Context, req *searchpb.IndexStatsRequest) (*searchpb.IndexStatsResponse, error) {
+	stats, err := s.service.IndexStats(ctx, int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &searchpb.IndexStatsResponse{
+		Generation:      stats.Generation,
+		BuildDurationMs: stats.BuildDuration.Milliseconds(),
+		Documents:       int32(stats.Documents),
+		Terms:           int32(stats.Terms),
+		TopTerms:        toTermStats(stats.TopTerms),
+		RareTerms:       toTermStats(stats.RareTerms),
+		Cache: &searchpb.CacheStats{
+			Hits:     stats.Cache.Hits,
+			Misses:   stats.Cache.Misses,
+			Entries:  int32(stats.Cache.Entries),
+			Capacity: int32(stats.Cache.Capacity),
+		},
+		WordsClient: &searchpb.WordsClientStats{
+			Hits:          stats.WordsClient.Hits,
+			Misses:        stats.WordsClient.Misses,
+			Entries:       int32(stats.WordsClient.Entries),
+			Capacity:      int32(stats.WordsClient.Capacity),
+			Invalidations: stats.WordsClient.Invalidations,
+			Retries:       stats.WordsClient.Retries,
+		},
+	}
+	if !stats.BuiltAt.IsZero() {
+		resp.BuiltAt = stats.BuiltAt.Format(time.RFC3339)
+	}
+	return resp, nil
+}
+
+func (s *Server) Generation(ctx context.Context, _ *emptypb.Empty) (*searchpb.GenerationResponse, error) {
+	generation, err := s.service.Generation(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &searchpb.GenerationResponse{Generation: generation}, nil
+}
+
+func (s *Server) TermInfo(ctx context.Context, req *searchpb.TermInfoRequest) (*searchpb.TermInfoResponse, error) {
+	info, err := s.service.TermInfo(ctx, req.Term, int(req.Limit))
+	if err != nil {
+		return nil, lookupError(err)
+	}
+
+	resp := &searchpb.TermInfoResponse{
+		Stats: toTermStats([]core.TermStats{info.TermStats})[0],
+		Form:  info.Form,
+		Idf:   info.IDF,
+	}
+	for _, p := range info.Postings {
+		resp.Postings = append(resp.Postings, &searchpb.Posting{
+			Id:     int32(p.ID),
+			Freq:   int32(p.Freq),
+			Fields: p.Fields,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) Correct(ctx context.Context, req *searchpb.CorrectRequest) (*searchpb.CorrectResponse, error) {
+	corrected, err := s.service.Correct(ctx, req.Phrase)
+	if err != nil {
+		return nil, lookupError(err)
+	}
+
+	resp := &searchpb.CorrectResponse{Phrase: corrected.Phrase, Generation: corrected.Generation}
+	for _, c := range corrected.Corrections {
+		resp.Corrections = append(resp.Corrections, &searchpb.Correction{
+			Word:       c.Word,
+			Correction: c.Correction,
+			Distance:   int32(c.Distance),
+			Count:      int32(c.Count),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) Analytics(ctx context.Context, req *searchpb.AnalyticsRequest) (*searchpb.AnalyticsResponse, error) {
+	analytics, err := s.service.Analytics(ctx, time.Duration(req.WindowSeconds)*time.Second, int(req.Limit))
+	if err != nil {
+		return nil, lookupError(err)
+	}
+
+	resp := &searchpb.AnalyticsResponse{
+		Since:       analytics.Since.Format(time.RFC3339),
+		Queries:     int32(analytics.Queries),
+		TopQueries:  toQueryCounts(analytics.TopQueries),
+		ZeroResults: toQueryCounts(analytics.ZeroResults),
+	}
+	for _, l := range analytics.Latency {
+		resp.Latency = append(resp.Latency, &searchpb.LatencyStats{
+			Mode:  l.Mode,
+			Count: int32(l.Count),
+			P50Ms: milliseconds(l.P50),
+			P90Ms: milliseconds(l.P90),
+			P99Ms: milliseconds(l.P99),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) Synonyms(ctx context.Context, _ *emptypb.Empty) (*searchpb.SynonymGroups, error) {
+	groups, err := s.service.Synonyms(ctx)
+	if err != nil {
+		return nil, lookupError(err)
+	}
+	resp := &searchpb.SynonymGroups{}
+	for _, words := range groups {
+		resp.Groups = append(resp.Groups, &searchpb.SynonymGroup{Words: words})
+	}
+	return resp, nil
+}
+
+func (s *Server) SetSynonyms(ctx context.Context, req *searchpb.SynonymGroups) (*emptypb.Empty, error) {
+	groups := make([][]string, len(req.Groups))
+	for i, g := range req.Groups {
+		groups[i] = g.GetWords()
+	}
+	if err := s.service.SetSynonyms(ctx, groups); err != nil {
+		return nil, lookupError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func toQueryCounts(counts []core.QueryCount) []*searchpb.QueryCount {
+	pb := make([]*searchpb.QueryCount, len(counts))
+	for i, c := range counts {
+		pb[i] = &searchpb.QueryCount{Phrase: c.Phrase, Count: int32(c.Count)}
+	}
+	return pb
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func toTermStats(terms []core.TermStats) []*searchpb.TermStats {
+	pb := make([]*searchpb.TermStats, len(terms))
+	for i, t := range terms {
+		pb[i] = &searchpb.TermStats{Term: t.Term, Docs: int32(t.Docs), Total: int32(t.Total)}
+	}
+	return pb
+}
+
+func lookupError(err error) error {
+	switch {
+	case errors.Is(err, core.ErrBadArguments):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, core.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toComicDetail(comic core.ComicDetail) *searchpb.ComicDetail {
+	pb := &searchpb.ComicDetail{
+		Id:              int32(comic.ID),
+		Url:             comic.URL,
+		Title:           comic.Title,
+		Alt:             comic.Alt,
+		Transcript:      comic.Transcript,
+		Words:           comic.Words,
+		Forms:           comic.Forms,
+		TitleWords:      comic.TitleWords,
+		AltWords:        comic.AltWords,
+		TranscriptWords: comic.TranscriptWords,
+		Prev:            int32(comic.Prev),
+		Next:            int32(comic.Next),
+		Bigrams:         comic.Bigrams,
+		DialogueWords:   comic.DialogueWords,
+		SceneWords:      comic.SceneWords,
+		Speakers:        comic.Speakers,
+		Scenes:          comic.Scenes,
+	}
+	if !comic.Date.IsZero() {
+		pb.Date = comic.Date.Format(time.DateOnly)
+	}
+	for _, line := range comic.Dialogue {
+		pb.Dialogue = append(pb.Dialogue, &searchpb.Line{Speaker: line.Speaker, Text: line.Text, Words: line.Words})
+	}
+	return pb
+}
+
+func toFilter(from, to, sort string) (core.Filter, error) {
+	filter := core.Filter{Sort: core.Sort(sort)}
+	var err error
+	if from != "" {
+		if filter.From, err = time.Parse(time.DateOnly, from); err != nil {
+			return filter, fmt.Errorf("invalid from date: %w", err)
+		}
+	}
+	if to != "" {
+		if filter.To, err = time.Parse(time.DateOnly, to); err != nil {
+			return filter, fmt.Errorf("invalid to date: %w", err)
+		}
+	}
+	return filter, nil
+}
+
+func toComics(comics []core.Comics) []*searchpb.Comic {
+	var res []*searchpb.Comic
+	for _, comic := range comics {
+		pb := &searchpb.Comic{
+			Id:    int32(comic.ID),
+			Url:   comic.URL,
+			Terms: comic.Terms,
+			Score: comic.Score,
+		}
+		if !comic.Date.IsZero() {
+			pb.Date = comic.Date.Format(time.DateOnly)
+		}
+		if comic.Explanation != nil {
+			pb.Explanation = toExplanation(*comic.Explanation)
+		}
+		if comic.Snippet != nil {
+			pb.Snippet = &searchpb.Snippet{
+				Field: comic.Snippet.Field,
+				Text:  comic.Snippet.Text,
+			}
+			for _, h := range comic.Snippet.Highlights {
+				pb.Snippet.Highlights = append(pb.Snippet.Highlights, &searchpb.Highlight{
+					Start: int32(h.Start),
+					End:   int32(h.End),
+				})
+			}
+		}
+		res = append(res, pb)
+	}
+	return res
+}
+
+func toQueryExplanation(e *core.QueryExplanation) *searchpb.QueryExplanation {
+	if e == nil {
+		return nil
+	}
+	return &searchpb.QueryExplanation{Path: e.Path, Generation: e.Generation, Terms: e.Terms, Phrases: e.Phrases}
+}
+
+func toExplanation(e core.Explanation) *searchpb.Explanation {
+	pb := &searchpb.Explanation{
+		Unique:  int32(e.Unique),
+		Phrases: e.Phrases,
+		Weight:  e.Weight,
+		Total:   int32(e.Total),
+		Rank:    e.Rank,
+	}
+	for _, t := range e.Terms {
+		pb.Terms = append(pb.Terms, &searchpb.TermMatch{
+			Term:    t.Term,
+			Origin:  t.Origin,
+			Fields:  t.Fields,
+			Matched: t.Matched,
+			Freq:    int32(t.Freq),
+			Weight:  t.Weight,
+		})
+	}
+	return pb
+}
@@ -3,7 +3,9 @@ package grpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -40,6 +42,74 @@ func TestServer_Ping(t *testing.T) {
 	assert.IsType(t, &emptypb.Empty{}, resp)
 }
 
+func TestServer_Suggest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockserver.NewMockSearcher(ctrl)
+	server := NewServer(mockService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.EXPECT().Suggest(gomock.Any(), "ru", 5).
+			Return([]core.Suggestion{{Word: "running", Count: 2}}, nil)
+
+		resp, err := server.Suggest(context.Background(), &searchpb.SuggestRequest{Prefix: "ru", Limit: 5})
+		assert.NoError(t, err)
+		assert.Equal(t, []*searchpb.Suggestion{{Word: "running", Count: 2}}, resp.Suggestions)
+	})
+
+	t.Run("bad prefix", func(t *testing.T) {
+		mockService.EXPECT().Suggest(gomock.Any(), "", 5).
+			Return(nil, core.ErrBadArguments)
+
+		_, err := server.Suggest(context.Background(), &searchpb.SuggestRequest{Limit: 5})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestServer_Generation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockserver.NewMockSearcher(ctrl)
+	server := NewServer(mockService)
+
+	mockService.EXPECT().Generation(gomock.Any()).Return(uint64(4), nil)
+	resp, err := server.Generation(context.Background(), &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), resp.Generation)
+}
+
+func TestServer_Correct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockserver.NewMockSearcher(ctrl)
+	server := NewServer(mockService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.EXPECT().Correct(gomock.Any(), "kernal panic").
+			Return(core.Corrected{
+				Phrase:      "kernel panic",
+				Corrections: []core.Correction{{Word: "kernal", Correction: "kernel", Distance: 1, Count: 3}},
+				Generation:  5,
+			}, nil)
+
+		resp, err := server.Correct(context.Background(), &searchpb.CorrectRequest{Phrase: "kernal panic"})
+		assert.NoError(t, err)
+		assert.Equal(t, "kernel panic", resp.Phrase)
+		assert.Equal(t, uint64(5), resp.Generation)
+		assert.Equal(t, []*searchpb.Correction{{Word: "kernal", Correction: "kernel", Distance: 1, Count: 3}}, resp.Corrections)
+	})
+
+	t.Run("empty phrase", func(t *testing.T) {
+		mockService.EXPECT().Correct(gomock.Any(), "").Return(core.Corrected{}, core.ErrBadArguments)
+
+		_, err := server.Correct(context.Background(), &searchpb.CorrectRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
 func TestServer_Search(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -52,7 +122,7 @@ func TestServer_Search(t *testing.T) {
 		{
 			name: "Successful search",
 			mockSetup: func(m *mockserver.MockSearcher) {
-				m.EXPECT().Search(gomock.Any(), "test", 10).
+				m.EXPECT().Search(gomock.Any(), "test", 10, core.SearchOptions{}).
 					Return(core.SearchResult{
 						Comics: []core.Comics{
 							{ID: 1, URL: "http://example.com/1"},
@@ -77,7 +147,7 @@ func TestServer_Search(t *testing.T) {
 		{
 			name: "Empty result",
 			mockSetup: func(m *mockserver.MockSearcher) {
-				m.EXPECT().Search(gomock.Any(), "empty", 10).
+				m.EXPECT().Search(gomock.Any(), "empty", 10, core.SearchOptions{}).
 					Return(core.SearchResult{
 						Comics: []core.Comics{},
 						Total:  0,
@@ -96,7 +166,7 @@ func TestServer_Search(t *testing.T) {
 		{
 			name: "Internal error",
 			mockSetup: func(m *mockserver.MockSearcher) {
-				m.EXPECT().Search(gomock.Any(), "error", 10).
+				m.EXPECT().Search(gomock.Any(), "error", 10, core.SearchOptions{}).
 					Return(core.SearchResult{}, errors.New("search error"))
 			},
 			req: &searchpb.SearchRequest{
@@ -106,6 +176,55 @@ func TestServer_Search(t *testing.T) {
 			expectedErr:  errors.New("search error"),
 			expectedCode: codes.Internal,
 		},
+		{
+			name: "Bad weights",
+			mockSetup: func(m *mockserver.MockSearcher) {
+				m.EXPECT().Search(gomock.Any(), "test", 10, core.SearchOptions{Weights: map[string]float64{"body": 2}}).
+					Return(core.SearchResult{}, fmt.Errorf("%w: unknown field \"body\"", core.ErrBadArguments))
+			},
+			req: &searchpb.SearchRequest{
+				Phrase:  "test",
+				Limit:   10,
+				Weights: map[string]float64{"body": 2},
+			},
+			expectedErr:  core.ErrBadArguments,
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "Date range and sort",
+			mockSetup: func(m *mockserver.MockSearcher) {
+				m.EXPECT().Search(gomock.Any(), "robot", 10, core.SearchOptions{Filter: core.Filter{
+					From: time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2010, time.December, 31, 0, 0, 0, 0, time.UTC),
+					Sort: core.SortNewest,
+				}}).
+					Return(core.SearchResult{
+						Comics: []core.Comics{{ID: 700, URL: "http://example.com/700", Date: time.Date(2010, time.March, 5, 0, 0, 0, 0, time.UTC)}},
+						Total:  1,
+					}, nil)
+			},
+			req: &searchpb.SearchRequest{
+				Phrase: "robot",
+				Limit:  10,
+				From:   "2010-01-01",
+				To:     "2010-12-31",
+				Sort:   "newest",
+			},
+			expectedResp: &searchpb.SearchResponse{
+				Comics: []*searchpb.Comic{{Id: 700, Url: "http://example.com/700", Date: "2010-03-05"}},
+				Total:  1,
+			},
+		},
+		{
+			name:      "Bad date",
+			mockSetup: func(m *mockserver.MockSearcher) {},
+			req: &searchpb.SearchRequest{
+				Phrase: "robot",
+				From:   "2010",
+			},
+			expectedErr:  errors.New("invalid from date"),
+			expectedCode: codes.InvalidArgument,
+		},
 	}
 
 	for _, tt := range tests {
@@ -145,7 +264,7 @@ func TestServer_IndexSearch(t *testing.T) {
 		{
 			name: "Successful index search",
 			mockSetup: func(m *mockserver.MockSearcher) {
-				m.EXPECT().IndexSearch(gomock.Any(), "test", 5).
+				m.EXPECT().IndexSearch(gomock.Any(), "test", 5, core.SearchOptions{}).
 					Return(core.SearchResult{
 						Comics: []core.Comics{
 							{ID: 3, URL: "http://example.com/3"},
@@ -165,10 +284,123 @@ func TestServer_IndexSearch(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "Detect mode index search",
+			mockSetup: func(m *mockserver.MockSearcher) {
+				m.EXPECT().IndexSearch(gomock.Any(), "test", 5, core.SearchOptions{Mode: core.ModeDetect}).
+					Return(core.SearchResult{}, nil)
+			},
+			req: &searchpb.IndexSearchRequest{
+				Phrase: "test",
+				Limit:  5,
+				Mode:   "detect",
+			},
+			expectedResp: &searchpb.SearchResponse{},
+		},
+		{
+			name: "Explained index search",
+			mockSetup: func(m *mockserver.MockSearcher) {
+				m.EXPECT().IndexSearch(gomock.Any(), "test", 5, core.SearchOptions{Explain: true}).
+					Return(core.SearchResult{
+						Comics: []core.Comics{{
+							ID:  3,
+							URL: "http://example.com/3",
+							Explanation: &core.Explanation{
+								Terms:  []core.TermMatch{{Term: "test", Fields: []string{"title"}, Matched: []string{"title"}, Freq: 2, Weight: 3}},
+								Unique: 1, Phrases: []string{"test case"}, Weight: 3, Total: 2,
+							},
+						}},
+						Total:      1,
+						Explain:    &core.QueryExplanation{Path: core.PathIndex, Generation: 4, Terms: []string{"title:test"}, Phrases: []string{"test case"}},
+						Generation: 4,
+					}, nil)
+			},
+			req: &searchpb.IndexSearchRequest{
+				Phrase:  "test",
+				Limit:   5,
+				Explain: true,
+			},
+			expectedResp: &searchpb.SearchResponse{
+				Comics: []*searchpb.Comic{{
+					Id:  3,
+					Url: "http://example.com/3",
+					Explanation: &searchpb.Explanation{
+						Terms:  []*searchpb.TermMatch{{Term: "test", Fields: []string{"title"}, Matched: []string{"title"}, Freq: 2, Weight: 3}},
+						Unique: 1, Phrases: []string{"test case"}, Weight: 3, Total: 2,
+					},
+				}},
+				Total:      1,
+				Explain:    &searchpb.QueryExplanation{Path: "index", Generation: 4, Terms: []string{"title:test"}, Phrases: []string{"test case"}},
+				Generation: 4,
+			},
+		},
+		{
+			name: "Fuzzy index search with suggestion",
+			mockSetup: func(m *mockserver.MockSearcher) {
+				m.EXPECT().IndexSearch(gomock.Any(), "tset", 5, core.SearchOptions{Fuzzy: true}).
+					Return(core.SearchResult{
+						Comics: []core.Comics{
+							{ID: 3, URL: "http://example.com/3"},
+						},
+						Total:      1,
+						Suggestion: "test",
+					}, nil)
+			},
+			req: &searchpb.IndexSearchRequest{
+				Phrase: "tset",
+				Limit:  5,
+				Fuzzy:  true,
+			},
+			expectedResp: &searchpb.SearchResponse{
+				Comics: []*searchpb.Comic{
+					{Id: 3, Url: "http://example.com/3"},
+				},
+				Total:      1,
+				Suggestion: "test",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Index search with snippet",
+			mockSetup: func(m *mockserver.MockSearcher) {
+				m.EXPECT().IndexSearch(gomock.Any(), "robots", 5, core.SearchOptions{}).
+					Return(core.SearchResult{
+						Comics: []core.Comics{{
+							ID:    7,
+							URL:   "http://example.com/7",
+							Terms: []string{"robots"},
+							Snippet: &core.Snippet{
+								Field:      "alt",
+								Text:       "Robots are coming",
+								Highlights: []core.Span{{Start: 0, End: 6}},
+							},
+						}},
+						Total: 1,
+					}, nil)
+			},
+			req: &searchpb.IndexSearchRequest{
+				Phrase: "robots",
+				Limit:  5,
+			},
+			expectedResp: &searchpb.SearchResponse{
+				Comics: []*searchpb.Comic{{
+					Id:    7,
+					Url:   "http://example.com/7",
+					Terms: []string{"robots"},
+					Snippet: &searchpb.Snippet{
+						Field:      "alt",
+						Text:       "Robots are coming",
+						Highlights: []*searchpb.Highlight{{Start: 0, End: 6}},
+					},
+				}},
+				Total: 1,
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "Error in index search",
 			mockSetup: func(m *mockserver.MockSearcher) {
-				m.EXPECT().IndexSearch(gomock.Any(), "error", 5).
+				m.EXPECT().IndexSearch(gomock.Any(), "error", 5, core.SearchOptions{}).
 					Return(core.SearchResult{}, errors.New("index search error"))
 			},
 			req: &searchpb.IndexSearchRequest{
@@ -204,3 +436,263 @@ func TestServer_IndexSearch(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_Similar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockserver.NewMockSearcher(ctrl)
+	server := NewServer(mockService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.EXPECT().Similar(gomock.Any(), 7, 3).
+			Return(core.SearchResult{
+				Comics: []core.Comics{{ID: 8, URL: "http://example.com/8", Score: 0.5}},
+				Total:  4,
+			}, nil)
+
+		resp, err := server.Similar(context.Background(), &searchpb.SimilarRequest{Id: 7, Limit: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(4), resp.Total)
+		assert.Equal(t, []*searchpb.Comic{{Id: 8, Url: "http://example.com/8", Score: 0.5}}, resp.Comics)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService.EXPECT().Similar(gomock.Any(), 42, 3).
+			Return(core.SearchResult{}, core.ErrNotFound)
+
+		_, err := server.Similar(context.Background(), &searchpb.SimilarRequest{Id: 42, Limit: 3})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("bad id", func(t *testing.T) {
+		mockService.EXPECT().Similar(gomock.Any(), 0, 3).
+			Return(core.SearchResult{}, core.ErrBadArguments)
+
+		_, err := server.Similar(context.Background(), &searchpb.SimilarRequest{Limit: 3})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestServer_GetComic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockserver.NewMockSearcher(ctrl)
+	server := NewServer(mockService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.EXPECT().GetComic(gomock.Any(), 7).
+			Return(core.ComicDetail{
+				Comics: core.Comics{
+					ID: 7, URL: "http://example.com/7", Title: "Seven", Alt: "Alt",
+					Words: []string{"seven"}, Forms: map[string]string{"seven": "Seven"},
+					Date:       time.Date(2006, time.January, 4, 0, 0, 0, 0, time.UTC),
+					TitleWords: []string{"seven"},
+					Bigrams:    []string{"seven eight"},
+					Dialogue:   []core.Line{{Speaker: "Cueball", Text: "Seven!", Words: []string{"seven"}}},
+					Speakers:   []string{"Cueball"},
+					Scenes:     []string{"Cueball at a desk."},
+					SceneWords: []string{"desk"},
+				},
+				Prev: 6,
+				Next: 8,
+			}, nil)
+
+		resp, err := server.GetComic(context.Background(), &searchpb.GetComicRequest{Id: 7})
+		assert.NoError(t, err)
+		assert.Equal(t, &searchpb.ComicDetail{
+			Id: 7, Url: "http://example.com/7", Title: "Seven", Alt: "Alt", Date: "2006-01-04",
+			Words: []string{"seven"}, Forms: map[string]string{"seven": "Seven"},
+			TitleWords: []string{"seven"}, Prev: 6, Next: 8,
+			Bigrams:    []string{"seven eight"},
+			Dialogue:   []*searchpb.Line{{Speaker: "Cueball", Text: "Seven!", Words: []string{"seven"}}},
+			SceneWords: []string{"desk"},
+			Speakers:   []string{"Cueball"},
+			Scenes:     []string{"Cueball at a desk."},
+		}, resp)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService.EXPECT().GetComic(gomock.Any(), 42).Return(core.ComicDetail{}, core.ErrNotFound)
+
+		_, err := server.GetComic(context.Background(), &searchpb.GetComicRequest{Id: 42})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("bad id", func(t *testing.T) {
+		mockService.EXPECT().GetComic(gomock.Any(), 0).Return(core.ComicDetail{}, core.ErrBadArguments)
+
+		_, err := server.GetComic(context.Background(), &searchpb.GetComicRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestServer_RandomComic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockserver.NewMockSearcher(ctrl)
+	server := NewServer(mockService)
+
+	mockService.EXPECT().RandomComic(gomock.Any()).
+		Return(core.ComicDetail{Comics: core.Comics{ID: 3, URL: "http://example.com/3"}, Prev: 2}, nil)
+	resp, err := server.RandomComic(context.Background(), &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, &searchpb.ComicDetail{Id: 3, Url: "http://example.com/3", Prev: 2}, resp)
+
+	mockService.EXPECT().RandomComic(gomock.Any()).Return(core.ComicDetail{}, core.ErrNotFound)
+	_, err = server.RandomComic(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_IndexStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockserver.NewMockSearcher(ctrl)
+	server := NewServer(mockService)
+
+	builtAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService.EXPECT().IndexStats(gomock.Any(), 5).Return(core.IndexStats{
+		Generation:    3,
+		BuiltAt:       builtAt,
+		BuildDuration: 1500 * time.Millisecond,
+		Documents:     10,
+		Terms:         20,
+		TopTerms:      []core.TermStats{{Term: "robot", Docs: 7, Total: 9}},
+		RareTerms:     []core.TermStats{{Term: "laser", Docs: 1, Total: 1}},
+		Cache:         core.CacheStats{Hits: 5, Misses: 2, Entries: 2, Capacity: 100},
+		WordsClient: core.WordsClientStats{
+			CacheStats:    core.CacheStats{Hits: 8, Misses: 3, Entries: 3, Capacity: 1000},
+			Invalidations: 1,
+			Retries:       2,
+		},
+	}, nil)
+
+	resp, err := server.IndexStats(context.Background(), &searchpb.IndexStatsRequest{Limit: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, &searchpb.IndexStatsResponse{
+		Generation:      3,
+		BuiltAt:         "2025-03-01T12:00:00Z",
+		BuildDurationMs: 1500,
+		Documents:       10,
+		Terms:           20,
+		TopTerms:        []*searchpb.TermStats{{Term: "robot", Docs: 7, Total: 9}},
+		RareTerms:       []*searchpb.TermStats{{Term: "laser", Docs: 1, Total: 1}},
+		Cache:           &searchpb.CacheStats{Hits: 5, Misses: 2, Entries: 2, Capacity: 100},
+		WordsClient: &searchpb.WordsClientStats{
+			Hits: 8, Misses: 3, Entries: 3, Capacity: 1000, Invalidations: 1, Retries: 2,
+		},
+	}, resp)
+}
+
+func TestServer_TermInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockserver.NewMockSearcher(ctrl)
+	server := NewServer(mockService)
+
+	mockService.EXPECT().TermInfo(gomock.Any(), "robots", 2).Return(core.TermInfo{
+		TermStats: core.TermStats{Term: "robot", Docs: 3, Total: 4},
+		Form:      "robots",
+		IDF:       0.5,
+		Postings:  []core.Posting{{ID: 1, Freq: 2, Fields: []string{"title"}}},
+	}, nil)
+
+	resp, err := server.TermInfo(context.Background(), &searchpb.TermInfoRequest{Term: "robots", Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, &searchpb.TermInfoResponse{
+		Stats:    &searchpb.TermStats{Term: "robot", Docs: 3, Total: 4},
+		Form:     "robots",
+		Idf:      0.5,
+		Postings: []*searchpb.Posting{{Id: 1, Freq: 2, Fields: []string{"title"}}},
+	}, resp)
+
+	mockService.EXPECT().TermInfo(gomock.Any(), "laser", 0).Return(core.TermInfo{}, core.ErrNotFound)
+	_, err = server.TermInfo(context.Background(), &searchpb.TermInfoRequest{Term: "laser"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_Analytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockserver.NewMockSearcher(ctrl)
+	server := NewServer(mockService)
+
+	t.Run("success", func(t *testing.T) {
+		since := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+		mockService.EXPECT().Analytics(gomock.Any(), 24*time.Hour, 10).Return(core.Analytics{
+			Since:       since,
+			Queries:     5,
+			TopQueries:  []core.QueryCount{{Phrase: "robot", Count: 3}},
+			ZeroResults: []core.QueryCount{{Phrase: "qwerty", Count: 1}},
+			Latency: []core.LatencyStats{{
+				Mode: core.ModeSearch, Count: 5,
+				P50: 2 * time.Millisecond, P90: 10 * time.Millisecond, P99: 1500 * time.Microsecond,
+			}},
+		}, nil)
+
+		resp, err := server.Analytics(context.Background(), &searchpb.AnalyticsRequest{WindowSeconds: 86400, Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, &searchpb.AnalyticsResponse{
+			Since:       "2025-03-01T12:00:00Z",
+			Queries:     5,
+			TopQueries:  []*searchpb.QueryCount{{Phrase: "robot", Count: 3}},
+			ZeroResults: []*searchpb.QueryCount{{Phrase: "qwerty", Count: 1}},
+			Latency:     []*searchpb.LatencyStats{{Mode: "search", Count: 5, P50Ms: 2, P90Ms: 10, P99Ms: 1.5}},
+		}, resp)
+	})
+
+	t.Run("bad window", func(t *testing.T) {
+		mockService.EXPECT().Analytics(gomock.Any(), time.Duration(0), 10).Return(core.Analytics{}, core.ErrBadArguments)
+
+		_, err := server.Analytics(context.Background(), &searchpb.AnalyticsRequest{Limit: 10})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestServer_Synonyms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockserver.NewMockSearcher(ctrl)
+	server := NewServer(mockService)
+
+	t.Run("get", func(t *testing.T) {
+		mockService.EXPECT().Synonyms(gomock.Any()).Return([][]string{{"car", "automobile"}}, nil)
+
+		resp, err := server.Synonyms(context.Background(), &emptypb.Empty{})
+		assert.NoError(t, err)
+		assert.Equal(t, &searchpb.SynonymGroups{
+			Groups: []*searchpb.SynonymGroup{{Words: []string{"car", "automobile"}}},
+		}, resp)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		mockService.EXPECT().Synonyms(gomock.Any()).Return(nil, core.ErrNotFound)
+
+		_, err := server.Synonyms(context.Background(), &emptypb.Empty{})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("set", func(t *testing.T) {
+		mockService.EXPECT().SetSynonyms(gomock.Any(), [][]string{{"cat", "kitten"}}).Return(nil)
+
+		_, err := server.SetSynonyms(context.Background(), &searchpb.SynonymGroups{
+			Groups: []*searchpb.SynonymGroup{{Words: []string{"cat", "kitten"}}},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("set invalid", func(t *testing.T) {
+		mockService.EXPECT().SetSynonyms(gomock.Any(), [][]string{{"cat"}}).Return(core.ErrBadArguments)
+
+		_, err := server.SetSynonyms(context.Background(), &searchpb.SynonymGroups{
+			Groups: []*searchpb.SynonymGroup{{Words: []string{"cat"}}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
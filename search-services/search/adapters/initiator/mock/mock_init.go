@@ -50,10 +50,10 @@ func (mr *MockIndexerMockRecorder) BuildIndex(ctx interface{}) *gomock.Call {
 }
 
 // GetIndex mocks base method.
-func (m *MockIndexer) GetIndex(ctx context.Context) core.Index {
+func (m *MockIndexer) GetIndex(ctx context.Context) *core.Index {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetIndex", ctx)
-	ret0, _ := ret[0].(core.Index)
+	ret0, _ := ret[0].(*core.Index)
 	return ret0
 }
 
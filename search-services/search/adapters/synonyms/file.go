@@ -0,0 +1,98 @@
+package synonyms
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File keeps synonym groups in a YAML file as a list of lists of words or,
+// with the .tsv extension, one group per line with tab separated words.
+// A missing file has no groups.
+type File struct {
+	path string
+}
+
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+func (f *File) tsv() bool {
+	return strings.EqualFold(filepath.Ext(f.path), ".tsv")
+}
+
+func (f *File) Load() ([][]string, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if f.tsv() {
+		return parseTSV(data)
+	}
+	var groups [][]string
+	if err := yaml.Unmarshal(data, &groups); err != nil {
+		return nil, fmt.Errorf("invalid synonyms file %s: %w", f.path, err)
+	}
+	return groups, nil
+}
+
+// Save rewrites the file in place rather than renaming a new one over it,
+// which would fail for a file mounted into a container.
+func (f *File) Save(groups [][]string) error {
+	var data []byte
+	if f.tsv() {
+		var b bytes.Buffer
+		for _, group := range groups {
+			b.WriteString(strings.Join(group, "\t"))
+			b.WriteByte('\n')
+		}
+		data = b.Bytes()
+	} else {
+		var b bytes.Buffer
+		enc := yaml.NewEncoder(&b)
+		enc.SetIndent(2)
+		if err := enc.Encode(flowGroups(groups)); err != nil {
+			return err
+		}
+		data = b.Bytes()
+	}
+	return os.WriteFile(f.path, data, 0o644)
+}
+
+// parseTSV reads a group from every line, skipping empty lines and
+// comments starting with #.
+func parseTSV(data []byte) ([][]string, error) {
+	var groups [][]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		groups = append(groups, strings.Split(line, "\t"))
+	}
+	return groups, scanner.Err()
+}
+
+// flowGroups writes every group on its own line like [car, automobile].
+func flowGroups(groups [][]string) *yaml.Node {
+	root := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, group := range groups {
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, word := range group {
+			seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: word})
+		}
+		root.Content = append(root.Content, seq)
+	}
+	return root
+}
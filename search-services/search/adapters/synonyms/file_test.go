@@ -0,0 +1,58 @@
+package synonyms
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "synonyms.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- [car, automobile, auto]\n- - picture\n  - image\n"), 0o644))
+
+	f := NewFile(path)
+	groups, err := f.Load()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"car", "automobile", "auto"}, {"picture", "image"}}, groups)
+
+	require.NoError(t, f.Save([][]string{{"car", "automobile"}, {"cat", "kitten"}}))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "- [car, automobile]\n- [cat, kitten]\n", string(data))
+
+	groups, err = f.Load()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"car", "automobile"}, {"cat", "kitten"}}, groups)
+}
+
+func TestFile_TSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "synonyms.tsv")
+	require.NoError(t, os.WriteFile(path, []byte("# car words\ncar\tautomobile\tauto\n\npicture\timage\n"), 0o644))
+
+	f := NewFile(path)
+	groups, err := f.Load()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"car", "automobile", "auto"}, {"picture", "image"}}, groups)
+
+	require.NoError(t, f.Save([][]string{{"cat", "kitten"}}))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "cat\tkitten\n", string(data))
+}
+
+func TestFile_Missing(t *testing.T) {
+	groups, err := NewFile(filepath.Join(t.TempDir(), "synonyms.yaml")).Load()
+	assert.NoError(t, err)
+	assert.Empty(t, groups)
+}
+
+func TestFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "synonyms.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("car: automobile\n"), 0o644))
+
+	_, err := NewFile(path).Load()
+	assert.Error(t, err)
+}
@@ -37,6 +37,26 @@ func (m *MockWordsClient) EXPECT() *MockWordsClientMockRecorder {
 	return m.recorder
 }
 
+// Dictionaries mocks base method.
+func (m *MockWordsClient) Dictionaries(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*words.DictionaryWords, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Dictionaries", varargs...)
+	ret0, _ := ret[0].(*words.DictionaryWords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dictionaries indicates an expected call of Dictionaries.
+func (mr *MockWordsClientMockRecorder) Dictionaries(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dictionaries", reflect.TypeOf((*MockWordsClient)(nil).Dictionaries), varargs...)
+}
+
 // Norm mocks base method.
 func (m *MockWordsClient) Norm(ctx context.Context, in *words.WordsRequest, opts ...grpc.CallOption) (*words.WordsReply, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +77,66 @@ func (mr *MockWordsClientMockRecorder) Norm(ctx, in interface{}, opts ...interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Norm", reflect.TypeOf((*MockWordsClient)(nil).Norm), varargs...)
 }
 
+// NormBatch mocks base method.
+func (m *MockWordsClient) NormBatch(ctx context.Context, in *words.NormBatchRequest, opts ...grpc.CallOption) (*words.NormBatchReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "NormBatch", varargs...)
+	ret0, _ := ret[0].(*words.NormBatchReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormBatch indicates an expected call of NormBatch.
+func (mr *MockWordsClientMockRecorder) NormBatch(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormBatch", reflect.TypeOf((*MockWordsClient)(nil).NormBatch), varargs...)
+}
+
+// NormDetailed mocks base method.
+func (m *MockWordsClient) NormDetailed(ctx context.Context, in *words.WordsRequest, opts ...grpc.CallOption) (*words.DetailedReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "NormDetailed", varargs...)
+	ret0, _ := ret[0].(*words.DetailedReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormDetailed indicates an expected call of NormDetailed.
+func (mr *MockWordsClientMockRecorder) NormDetailed(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormDetailed", reflect.TypeOf((*MockWordsClient)(nil).NormDetailed), varargs...)
+}
+
+// NormStream mocks base method.
+func (m *MockWordsClient) NormStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[words.NormItem, words.NormResult], error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "NormStream", varargs...)
+	ret0, _ := ret[0].(grpc.BidiStreamingClient[words.NormItem, words.NormResult])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormStream indicates an expected call of NormStream.
+func (mr *MockWordsClientMockRecorder) NormStream(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormStream", reflect.TypeOf((*MockWordsClient)(nil).NormStream), varargs...)
+}
+
 // Ping mocks base method.
 func (m *MockWordsClient) Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +157,46 @@ func (mr *MockWordsClientMockRecorder) Ping(ctx, in interface{}, opts ...interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockWordsClient)(nil).Ping), varargs...)
 }
 
+// SetDictionaries mocks base method.
+func (m *MockWordsClient) SetDictionaries(ctx context.Context, in *words.DictionaryWords, opts ...grpc.CallOption) (*words.DictionaryVersion, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetDictionaries", varargs...)
+	ret0, _ := ret[0].(*words.DictionaryVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDictionaries indicates an expected call of SetDictionaries.
+func (mr *MockWordsClientMockRecorder) SetDictionaries(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDictionaries", reflect.TypeOf((*MockWordsClient)(nil).SetDictionaries), varargs...)
+}
+
+// Version mocks base method.
+func (m *MockWordsClient) Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*words.DictionaryVersion, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Version", varargs...)
+	ret0, _ := ret[0].(*words.DictionaryVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockWordsClientMockRecorder) Version(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockWordsClient)(nil).Version), varargs...)
+}
+
 // MockWordsServer is a mock of WordsServer interface.
 type MockWordsServer struct {
 	ctrl     *gomock.Controller
@@ -100,6 +220,21 @@ func (m *MockWordsServer) EXPECT() *MockWordsServerMockRecorder {
 	return m.recorder
 }
 
+// Dictionaries mocks base method.
+func (m *MockWordsServer) Dictionaries(arg0 context.Context, arg1 *emptypb.Empty) (*words.DictionaryWords, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dictionaries", arg0, arg1)
+	ret0, _ := ret[0].(*words.DictionaryWords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dictionaries indicates an expected call of Dictionaries.
+func (mr *MockWordsServerMockRecorder) Dictionaries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dictionaries", reflect.TypeOf((*MockWordsServer)(nil).Dictionaries), arg0, arg1)
+}
+
 // Norm mocks base method.
 func (m *MockWordsServer) Norm(arg0 context.Context, arg1 *words.WordsRequest) (*words.WordsReply, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +250,50 @@ func (mr *MockWordsServerMockRecorder) Norm(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Norm", reflect.TypeOf((*MockWordsServer)(nil).Norm), arg0, arg1)
 }
 
+// NormBatch mocks base method.
+func (m *MockWordsServer) NormBatch(arg0 context.Context, arg1 *words.NormBatchRequest) (*words.NormBatchReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormBatch", arg0, arg1)
+	ret0, _ := ret[0].(*words.NormBatchReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormBatch indicates an expected call of NormBatch.
+func (mr *MockWordsServerMockRecorder) NormBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormBatch", reflect.TypeOf((*MockWordsServer)(nil).NormBatch), arg0, arg1)
+}
+
+// NormDetailed mocks base method.
+func (m *MockWordsServer) NormDetailed(arg0 context.Context, arg1 *words.WordsRequest) (*words.DetailedReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormDetailed", arg0, arg1)
+	ret0, _ := ret[0].(*words.DetailedReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormDetailed indicates an expected call of NormDetailed.
+func (mr *MockWordsServerMockRecorder) NormDetailed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormDetailed", reflect.TypeOf((*MockWordsServer)(nil).NormDetailed), arg0, arg1)
+}
+
+// NormStream mocks base method.
+func (m *MockWordsServer) NormStream(arg0 grpc.BidiStreamingServer[words.NormItem, words.NormResult]) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormStream", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NormStream indicates an expected call of NormStream.
+func (mr *MockWordsServerMockRecorder) NormStream(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormStream", reflect.TypeOf((*MockWordsServer)(nil).NormStream), arg0)
+}
+
 // Ping mocks base method.
 func (m *MockWordsServer) Ping(arg0 context.Context, arg1 *emptypb.Empty) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +309,36 @@ func (mr *MockWordsServerMockRecorder) Ping(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockWordsServer)(nil).Ping), arg0, arg1)
 }
 
+// SetDictionaries mocks base method.
+func (m *MockWordsServer) SetDictionaries(arg0 context.Context, arg1 *words.DictionaryWords) (*words.DictionaryVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDictionaries", arg0, arg1)
+	ret0, _ := ret[0].(*words.DictionaryVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDictionaries indicates an expected call of SetDictionaries.
+func (mr *MockWordsServerMockRecorder) SetDictionaries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDictionaries", reflect.TypeOf((*MockWordsServer)(nil).SetDictionaries), arg0, arg1)
+}
+
+// Version mocks base method.
+func (m *MockWordsServer) Version(arg0 context.Context, arg1 *emptypb.Empty) (*words.DictionaryVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version", arg0, arg1)
+	ret0, _ := ret[0].(*words.DictionaryVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockWordsServerMockRecorder) Version(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockWordsServer)(nil).Version), arg0, arg1)
+}
+
 // mustEmbedUnimplementedWordsServer mocks base method.
 func (m *MockWordsServer) mustEmbedUnimplementedWordsServer() {
 	m.ctrl.T.Helper()
@@ -5,23 +5,27 @@ import (
 	"fmt"
 	"log/slog"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	wordspb "yadro.com/course/proto/words"
+	"yadro.com/course/search/core"
+	"yadro.com/course/wordsclient"
 )
 
 type Client struct {
 	log    *slog.Logger
 	client wordspb.WordsClient
+	// shared is the client behind client to take the stats from, nil when
+	// client is not dialed by NewClient
+	shared *wordsclient.Client
 }
 
-func NewClient(address string, log *slog.Logger) (*Client, error) {
-	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewClient(address string, cfg wordsclient.Config, log *slog.Logger) (*Client, error) {
+	client, err := wordsclient.Dial(log, address, cfg)
 	if err != nil {
 		return nil, err
 	}
 	return &Client{
-		client: wordspb.NewWordsClient(conn),
+		client: client,
+		shared: client,
 		log:    log,
 	}, nil
 }
@@ -34,6 +38,40 @@ func (c Client) Norm(ctx context.Context, phrase string) ([]string, error) {
 	return resp.Words, nil
 }
 
+// Bigrams returns the pairs of adjacent normalized words of the phrase.
+func (c Client) Bigrams(ctx context.Context, phrase string) ([]string, error) {
+	resp, err := c.client.Norm(ctx, &wordspb.WordsRequest{Phrase: phrase, Bigrams: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize words: %w", err)
+	}
+	return resp.Bigrams, nil
+}
+
+// OnVersionChange sets the function called when the words service changes
+// its dictionaries or config.
+func (c Client) OnVersionChange(f func()) {
+	if c.shared != nil {
+		c.shared.OnVersionChange(f)
+	}
+}
+
+func (c Client) Stats() core.WordsClientStats {
+	if c.shared == nil {
+		return core.WordsClientStats{}
+	}
+	stats := c.shared.Stats()
+	return core.WordsClientStats{
+		CacheStats: core.CacheStats{
+			Hits:     stats.Hits,
+			Misses:   stats.Misses,
+			Entries:  stats.Entries,
+			Capacity: stats.Capacity,
+		},
+		Invalidations: stats.Invalidations,
+		Retries:       stats.Retries,
+	}
+}
+
 func (c Client) Ping(ctx context.Context) error {
 	_, err := c.client.Ping(ctx, nil)
 	return err
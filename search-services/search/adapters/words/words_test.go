@@ -94,6 +94,27 @@ func TestClient_Norm(t *testing.T) {
 	}
 }
 
+func TestClient_Bigrams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mockwords.NewMockWordsClient(ctrl)
+	client := &Client{client: mockClient, log: slog.Default()}
+
+	mockClient.EXPECT().Norm(
+		gomock.Any(),
+		&wordspb.WordsRequest{Phrase: "black hat guy", Bigrams: true},
+	).Return(&wordspb.WordsReply{Words: []string{"black", "hat", "guy"}, Bigrams: []string{"black hat", "hat guy"}}, nil)
+
+	bigrams, err := client.Bigrams(context.Background(), "black hat guy")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"black hat", "hat guy"}, bigrams)
+
+	mockClient.EXPECT().Norm(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Internal, "adapters error"))
+	_, err = client.Bigrams(context.Background(), "error")
+	assert.ErrorContains(t, err, "failed to normalize words")
+}
+
 func TestClient_Ping(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
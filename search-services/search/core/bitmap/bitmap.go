@@ -0,0 +1,365 @@
+package bitmap
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Bitmap is a compressed set of uint32 values in the spirit of roaring
+// bitmaps: values are split by their high 16 bits into containers that are
+// stored either as a sorted array (sparse) or as a fixed bitset (dense).
+type Bitmap struct {
+	keys       []uint16
+	containers []*container
+}
+
+const (
+	arrayMaxSize = 4096
+	bitsetWords  = 1 << 16 / 64
+)
+
+type container struct {
+	array  []uint16
+	bitset []uint64
+	card   int
+}
+
+func New() *Bitmap {
+	return &Bitmap{}
+}
+
+// FromSorted builds a bitmap from values sorted in ascending order.
+func FromSorted(values []uint32) *Bitmap {
+	b := New()
+	for i := 0; i < len(values); {
+		key := uint16(values[i] >> 16)
+		j := i
+		for j < len(values) && uint16(values[j]>>16) == key {
+			j++
+		}
+		c := &container{}
+		if j-i > arrayMaxSize {
+			c.bitset = make([]uint64, bitsetWords)
+			for _, v := range values[i:j] {
+				c.bitset[uint16(v)>>6] |= 1 << (uint16(v) & 63)
+			}
+			c.card = popcount(c.bitset)
+		} else {
+			c.array = make([]uint16, 0, j-i)
+			for _, v := range values[i:j] {
+				if n := len(c.array); n > 0 && c.array[n-1] == uint16(v) {
+					continue
+				}
+				c.array = append(c.array, uint16(v))
+			}
+			c.card = len(c.array)
+		}
+		b.keys = append(b.keys, key)
+		b.containers = append(b.containers, c)
+		i = j
+	}
+	return b
+}
+
+func (b *Bitmap) Add(x uint32) {
+	key, low := uint16(x>>16), uint16(x)
+	i, found := b.find(key)
+	if !found {
+		b.keys = append(b.keys, 0)
+		copy(b.keys[i+1:], b.keys[i:])
+		b.keys[i] = key
+		b.containers = append(b.containers, nil)
+		copy(b.containers[i+1:], b.containers[i:])
+		b.containers[i] = &container{}
+	}
+	b.containers[i].add(low)
+}
+
+func (b *Bitmap) Contains(x uint32) bool {
+	i, found := b.find(uint16(x >> 16))
+	return found && b.containers[i].contains(uint16(x))
+}
+
+func (b *Bitmap) Cardinality() int {
+	n := 0
+	for _, c := range b.containers {
+		n += c.card
+	}
+	return n
+}
+
+func (b *Bitmap) IsEmpty() bool {
+	return b.Cardinality() == 0
+}
+
+// Rank returns the number of values in the bitmap that are less than or equal to x.
+func (b *Bitmap) Rank(x uint32) int {
+	key, low := uint16(x>>16), uint16(x)
+	n := 0
+	for i, k := range b.keys {
+		if k > key {
+			break
+		}
+		if k < key {
+			n += b.containers[i].card
+			continue
+		}
+		n += b.containers[i].rank(low)
+	}
+	return n
+}
+
+// Each calls fn for every value in ascending order until fn returns false.
+func (b *Bitmap) Each(fn func(uint32) bool) {
+	for i, key := range b.keys {
+		high := uint32(key) << 16
+		if !b.containers[i].each(func(low uint16) bool { return fn(high | uint32(low)) }) {
+			return
+		}
+	}
+}
+
+func (b *Bitmap) ToArray() []uint32 {
+	values := make([]uint32, 0, b.Cardinality())
+	b.Each(func(v uint32) bool {
+		values = append(values, v)
+		return true
+	})
+	return values
+}
+
+// SizeInBytes is an estimate of the memory used by the bitmap payload.
+func (b *Bitmap) SizeInBytes() int {
+	size := len(b.keys) * 2
+	for _, c := range b.containers {
+		size += len(c.array)*2 + len(c.bitset)*8
+	}
+	return size
+}
+
+func And(a, b *Bitmap) *Bitmap {
+	res := New()
+	for i, j := 0, 0; i < len(a.keys) && j < len(b.keys); {
+		switch {
+		case a.keys[i] < b.keys[j]:
+			i++
+		case a.keys[i] > b.keys[j]:
+			j++
+		default:
+			if c := a.containers[i].and(b.containers[j]); c.card > 0 {
+				res.keys = append(res.keys, a.keys[i])
+				res.containers = append(res.containers, c)
+			}
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+func Or(a, b *Bitmap) *Bitmap {
+	res := New()
+	i, j := 0, 0
+	for i < len(a.keys) || j < len(b.keys) {
+		switch {
+		case j == len(b.keys) || (i < len(a.keys) && a.keys[i] < b.keys[j]):
+			res.keys = append(res.keys, a.keys[i])
+			res.containers = append(res.containers, a.containers[i].clone())
+			i++
+		case i == len(a.keys) || a.keys[i] > b.keys[j]:
+			res.keys = append(res.keys, b.keys[j])
+			res.containers = append(res.containers, b.containers[j].clone())
+			j++
+		default:
+			res.keys = append(res.keys, a.keys[i])
+			res.containers = append(res.containers, a.containers[i].or(b.containers[j]))
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+func (b *Bitmap) find(key uint16) (int, bool) {
+	i := sort.Search(len(b.keys), func(i int) bool { return b.keys[i] >= key })
+	return i, i < len(b.keys) && b.keys[i] == key
+}
+
+func (c *container) add(low uint16) {
+	if c.bitset != nil {
+		if c.bitset[low>>6]&(1<<(low&63)) == 0 {
+			c.bitset[low>>6] |= 1 << (low & 63)
+			c.card++
+		}
+		return
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	if i < len(c.array) && c.array[i] == low {
+		return
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[i+1:], c.array[i:])
+	c.array[i] = low
+	c.card++
+	if c.card > arrayMaxSize {
+		c.toBitset()
+	}
+}
+
+func (c *container) contains(low uint16) bool {
+	if c.bitset != nil {
+		return c.bitset[low>>6]&(1<<(low&63)) != 0
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	return i < len(c.array) && c.array[i] == low
+}
+
+func (c *container) rank(low uint16) int {
+	if c.bitset != nil {
+		word := int(low >> 6)
+		n := popcount(c.bitset[:word])
+		mask := uint64(1)<<(low&63+1) - 1
+		return n + bits.OnesCount64(c.bitset[word]&mask)
+	}
+	return sort.Search(len(c.array), func(i int) bool { return c.array[i] > low })
+}
+
+func (c *container) each(fn func(uint16) bool) bool {
+	if c.bitset != nil {
+		for w, word := range c.bitset {
+			for word != 0 {
+				t := bits.TrailingZeros64(word)
+				if !fn(uint16(w<<6 + t)) {
+					return false
+				}
+				word &= word - 1
+			}
+		}
+		return true
+	}
+	for _, v := range c.array {
+		if !fn(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *container) and(o *container) *container {
+	switch {
+	case c.bitset != nil && o.bitset != nil:
+		res := &container{bitset: make([]uint64, bitsetWords)}
+		for i := range res.bitset {
+			res.bitset[i] = c.bitset[i] & o.bitset[i]
+		}
+		res.card = popcount(res.bitset)
+		if res.card <= arrayMaxSize {
+			res.toArray()
+		}
+		return res
+	case c.bitset != nil:
+		return o.and(c)
+	case o.bitset != nil:
+		res := &container{array: make([]uint16, 0, len(c.array))}
+		for _, v := range c.array {
+			if o.contains(v) {
+				res.array = append(res.array, v)
+			}
+		}
+		res.card = len(res.array)
+		return res
+	default:
+		res := &container{array: make([]uint16, 0, min(len(c.array), len(o.array)))}
+		for i, j := 0, 0; i < len(c.array) && j < len(o.array); {
+			switch {
+			case c.array[i] < o.array[j]:
+				i++
+			case c.array[i] > o.array[j]:
+				j++
+			default:
+				res.array = append(res.array, c.array[i])
+				i++
+				j++
+			}
+		}
+		res.card = len(res.array)
+		return res
+	}
+}
+
+func (c *container) or(o *container) *container {
+	if c.bitset != nil || o.bitset != nil || c.card+o.card > arrayMaxSize {
+		res := &container{bitset: make([]uint64, bitsetWords)}
+		for _, src := range []*container{c, o} {
+			if src.bitset != nil {
+				for i, word := range src.bitset {
+					res.bitset[i] |= word
+				}
+				continue
+			}
+			for _, v := range src.array {
+				res.bitset[v>>6] |= 1 << (v & 63)
+			}
+		}
+		res.card = popcount(res.bitset)
+		if res.card <= arrayMaxSize {
+			res.toArray()
+		}
+		return res
+	}
+	res := &container{array: make([]uint16, 0, c.card+o.card)}
+	i, j := 0, 0
+	for i < len(c.array) && j < len(o.array) {
+		switch {
+		case c.array[i] < o.array[j]:
+			res.array = append(res.array, c.array[i])
+			i++
+		case c.array[i] > o.array[j]:
+			res.array = append(res.array, o.array[j])
+			j++
+		default:
+			res.array = append(res.array, c.array[i])
+			i++
+			j++
+		}
+	}
+	res.array = append(res.array, c.array[i:]...)
+	res.array = append(res.array, o.array[j:]...)
+	res.card = len(res.array)
+	return res
+}
+
+func (c *container) clone() *container {
+	res := &container{card: c.card}
+	if c.bitset != nil {
+		res.bitset = append([]uint64(nil), c.bitset...)
+	} else {
+		res.array = append([]uint16(nil), c.array...)
+	}
+	return res
+}
+
+func (c *container) toBitset() {
+	c.bitset = make([]uint64, bitsetWords)
+	for _, v := range c.array {
+		c.bitset[v>>6] |= 1 << (v & 63)
+	}
+	c.array = nil
+}
+
+func (c *container) toArray() {
+	c.array = make([]uint16, 0, c.card)
+	c.each(func(v uint16) bool {
+		c.array = append(c.array, v)
+		return true
+	})
+	c.bitset = nil
+}
+
+func popcount(words []uint64) int {
+	n := 0
+	for _, w := range words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
@@ -0,0 +1,112 @@
+package bitmap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitmap_AddContains(t *testing.T) {
+	b := New()
+	for _, v := range []uint32{5, 1, 70000, 5, 3} {
+		b.Add(v)
+	}
+
+	assert.Equal(t, 4, b.Cardinality())
+	assert.True(t, b.Contains(1))
+	assert.True(t, b.Contains(70000))
+	assert.False(t, b.Contains(2))
+	assert.False(t, b.Contains(65536+5))
+	assert.Equal(t, []uint32{1, 3, 5, 70000}, b.ToArray())
+}
+
+func TestBitmap_Rank(t *testing.T) {
+	b := FromSorted([]uint32{2, 4, 6, 65536, 65540})
+
+	assert.Equal(t, 0, b.Rank(1))
+	assert.Equal(t, 1, b.Rank(2))
+	assert.Equal(t, 3, b.Rank(100))
+	assert.Equal(t, 4, b.Rank(65536))
+	assert.Equal(t, 5, b.Rank(1<<20))
+}
+
+func TestBitmap_DenseContainer(t *testing.T) {
+	values := make([]uint32, 0, 10000)
+	for v := uint32(0); v < 20000; v += 2 {
+		values = append(values, v)
+	}
+
+	b := FromSorted(values)
+	assert.NotNil(t, b.containers[0].bitset)
+	assert.Equal(t, len(values), b.Cardinality())
+	assert.Equal(t, values, b.ToArray())
+	assert.Equal(t, 5000, b.Rank(9998))
+	assert.Equal(t, 5000, b.Rank(9999))
+
+	added := New()
+	for _, v := range values {
+		added.Add(v)
+	}
+	assert.NotNil(t, added.containers[0].bitset)
+	assert.Equal(t, values, added.ToArray())
+}
+
+func TestAndOr(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, tc := range []struct{ size, spread int }{{10, 1 << 20}, {3000, 3 << 16}, {9000, 1 << 16}} {
+		a, b := randomSet(rnd, tc.size, tc.spread), randomSet(rnd, tc.size, tc.spread)
+
+		var and, or []uint32
+		for v := range a {
+			if _, ok := b[v]; ok {
+				and = append(and, v)
+			}
+			or = append(or, v)
+		}
+		for v := range b {
+			if _, ok := a[v]; !ok {
+				or = append(or, v)
+			}
+		}
+		slices.Sort(and)
+		slices.Sort(or)
+
+		ba, bb := FromSorted(sortedKeys(a)), FromSorted(sortedKeys(b))
+		assert.Equal(t, len(and), And(ba, bb).Cardinality())
+		assert.Equal(t, or, Or(ba, bb).ToArray())
+		if len(and) > 0 {
+			assert.Equal(t, and, And(ba, bb).ToArray())
+		}
+	}
+}
+
+func TestBitmap_EachStops(t *testing.T) {
+	b := FromSorted([]uint32{1, 2, 3})
+
+	var got []uint32
+	b.Each(func(v uint32) bool {
+		got = append(got, v)
+		return v < 2
+	})
+	assert.Equal(t, []uint32{1, 2}, got)
+}
+
+func randomSet(rnd *rand.Rand, size, spread int) map[uint32]struct{} {
+	set := make(map[uint32]struct{}, size)
+	for len(set) < size {
+		set[uint32(rnd.Intn(spread))] = struct{}{}
+	}
+	return set
+}
+
+func sortedKeys(set map[uint32]struct{}) []uint32 {
+	keys := make([]uint32, 0, len(set))
+	for v := range set {
+		keys = append(keys, v)
+	}
+	slices.Sort(keys)
+	return keys
+}
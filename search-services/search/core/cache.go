@@ -0,0 +1,138 @@
+package core
+
+import (
+	"container/list"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type CacheStats struct {
+	Hits     uint64
+	Misses   uint64
+	Entries  int
+	Capacity int
+}
+
+// resultCache keeps the latest search results of one index generation,
+// evicting the least recently used ones. Results of other generations are
+// dropped as soon as a newer one is seen.
+type resultCache struct {
+	mu         sync.Mutex
+	capacity   int
+	generation uint64
+	order      *list.List
+	entries    map[string]*list.Element
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+type cacheEntry struct {
+	key   string
+	found search
+}
+
+func newResultCache(capacity int) *resultCache {
+	return &resultCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *resultCache) get(generation uint64, key string) (search, bool) {
+	if c.capacity <= 0 {
+		return search{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reset(generation)
+	e, ok := c.entries[key]
+	if !ok {
+		c.misses.Add(1)
+		return search{}, false
+	}
+	c.hits.Add(1)
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).found, true
+}
+
+func (c *resultCache) put(generation uint64, key string, found search) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// результат, посчитанный на старом индексе, уже не нужен
+	if generation < c.generation {
+		return
+	}
+	c.reset(generation)
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*cacheEntry).found = found
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, found: found})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// reset drops all entries if the generation is newer than the cached one.
+func (c *resultCache) reset(generation uint64) {
+	if generation <= c.generation {
+		return
+	}
+	c.generation = generation
+	c.order.Init()
+	clear(c.entries)
+}
+
+func (c *resultCache) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{
+		Hits:     c.hits.Load(),
+		Misses:   c.misses.Load(),
+		Entries:  c.order.Len(),
+		Capacity: c.capacity,
+	}
+}
+
+// cacheKey identifies a search by the path serving it, the normalized
+// query and every option affecting the result, so that phrases differing
+// only in word forms or stop words share an entry.
+func cacheKey(path string, terms []Term, phrases Phrases, speakers []string, limit int, opts SearchOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%d|%t|%t|%s|", path, limit, opts.Fuzzy, opts.Explain, opts.Sort)
+	if !opts.From.IsZero() {
+		b.WriteString(opts.From.Format(time.DateOnly))
+	}
+	b.WriteByte('|')
+	if !opts.To.IsZero() {
+		b.WriteString(opts.To.Format(time.DateOnly))
+	}
+	b.WriteByte('|')
+	for _, field := range slices.Sorted(maps.Keys(opts.Weights)) {
+		fmt.Fprintf(&b, "%s:%g,", field, opts.Weights[field])
+	}
+	b.WriteByte('|')
+	for _, term := range terms {
+		fmt.Fprintf(&b, "%d:%s,", term.Fields, term.Word)
+	}
+	fmt.Fprintf(&b, "|%s|%s|", strings.Join(phrases.Required, ","), strings.Join(phrases.Boost, ","))
+	b.WriteString(strings.Join(slices.Sorted(slices.Values(speakers)), ","))
+	return b.String()
+}
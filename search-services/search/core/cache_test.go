@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCache(t *testing.T) {
+	cache := newResultCache(2)
+
+	_, ok := cache.get(1, "a")
+	assert.False(t, ok)
+
+	cache.put(1, "a", search{result: SearchResult{Total: 1}})
+	cache.put(1, "b", search{result: SearchResult{Total: 2}})
+	found, ok := cache.get(1, "a")
+	require.True(t, ok)
+	assert.Equal(t, 1, found.result.Total)
+
+	// b вытесняется как давно не использованный
+	cache.put(1, "c", search{result: SearchResult{Total: 3}})
+	_, ok = cache.get(1, "b")
+	assert.False(t, ok)
+	_, ok = cache.get(1, "c")
+	assert.True(t, ok)
+
+	// запоздавший результат старого поколения не сохраняется
+	cache.put(2, "a", search{result: SearchResult{Total: 4}})
+	cache.put(1, "b", search{result: SearchResult{Total: 2}})
+	_, ok = cache.get(2, "c")
+	assert.False(t, ok)
+	_, ok = cache.get(2, "b")
+	assert.False(t, ok)
+
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 4, Entries: 1, Capacity: 2}, cache.stats())
+}
+
+func TestResultCache_Disabled(t *testing.T) {
+	cache := newResultCache(0)
+	cache.put(1, "a", search{result: SearchResult{Total: 1}})
+	_, ok := cache.get(1, "a")
+	assert.False(t, ok)
+	assert.Equal(t, CacheStats{}, cache.stats())
+}
+
+func TestCacheKey(t *testing.T) {
+	robot := []Term{{Word: "robot", Fields: AllFields}}
+	laser := []Term{{Word: "robot", Fields: AllFields}, {Word: "laser", Fields: AllFields}}
+	opts := SearchOptions{Weights: map[string]float64{"title": 5, "alt": 1}}
+	assert.Equal(t,
+		cacheKey(PathIndex, laser, Phrases{}, []string{"cueball", "megan"}, 10, opts),
+		cacheKey(PathIndex, laser, Phrases{}, []string{"megan", "cueball"}, 10, SearchOptions{Weights: map[string]float64{"alt": 1, "title": 5}}))
+
+	keys := map[string]bool{}
+	for _, key := range []string{
+		cacheKey(PathIndex, robot, Phrases{}, nil, 10, SearchOptions{}),
+		cacheKey(PathDB, robot, Phrases{}, nil, 10, SearchOptions{}),
+		cacheKey(PathIndex, robot, Phrases{}, nil, 5, SearchOptions{}),
+		cacheKey(PathIndex, robot, Phrases{}, nil, 10, SearchOptions{Fuzzy: true}),
+		cacheKey(PathIndex, robot, Phrases{}, nil, 10, SearchOptions{Explain: true}),
+		cacheKey(PathIndex, robot, Phrases{}, nil, 10, SearchOptions{Filter: Filter{Sort: SortNewest}}),
+		cacheKey(PathIndex, robot, Phrases{}, nil, 10, SearchOptions{Filter: Filter{From: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)}}),
+		cacheKey(PathIndex, robot, Phrases{}, nil, 10, SearchOptions{Filter: Filter{To: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)}}),
+		cacheKey(PathIndex, []Term{{Word: "robot", Fields: FieldTitle}}, Phrases{}, nil, 10, SearchOptions{}),
+		cacheKey(PathIndex, laser, Phrases{}, nil, 10, SearchOptions{}),
+		cacheKey(PathIndex, laser, Phrases{Boost: []string{"robot laser"}}, nil, 10, SearchOptions{}),
+		cacheKey(PathIndex, laser, Phrases{Required: []string{"robot laser"}}, nil, 10, SearchOptions{}),
+		cacheKey(PathIndex, robot, Phrases{}, []string{"megan"}, 10, SearchOptions{}),
+	} {
+		keys[key] = true
+	}
+	assert.Len(t, keys, 13)
+}
+
+func TestService_SearchCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := NewMockDB(ctrl)
+	mockWords := NewMockWords(ctrl)
+	service, _ := NewService(slog.Default(), mockDB, mockWords, DefaultWeights, 10, nil, nil)
+
+	comics := []Comics{{ID: 1, URL: "u1", Words: []string{"robot"}}}
+	mockDB.EXPECT().AllComics(gomock.Any()).Return(comics, nil).Times(3)
+	require.NoError(t, service.BuildIndex(context.Background()))
+
+	// разные формы слова нормализуются в одни термины и делят запись кеша
+	mockWords.EXPECT().Norm(gomock.Any(), "robot").Return([]string{"robot"}, nil).Times(3)
+	mockWords.EXPECT().Norm(gomock.Any(), "Robots").Return([]string{"robot"}, nil)
+	for _, phrase := range []string{"robot", "Robots", "robot"} {
+		result, err := service.IndexSearch(context.Background(), phrase, 10, SearchOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Total)
+		assert.Equal(t, uint64(1), result.Generation)
+	}
+
+	// пересборка без изменений не сбрасывает кеш
+	require.NoError(t, service.BuildIndex(context.Background()))
+	_, err := service.IndexSearch(context.Background(), "robot", 10, SearchOptions{})
+	require.NoError(t, err)
+
+	wordsStats := WordsClientStats{CacheStats: CacheStats{Hits: 4, Misses: 2, Entries: 2, Capacity: 100}, Retries: 1}
+	mockWords.EXPECT().Stats().Return(wordsStats)
+	stats, err := service.IndexStats(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), stats.Generation)
+	assert.Equal(t, CacheStats{Hits: 3, Misses: 1, Entries: 1, Capacity: 10}, stats.Cache)
+	assert.Equal(t, wordsStats, stats.WordsClient)
+
+	// изменившиеся комиксы дают новое поколение и новый поиск
+	comics[0].Words = []string{"robot", "laser"}
+	require.NoError(t, service.BuildIndex(context.Background()))
+	mockWords.EXPECT().Norm(gomock.Any(), "robot").Return([]string{"robot"}, nil)
+	result, err := service.IndexSearch(context.Background(), "robot", 10, SearchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), result.Generation)
+
+	// новая версия словарей Words Normalizer тоже сбрасывает кеш
+	service.WordsChanged()
+	mockWords.EXPECT().Norm(gomock.Any(), "robot").Return([]string{"robot"}, nil)
+	result, err = service.IndexSearch(context.Background(), "robot", 10, SearchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), result.Generation)
+}
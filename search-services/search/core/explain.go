@@ -0,0 +1,131 @@
+package core
+
+import "yadro.com/course/search/core/bitmap"
+
+// Search paths reported in query explanations.
+const (
+	PathDB    = "db"
+	PathIndex = "index"
+)
+
+// QueryExplanation tells which search path and index generation served a
+// query and what the query was normalized to.
+type QueryExplanation struct {
+	Path       string
+	Generation uint64
+	// Terms are the normalized query words, field-qualified ones with their
+	// prefix like title:robot
+	Terms []string
+	// Phrases are the bigrams of the query boosting the hits having them
+	Phrases []string
+}
+
+// Explanation shows how a hit was ranked. Hits are ordered by the number of
+// distinct matched terms, then by the number of matched query bigrams, then
+// by the weight of the fields the terms matched in and then by the total
+// number of occurrences, so the four numbers together are the final score.
+// The full-text search of the database orders hits by Rank instead.
+type Explanation struct {
+	Terms   []TermMatch
+	Unique  int
+	Phrases []string
+	Weight  float64
+	Total   int
+	Rank    float64
+}
+
+// TermMatch is the contribution of a query term to a hit.
+type TermMatch struct {
+	Term string
+	// Origin is the query word a synonym was added for, empty for the
+	// query words themselves
+	Origin string
+	// Fields the term was searched in and Matched the ones of them the
+	// comic has it in, empty if the term did not match
+	Fields  []string
+	Matched []string
+	Freq    int
+	Weight  float64
+}
+
+// explain describes the query and the ranking of the comics found by it.
+// Hits of the database come with the numbers it ordered them by, which are
+// kept; the index only tells which terms matched in which fields, so comics
+// missing from it, e.g. stored after it was built, are explained by those
+// numbers alone. Index hits missing from it are left without an explanation.
+// Terms searched in the dialogue of a query with speakers are explained as
+// matches in their lines only.
+func (idx *Index) explain(path string, terms []Term, phrases Phrases, speakers []string, comics []Comics, weights FieldWeights) *QueryExplanation {
+	masks := make(map[string]Field, len(terms))
+	var words []Term
+	for _, t := range terms {
+		if _, ok := masks[t.Word]; !ok {
+			words = append(words, t)
+		}
+		masks[t.Word] |= t.Fields
+	}
+	said := make(map[string]*bitmap.Bitmap)
+	if len(speakers) > 0 {
+		for word, fields := range masks {
+			if fields&(FieldTranscript|FieldDialogue) != 0 {
+				masks[word] = FieldDialogue
+				said[word] = idx.saidBy(word, speakers)
+			}
+		}
+	}
+
+	query := &QueryExplanation{Path: path, Generation: idx.generation, Terms: queryTerms(terms), Phrases: phrases.Boost}
+
+	for i := range comics {
+		scores := comics[i].Explanation
+		doc, ok := idx.ordinal(comics[i].ID)
+		if !ok {
+			if scores != nil {
+				scores.Terms = []TermMatch{}
+			}
+			continue
+		}
+		e := &Explanation{Terms: make([]TermMatch, 0, len(words))}
+		found := make(map[string]bool, len(words))
+		for _, t := range words {
+			m := TermMatch{Term: t.Word, Origin: t.Origin, Fields: masks[t.Word].names(), Matched: []string{}}
+			if p, ok := idx.terms[t.Word]; ok && p.docs.Contains(doc) && (said[t.Word] == nil || said[t.Word].Contains(doc)) {
+				n := p.docs.Rank(doc) - 1
+				if f := p.field(n) & masks[t.Word]; f != 0 {
+					m.Matched = f.names()
+					m.Freq = int(p.freq(n))
+					m.Weight = weights.Of(f) * t.boost()
+					if !found[t.concept()] {
+						found[t.concept()] = true
+						e.Unique++
+					}
+					e.Weight += m.Weight
+					e.Total += m.Freq
+				}
+			}
+			e.Terms = append(e.Terms, m)
+		}
+		for _, bigram := range phrases.Boost {
+			if docs, ok := idx.bigrams[bigram]; ok && docs.Contains(doc) {
+				e.Phrases = append(e.Phrases, bigram)
+			}
+		}
+		if scores != nil {
+			e.Unique, e.Weight, e.Total, e.Rank = scores.Unique, scores.Weight, scores.Total, scores.Rank
+		}
+		comics[i].Explanation = e
+	}
+	return query
+}
+
+// queryTerms returns the words of the query without the synonyms added to
+// it, field-qualified ones with their prefix.
+func queryTerms(terms []Term) []string {
+	words := make([]string, 0, len(terms))
+	for _, t := range terms {
+		if t.Origin == "" {
+			words = append(words, t.Fields.prefix()+t.Word)
+		}
+	}
+	return words
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Explain(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"robot", "robot", "laser"},
+			TitleWords: []string{"robot"}, TranscriptWords: []string{"robot", "laser"}, Bigrams: []string{"robot laser"}},
+		{ID: 2, Words: []string{"robot"}, AltWords: []string{"robot"}},
+		{ID: 3, Words: []string{"cat"}},
+	})
+	index.generation = 7
+
+	terms := []Term{{Word: "robot", Fields: AllFields}, {Word: "laser", Fields: FieldTitle}}
+	phrases := Phrases{Boost: []string{"robot laser"}}
+	comics, _ := index.Search(terms, phrases, 0, DefaultWeights, Filter{})
+	comics = append(comics, Comics{ID: 42})
+	query := index.explain(PathIndex, terms, phrases, nil, comics, DefaultWeights)
+
+	assert.Equal(t, &QueryExplanation{
+		Path: PathIndex, Generation: 7, Terms: []string{"robot", "title:laser"}, Phrases: []string{"robot laser"},
+	}, query)
+	require.Len(t, comics, 3)
+
+	// laser есть в комиксе 1 только в transcript и не учитывается
+	assert.Equal(t, &Explanation{
+		Terms: []TermMatch{
+			{Term: "robot", Fields: []string{"title", "alt", "transcript"}, Matched: []string{"title", "transcript"}, Freq: 2, Weight: 4},
+			{Term: "laser", Fields: []string{"title"}, Matched: []string{}},
+		},
+		Unique:  1,
+		Phrases: []string{"robot laser"},
+		Weight:  4,
+		Total:   2,
+	}, comics[0].Explanation)
+	assert.Equal(t, 2, comics[1].ID)
+	assert.Equal(t, 1.5, comics[1].Explanation.Weight)
+	assert.Empty(t, comics[1].Explanation.Phrases)
+	assert.Nil(t, comics[2].Explanation)
+}
+
+func TestIndex_ExplainDB(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"robot"}, TitleWords: []string{"robot"}},
+	})
+
+	// числа, по которым база упорядочила комиксы, сохраняются
+	terms := []Term{{Word: "robot", Fields: AllFields}}
+	comics := []Comics{
+		{ID: 1, Explanation: &Explanation{Rank: 0.4}},
+		{ID: 2, Explanation: &Explanation{Unique: 1, Weight: 1, Total: 1}},
+	}
+	index.explain(PathDB, terms, Phrases{}, nil, comics, DefaultWeights)
+
+	assert.Equal(t, &Explanation{
+		Terms: []TermMatch{
+			{Term: "robot", Fields: []string{"title", "alt", "transcript"}, Matched: []string{"title"}, Freq: 1, Weight: 3},
+		},
+		Rank: 0.4,
+	}, comics[0].Explanation)
+	assert.Equal(t, &Explanation{Terms: []TermMatch{}, Unique: 1, Weight: 1, Total: 1}, comics[1].Explanation)
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field is a set of comic text fields a term occurs in or is searched in.
+type Field uint8
+
+const (
+	FieldTitle Field = 1 << iota
+	FieldAlt
+	FieldTranscript
+	// FieldDialogue and FieldScene are the parts of the transcript said by
+	// the characters and describing the scene, weighted as the transcript
+	FieldDialogue
+	FieldScene
+
+	// AllFields is the whole comic, the transcript covers its parts
+	AllFields = FieldTitle | FieldAlt | FieldTranscript
+
+	transcriptFields = FieldTranscript | FieldDialogue | FieldScene
+)
+
+var fieldNames = []struct {
+	name  string
+	field Field
+}{
+	{"title", FieldTitle},
+	{"alt", FieldAlt},
+	{"transcript", FieldTranscript},
+	{"dialogue", FieldDialogue},
+	{"scene", FieldScene},
+}
+
+func parseField(name string) (Field, bool) {
+	for _, f := range fieldNames {
+		if f.name == name {
+			return f.field, true
+		}
+	}
+	return 0, false
+}
+
+// prefix returns the query prefix restricting a term to the fields, empty
+// for a term searched everywhere.
+func (f Field) prefix() string {
+	for _, n := range fieldNames {
+		if n.field == f {
+			return n.name + ":"
+		}
+	}
+	return ""
+}
+
+// FieldWeights sets how much a match in every field adds to the rank.
+type FieldWeights struct {
+	Title      float64
+	Alt        float64
+	Transcript float64
+}
+
+var DefaultWeights = FieldWeights{Title: 3, Alt: 1.5, Transcript: 1}
+
+func (w FieldWeights) Validate() error {
+	if w.Title < 0 || w.Alt < 0 || w.Transcript < 0 {
+		return fmt.Errorf("negative field weight: %+v", w)
+	}
+	return nil
+}
+
+// Of sums the weights of the given fields.
+func (w FieldWeights) Of(f Field) float64 {
+	var sum float64
+	if f&FieldTitle != 0 {
+		sum += w.Title
+	}
+	if f&FieldAlt != 0 {
+		sum += w.Alt
+	}
+	if f&transcriptFields != 0 {
+		sum += w.Transcript
+	}
+	return sum
+}
+
+// Override replaces weights of the named fields.
+func (w FieldWeights) Override(weights map[string]float64) (FieldWeights, error) {
+	for name, weight := range weights {
+		f, ok := parseField(name)
+		if !ok {
+			return w, fmt.Errorf("%w: unknown field %q", ErrBadArguments, name)
+		}
+		if weight < 0 {
+			return w, fmt.Errorf("%w: negative weight of %s", ErrBadArguments, name)
+		}
+		if f&(FieldDialogue|FieldScene) != 0 {
+			return w, fmt.Errorf("%w: %s is weighted as transcript", ErrBadArguments, name)
+		}
+		switch f {
+		case FieldTitle:
+			w.Title = weight
+		case FieldAlt:
+			w.Alt = weight
+		case FieldTranscript:
+			w.Transcript = weight
+		}
+	}
+	return w, nil
+}
+
+// Term is a normalized query word with the fields it is searched in.
+// A synonym added to the query has the word it expands as Origin and counts
+// as a match of that word with the lower Weight.
+type Term struct {
+	Word   string
+	Fields Field
+	Origin string
+	Weight float64
+}
+
+// concept returns the query word the term stands for.
+func (t Term) concept() string {
+	if t.Origin != "" {
+		return t.Origin
+	}
+	return t.Word
+}
+
+// boost returns the factor the field weights of a match are multiplied by.
+func (t Term) boost() float64 {
+	if t.Origin != "" {
+		return t.Weight
+	}
+	return 1
+}
+
+func termWords(terms []Term) []string {
+	words := make([]string, len(terms))
+	for i, t := range terms {
+		words[i] = t.Word
+	}
+	return words
+}
+
+// parseQuery splits field-qualified parts such as title:robot out of the
+// phrase. The rest of the phrase is returned untouched and is searched in
+// all fields.
+func parseQuery(phrase string) (string, map[Field][]string) {
+	var scoped map[Field][]string
+	var plain []string
+	for _, part := range strings.Fields(phrase) {
+		name, value, ok := strings.Cut(part, ":")
+		f, known := parseField(strings.ToLower(name))
+		if !ok || !known || value == "" {
+			plain = append(plain, part)
+			continue
+		}
+		if scoped == nil {
+			scoped = make(map[Field][]string)
+		}
+		scoped[f] = append(scoped[f], value)
+	}
+	if scoped == nil {
+		return phrase, nil
+	}
+	return strings.Join(plain, " "), scoped
+}
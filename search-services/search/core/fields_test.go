@@ -0,0 +1,79 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchFieldWeights(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"robot", "laser"}, TranscriptWords: []string{"robot", "laser"}},
+		{ID: 2, Words: []string{"robot"}, TitleWords: []string{"robot"}},
+		{ID: 3, Words: []string{"robot"}, AltWords: []string{"robot"}},
+		{ID: 4, Words: []string{"robot"}},
+	})
+	ids := func(comics []Comics) []int {
+		var res []int
+		for _, c := range comics {
+			res = append(res, c.ID)
+		}
+		return res
+	}
+
+	t.Run("title outranks transcript", func(t *testing.T) {
+		comics, total := index.Search([]Term{{Word: "robot", Fields: AllFields}}, Phrases{}, 10, DefaultWeights, Filter{})
+		assert.Equal(t, 4, total)
+		assert.Equal(t, []int{2, 3, 1, 4}, ids(comics))
+	})
+
+	t.Run("more terms still win", func(t *testing.T) {
+		comics, _ := index.Search([]Term{{Word: "robot", Fields: AllFields}, {Word: "laser", Fields: AllFields}}, Phrases{}, 10, DefaultWeights, Filter{})
+		assert.Equal(t, []int{1, 2, 3, 4}, ids(comics))
+	})
+
+	t.Run("custom weights", func(t *testing.T) {
+		comics, _ := index.Search([]Term{{Word: "robot", Fields: AllFields}}, Phrases{}, 10, FieldWeights{Title: 1, Alt: 1, Transcript: 5}, Filter{})
+		assert.Equal(t, []int{1, 4, 2, 3}, ids(comics))
+	})
+
+	t.Run("restricted to title", func(t *testing.T) {
+		comics, total := index.Search([]Term{{Word: "robot", Fields: FieldTitle}}, Phrases{}, 10, DefaultWeights, Filter{})
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []int{2}, ids(comics))
+	})
+}
+
+func TestParseQuery(t *testing.T) {
+	plain, scoped := parseQuery("robots in space")
+	assert.Equal(t, "robots in space", plain)
+	assert.Nil(t, scoped)
+
+	plain, scoped = parseQuery("Title:robot space alt:laser alt:beam http://xkcd.com body:x scene:door")
+	assert.Equal(t, "space http://xkcd.com body:x", plain)
+	assert.Equal(t, map[Field][]string{
+		FieldTitle: {"robot"},
+		FieldAlt:   {"laser", "beam"},
+		FieldScene: {"door"},
+	}, scoped)
+}
+
+func TestFieldWeights_Override(t *testing.T) {
+	w, err := DefaultWeights.Override(map[string]float64{"title": 10, "transcript": 0})
+	require.NoError(t, err)
+	assert.Equal(t, FieldWeights{Title: 10, Alt: 1.5, Transcript: 0}, w)
+	assert.Equal(t, DefaultWeights, FieldWeights{Title: 3, Alt: 1.5, Transcript: 1})
+
+	_, err = DefaultWeights.Override(map[string]float64{"body": 1})
+	assert.ErrorIs(t, err, ErrBadArguments)
+	_, err = DefaultWeights.Override(map[string]float64{"alt": -1})
+	assert.ErrorIs(t, err, ErrBadArguments)
+	_, err = DefaultWeights.Override(map[string]float64{"dialogue": 2})
+	assert.ErrorIs(t, err, ErrBadArguments)
+
+	assert.Equal(t, 4.5, DefaultWeights.Of(FieldTitle|FieldAlt))
+	assert.Equal(t, 1.0, DefaultWeights.Of(FieldTranscript|FieldDialogue|FieldScene))
+	assert.Equal(t, "title:", FieldTitle.prefix())
+	assert.Equal(t, "", AllFields.prefix())
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// Sort is the order of search hits.
+type Sort string
+
+const (
+	SortRelevance Sort = "relevance"
+	SortNewest    Sort = "newest"
+	SortOldest    Sort = "oldest"
+	SortID        Sort = "id"
+)
+
+// Filter restricts search hits to comics published within [From, To] and
+// sets their order. Zero bounds are open, an empty Sort means relevance.
+// Speakers are the keys of the speakers given in the query, see speakerKey.
+type Filter struct {
+	From     time.Time
+	To       time.Time
+	Sort     Sort
+	Speakers []string
+}
+
+func (f Filter) Validate() error {
+	switch f.Sort {
+	case "", SortRelevance, SortNewest, SortOldest, SortID:
+	default:
+		return fmt.Errorf("%w: unknown sort %q", ErrBadArguments, f.Sort)
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && f.From.After(f.To) {
+		return fmt.Errorf("%w: date range from %s to %s is empty",
+			ErrBadArguments, f.From.Format(time.DateOnly), f.To.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// dated reports whether the filter restricts publish dates. Comics with an
+// unknown date never match such a filter.
+func (f Filter) dated() bool {
+	return !f.From.IsZero() || !f.To.IsZero()
+}
+
+func (f Filter) contains(date time.Time) bool {
+	if date.IsZero() {
+		return false
+	}
+	return (f.From.IsZero() || !date.Before(f.From)) && (f.To.IsZero() || !date.After(f.To))
+}
+
+// compareDates orders known dates before unknown ones in both directions.
+func compareDates(a, b time.Time, newest bool) int {
+	switch {
+	case a.Equal(b):
+		return 0
+	case a.IsZero():
+		return 1
+	case b.IsZero():
+		return -1
+	case a.Before(b) == newest:
+		return 1
+	}
+	return -1
+}
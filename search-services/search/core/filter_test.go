@@ -0,0 +1,81 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_SearchFilter(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"robot", "laser"}, Date: day(2006, time.January, 1)},
+		{ID: 2, Words: []string{"robot"}, Date: day(2010, time.March, 5)},
+		{ID: 3, Words: []string{"robot"}, Date: day(2010, time.December, 31)},
+		{ID: 4, Words: []string{"robot"}},
+		{ID: 5, Words: []string{"robot"}, Date: day(2015, time.June, 1)},
+	})
+	robot := []Term{{Word: "robot", Fields: AllFields}, {Word: "laser", Fields: AllFields}}
+	ids := func(comics []Comics) []int {
+		var res []int
+		for _, c := range comics {
+			res = append(res, c.ID)
+		}
+		return res
+	}
+
+	t.Run("relevance", func(t *testing.T) {
+		comics, total := index.Search(robot, Phrases{}, 10, DefaultWeights, Filter{})
+		assert.Equal(t, 5, total)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, ids(comics))
+		assert.Equal(t, day(2006, time.January, 1), comics[0].Date)
+	})
+
+	t.Run("date range", func(t *testing.T) {
+		comics, total := index.Search(robot, Phrases{}, 10, DefaultWeights, Filter{
+			From: day(2010, time.January, 1),
+			To:   day(2010, time.December, 31),
+		})
+		assert.Equal(t, 2, total)
+		assert.Equal(t, []int{2, 3}, ids(comics))
+	})
+
+	t.Run("open range excludes unknown dates", func(t *testing.T) {
+		comics, total := index.Search(robot, Phrases{}, 10, DefaultWeights, Filter{From: day(2010, time.June, 1)})
+		assert.Equal(t, 2, total)
+		assert.Equal(t, []int{3, 5}, ids(comics))
+	})
+
+	t.Run("newest", func(t *testing.T) {
+		comics, _ := index.Search(robot, Phrases{}, 10, DefaultWeights, Filter{Sort: SortNewest})
+		assert.Equal(t, []int{5, 3, 2, 1, 4}, ids(comics))
+	})
+
+	t.Run("oldest with limit", func(t *testing.T) {
+		comics, total := index.Search(robot, Phrases{}, 3, DefaultWeights, Filter{Sort: SortOldest})
+		assert.Equal(t, 5, total)
+		assert.Equal(t, []int{1, 2, 3}, ids(comics))
+	})
+
+	t.Run("id", func(t *testing.T) {
+		comics, _ := index.Search([]Term{{Word: "robot", Fields: AllFields}}, Phrases{}, 10, DefaultWeights, Filter{Sort: SortID})
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, ids(comics))
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		comics, total := index.Search(robot, Phrases{}, 10, DefaultWeights, Filter{From: day(2020, time.January, 1)})
+		assert.Equal(t, 0, total)
+		assert.Empty(t, comics)
+	})
+}
+
+func TestFilter_Validate(t *testing.T) {
+	assert.NoError(t, Filter{}.Validate())
+	assert.NoError(t, Filter{Sort: SortOldest}.Validate())
+	assert.ErrorIs(t, Filter{Sort: "popular"}.Validate(), ErrBadArguments)
+
+	from := time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, Filter{From: from, To: from}.Validate())
+	assert.ErrorIs(t, Filter{From: from, To: from.AddDate(0, 0, -1)}.Validate(), ErrBadArguments)
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const gramSize = 3
+
+// trigrams returns the padded character trigrams of a term, so that short
+// terms and term boundaries still produce grams to compare.
+func trigrams(term string) []string {
+	runes := []rune("$" + term + "$")
+	if len(runes) < gramSize {
+		return []string{string(runes)}
+	}
+	grams := make([]string, 0, len(runes)-gramSize+1)
+	seen := make(map[string]struct{}, len(runes))
+	for i := 0; i+gramSize <= len(runes); i++ {
+		gram := string(runes[i : i+gramSize])
+		if _, ok := seen[gram]; ok {
+			continue
+		}
+		seen[gram] = struct{}{}
+		grams = append(grams, gram)
+	}
+	return grams
+}
+
+func maxEdits(term string) int {
+	switch n := utf8.RuneCountInString(term); {
+	case n < 3:
+		return 0
+	case n <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Fuzzy returns the indexed term closest to the given one by edit distance.
+// Candidates are preselected by shared trigrams and verified with a bounded
+// edit distance; ties go to the term found in more comics.
+func (idx *Index) Fuzzy(term string) (string, bool) {
+	if _, ok := idx.terms[term]; ok {
+		return term, true
+	}
+	k := maxEdits(term)
+	if k == 0 {
+		return "", false
+	}
+
+	best, bestDist, bestFreq := "", k+1, 0
+	for _, t := range idx.candidates(term, k) {
+		candidate := idx.vocab[t]
+		dist := editDistance(term, candidate, k)
+		if dist > k {
+			continue
+		}
+		freq := idx.DocFreq(candidate)
+		if dist < bestDist || dist == bestDist && (freq > bestFreq || freq == bestFreq && candidate < best) {
+			best, bestDist, bestFreq = candidate, dist, freq
+		}
+	}
+	return best, best != ""
+}
+
+// candidates returns vocabulary terms that may be within k edits of term.
+// Every edit changes at most gramSize trigrams (a transposition one more), so
+// a close term has to share the rest of them. When the term is too short for
+// that bound to exclude anything, terms of a similar length are checked instead.
+func (idx *Index) candidates(term string, k int) []int32 {
+	grams := trigrams(term)
+	need := len(grams) - k*(gramSize+1)
+	if need <= 0 {
+		n := utf8.RuneCountInString(term)
+		var res []int32
+		for l := n - k; l <= n+k; l++ {
+			res = append(res, idx.lengths[l]...)
+		}
+		return res
+	}
+
+	shared := make(map[int32]int)
+	for _, gram := range grams {
+		for _, t := range idx.grams[gram] {
+			shared[t]++
+		}
+	}
+	res := make([]int32, 0, len(shared))
+	for t, n := range shared {
+		if n >= need {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// correct replaces unknown query terms with the closest indexed ones. The
+// suggestion is the corrected query in surface words, empty if nothing was
+// corrected; the corrected terms are returned only when fuzzy is set.
+func (idx *Index) correct(terms []Term, fuzzy bool) ([]Term, string) {
+	corrected := slices.Clone(terms)
+	changed := false
+	for i, t := range terms {
+		if _, ok := idx.terms[t.Word]; ok {
+			continue
+		}
+		if match, ok := idx.Fuzzy(t.Word); ok {
+			corrected[i].Word = match
+			changed = true
+		}
+	}
+	if !changed {
+		return terms, ""
+	}
+
+	forms := make([]string, len(corrected))
+	for i, t := range corrected {
+		forms[i] = t.Fields.prefix() + idx.Form(t.Word)
+	}
+	suggestion := strings.Join(forms, " ")
+	if !fuzzy {
+		return terms, suggestion
+	}
+	return corrected, suggestion
+}
+
+// editDistance computes the Damerau-Levenshtein (optimal string alignment)
+// distance between a and b, so that swapped letters count as one typo. It
+// gives up early and returns max+1 once the distance is known to exceed max.
+func editDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > max || -d > max {
+		return max + 1
+	}
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
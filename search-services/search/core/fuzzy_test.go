@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_Fuzzy(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"linux", "kernel"}},
+		{ID: 2, Words: []string{"kernel", "panic"}},
+		{ID: 3, Words: []string{"kennel", "dog"}},
+	})
+
+	tests := []struct {
+		term  string
+		want  string
+		found bool
+	}{
+		{term: "kernel", want: "kernel", found: true},
+		{term: "kernal", want: "kernel", found: true},
+		{term: "krnl", want: "", found: false},
+		{term: "linx", want: "linux", found: true},
+		{term: "knernel", want: "kernel", found: true},
+		{term: "dg", want: "", found: false},
+		{term: "xkcd", want: "", found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			got, ok := index.Fuzzy(tt.term)
+			assert.Equal(t, tt.found, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("kernel", "kernel", 2))
+	assert.Equal(t, 1, editDistance("kernal", "kernel", 2))
+	assert.Equal(t, 1, editDistance("tset", "test", 1))
+	assert.Equal(t, 3, editDistance("kitten", "sitting", 3))
+	assert.Equal(t, 3, editDistance("kitten", "sitting", 2))
+	assert.Equal(t, 1, editDistance("мир", "миф", 1))
+}
@@ -0,0 +1,216 @@
+package core
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	snippetLen     = 160
+	snippetContext = 30
+	ellipsis       = "…"
+)
+
+type token struct {
+	span Span
+	stem string
+}
+
+// fieldTokens returns the tokens the words service stored for the title,
+// the alt text and the transcript of the comic, nil if it stored none.
+func fieldTokens(comic Comics) *[3][]token {
+	if len(comic.TitleTokens)+len(comic.AltTokens)+len(comic.TranscriptTokens) == 0 {
+		return nil
+	}
+	return &[3][]token{tokens(comic.TitleTokens), tokens(comic.AltTokens), tokens(comic.TranscriptTokens)}
+}
+
+// tokens returns the tokens the words service stored for a field, nil if
+// it stored none.
+func tokens(stored []Token) []token {
+	if len(stored) == 0 {
+		return nil
+	}
+	tokens := make([]token, len(stored))
+	for i, t := range stored {
+		tokens[i] = token{span: Span{Start: t.Start, End: t.End}, stem: t.Term}
+	}
+	return tokens
+}
+
+// tokenize splits text on punctuation and spaces and resolves every token
+// to a stem through the surface forms known to the index. It only serves
+// comics stored without their tokens, whose inflected forms other than the
+// stored one are not highlighted.
+func (idx *Index) tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		sep := unicode.IsPunct(r) || unicode.IsSpace(r) || r == '+'
+		switch {
+		case sep && start >= 0:
+			tokens = append(tokens, idx.token(text, start, i))
+			start = -1
+		case !sep && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, idx.token(text, start, len(text)))
+	}
+	return tokens
+}
+
+func (idx *Index) token(text string, start, end int) token {
+	word := strings.ToLower(text[start:end])
+	stem, ok := idx.surface[word]
+	if !ok {
+		stem = word
+	}
+	return token{span: Span{Start: start, End: end}, stem: stem}
+}
+
+// annotate fills matched terms and a highlighted snippet of every hit.
+func (idx *Index) annotate(comics []Comics, words []string) {
+	query := make(map[string]struct{}, len(words))
+	var terms []string
+	for _, word := range words {
+		if _, ok := query[word]; ok {
+			continue
+		}
+		query[word] = struct{}{}
+		terms = append(terms, word)
+	}
+
+	for i := range comics {
+		doc, ok := idx.ordinal(comics[i].ID)
+		if !ok {
+			continue
+		}
+		for _, term := range terms {
+			if p, ok := idx.terms[term]; ok && p.docs.Contains(doc) {
+				comics[i].Terms = append(comics[i].Terms, idx.Form(term))
+			}
+		}
+		comics[i].Snippet = idx.snippet(idx.docs[doc], query)
+	}
+}
+
+// snippet picks the field whose best window contains the most distinct query
+// terms. Without any match the alt text is shown as it describes the comic.
+func (idx *Index) snippet(doc Document, query map[string]struct{}) *Snippet {
+	fields := []struct {
+		name, text string
+		tokens     []token
+	}{
+		{"title", doc.Title, nil},
+		{"alt", doc.Alt, nil},
+		{"transcript", doc.Transcript, nil},
+	}
+	if doc.tokens != nil {
+		for i := range fields {
+			fields[i].tokens = doc.tokens[i]
+		}
+	}
+
+	var best *Snippet
+	bestScore := 0
+	for _, field := range fields {
+		if field.text == "" {
+			continue
+		}
+		tokens := field.tokens
+		if tokens == nil {
+			tokens = idx.tokenize(field.text)
+		}
+		s, score := window(field.text, tokens, query)
+		if score > bestScore {
+			s.Field = field.name
+			best, bestScore = s, score
+		}
+	}
+	if best != nil {
+		return best
+	}
+
+	// the alt text first, then the title
+	for _, i := range []int{1, 0} {
+		if field := fields[i]; field.text != "" {
+			s, _ := window(field.text, nil, nil)
+			s.Field = field.name
+			return s
+		}
+	}
+	return nil
+}
+
+// window cuts up to snippetLen bytes of text around the densest group of
+// matched tokens and returns it with the number of distinct terms inside.
+func window(text string, tokens []token, query map[string]struct{}) (*Snippet, int) {
+	var hits []token
+	for _, t := range tokens {
+		if _, ok := query[t.stem]; ok {
+			hits = append(hits, t)
+		}
+	}
+
+	start, end := 0, len(text)
+	if len(text) > snippetLen {
+		bestCount := -1
+		for i, h := range hits {
+			if n := distinct(hits[i:], h.span.Start+snippetLen-snippetContext); n > bestCount {
+				start, bestCount = max(0, h.span.Start-snippetContext), n
+			}
+		}
+		end = min(len(text), start+snippetLen)
+
+		// не режем слова пополам
+		for _, t := range tokens {
+			if t.span.Start < start && t.span.End > start {
+				start = t.span.Start
+			}
+			if t.span.Start < end && t.span.End > end {
+				end = t.span.Start
+				break
+			}
+		}
+		for start > 0 && !utf8.RuneStart(text[start]) {
+			start--
+		}
+		for end < len(text) && !utf8.RuneStart(text[end]) {
+			end--
+		}
+	}
+
+	s := &Snippet{Text: strings.TrimSpace(text[start:end])}
+	shift := start + strings.Index(text[start:end], s.Text)
+	if start > 0 {
+		s.Text = ellipsis + s.Text
+		shift -= len(ellipsis)
+	}
+	if end < len(text) {
+		s.Text += ellipsis
+	}
+
+	seen := make(map[string]struct{})
+	for _, h := range hits {
+		if h.span.Start < start || h.span.End > end {
+			continue
+		}
+		seen[h.stem] = struct{}{}
+		s.Highlights = append(s.Highlights, Span{Start: h.span.Start - shift, End: h.span.End - shift})
+	}
+	return s, len(seen)
+}
+
+func distinct(hits []token, limit int) int {
+	seen := make(map[string]struct{})
+	for _, h := range hits {
+		if h.span.End > limit {
+			break
+		}
+		seen[h.stem] = struct{}{}
+	}
+	return len(seen)
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func highlighted(s *Snippet) []string {
+	var marks []string
+	for _, h := range s.Highlights {
+		marks = append(marks, s.Text[h.Start:h.End])
+	}
+	return marks
+}
+
+func TestIndex_Annotate(t *testing.T) {
+	index := NewIndex([]Comics{
+		{
+			ID:    1,
+			Words: []string{"comput", "program"},
+			Forms: map[string]string{"comput": "computers", "program": "programming"},
+			Title: "Computers",
+			Alt:   "Programming computers is fun, a computer said.",
+		},
+		{
+			ID:    2,
+			Words: []string{"cat"},
+			Forms: map[string]string{"cat": "cat"},
+			Title: "Cat",
+			Alt:   "Just a cat.",
+		},
+	})
+
+	comics := []Comics{{ID: 1}, {ID: 2}, {ID: 3}}
+	index.annotate(comics, []string{"comput", "program", "comput"})
+
+	assert.Equal(t, []string{"computers", "programming"}, comics[0].Terms)
+	assert.Equal(t, "alt", comics[0].Snippet.Field)
+	assert.Equal(t, []string{"Programming", "computers"}, highlighted(comics[0].Snippet))
+
+	assert.Empty(t, comics[1].Terms)
+	assert.Equal(t, &Snippet{Field: "alt", Text: "Just a cat."}, comics[1].Snippet)
+
+	assert.Empty(t, comics[2].Terms)
+	assert.Nil(t, comics[2].Snippet)
+}
+
+func TestIndex_SnippetWindow(t *testing.T) {
+	transcript := strings.Repeat("Cueball stands there. ", 20) + "Megan: Robots are coming! " + strings.Repeat("Nothing happens. ", 20)
+	index := NewIndex([]Comics{{
+		ID:         1,
+		Words:      []string{"robot"},
+		Forms:      map[string]string{"robot": "robots"},
+		Title:      "Waiting",
+		Transcript: transcript,
+	}})
+
+	comics := []Comics{{ID: 1}}
+	index.annotate(comics, []string{"robot"})
+
+	s := comics[0].Snippet
+	assert.Equal(t, "transcript", s.Field)
+	assert.LessOrEqual(t, len(s.Text), snippetLen+2*len(ellipsis))
+	assert.True(t, strings.HasPrefix(s.Text, ellipsis))
+	assert.True(t, strings.HasSuffix(s.Text, ellipsis))
+	assert.Equal(t, []string{"Robots"}, highlighted(s))
+}
+
+func TestIndex_SnippetTokens(t *testing.T) {
+	// только одна форма основы известна индексу, остальные берутся из
+	// сохраненных токенов
+	index := NewIndex([]Comics{{
+		ID:               1,
+		Words:            []string{"robot", "come"},
+		Forms:            map[string]string{"robot": "robot", "come": "coming"},
+		Title:            "A robot",
+		Transcript:       "Robots are coming! The ROBOT'S here.",
+		TitleTokens:      []Token{{Start: 2, End: 7, Term: "robot"}},
+		TranscriptTokens: []Token{{Start: 0, End: 6, Term: "robot"}, {Start: 11, End: 17, Term: "come"}, {Start: 23, End: 30, Term: "robot"}},
+	}, {
+		ID:         2,
+		Words:      []string{"robot"},
+		Forms:      map[string]string{"robot": "robot"},
+		Transcript: "Robots and a robot.",
+	}})
+
+	comics := []Comics{{ID: 1}, {ID: 2}}
+	index.annotate(comics, []string{"robot", "come"})
+
+	assert.Equal(t, "transcript", comics[0].Snippet.Field)
+	assert.Equal(t, []string{"Robots", "coming", "ROBOT'S"}, highlighted(comics[0].Snippet))
+
+	// комикс без сохраненных токенов подсвечивает только известную форму
+	assert.Equal(t, []string{"robot"}, highlighted(comics[1].Snippet))
+}
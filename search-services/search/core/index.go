@@ -0,0 +1,652 @@
+package core
+
+import (
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"yadro.com/course/search/core/bitmap"
+)
+
+// Index is an inverted index over comics. Documents are numbered densely in
+// ID order and every term keeps a compressed posting list of those numbers
+// together with the term frequency in each document, so a query can be
+// answered without going back to the database.
+type Index struct {
+	docs  []Document
+	terms map[string]posting
+	// documents having every pair of adjacent words
+	bigrams map[string]*bitmap.Bitmap
+	// documents every speaker has a line in and documents every speaker says
+	// every word in, see spokenKey
+	speakers map[string]*bitmap.Bitmap
+	spoken   map[string]*bitmap.Bitmap
+	// dated documents ordered by publish date for date range filters
+	byDate []uint32
+
+	vocab   []string
+	grams   map[string][]int32
+	lengths map[int][]int32
+
+	forms       map[string]string
+	surface     map[string]string
+	completions []completion
+	speller     *Speller
+
+	generation    uint64
+	checksum      uint64
+	builtAt       time.Time
+	buildDuration time.Duration
+}
+
+type Document struct {
+	ID     int
+	URL    string
+	Length int
+	Date   time.Time
+
+	Title      string
+	Alt        string
+	Transcript string
+	// tokens of the title, the alt text and the transcript as the words
+	// service found them, nil for comics stored without them
+	tokens *[3][]token
+
+	// distinct terms of the comic as positions in the vocabulary with their
+	// frequencies and the length of its TF-IDF vector, used to find similar
+	// comics
+	terms []uint32
+	tfs   []uint16
+	norm  float64
+}
+
+// posting is the documents having a term with its frequency and fields in
+// each of them. Most terms occur once and in the same fields of every
+// document, so freqs is nil if all frequencies are 1 and fields is nil if
+// all documents have the term in the fields of only.
+type posting struct {
+	docs   *bitmap.Bitmap
+	freqs  []uint16
+	fields []Field
+	only   Field
+}
+
+func newPosting(docs []uint32, freqs []uint16, fields []Field) posting {
+	p := posting{docs: bitmap.FromSorted(docs), freqs: slices.Clip(freqs), fields: slices.Clip(fields)}
+	if !slices.ContainsFunc(freqs, func(f uint16) bool { return f != 1 }) {
+		p.freqs = nil
+	}
+	if !slices.ContainsFunc(fields, func(f Field) bool { return f != fields[0] }) {
+		p.fields, p.only = nil, fields[0]
+	}
+	return p
+}
+
+// freq returns the frequency of the term in the nth document of the posting.
+func (p posting) freq(n int) uint16 {
+	if p.freqs == nil {
+		return 1
+	}
+	return p.freqs[n]
+}
+
+// field returns the fields of the nth document of the posting having the term.
+func (p posting) field(n int) Field {
+	if p.fields == nil {
+		return p.only
+	}
+	return p.fields[n]
+}
+
+// completion is a surface word that can be suggested for a typed prefix.
+type completion struct {
+	word string
+	stem string
+}
+
+type hit struct {
+	doc     uint32
+	unique  int
+	phrases int
+	weight  float64
+	total   int
+}
+
+// match is a query term with the documents it matches in the requested fields.
+type match struct {
+	posting
+	docs   *bitmap.Bitmap
+	fields Field
+	// concept numbers the query word the term stands for, boost scales the
+	// weights of synonyms
+	concept int
+	boost   float64
+}
+
+// termCounts returns how many times every word of the comic occurs in its
+// title, alt text and transcript. The stored words are distinct, so only
+// the tokens tell the counts; comics stored without them count every word
+// as often as it is listed.
+func termCounts(comic Comics) map[string]uint16 {
+	occurrences := make(map[string]uint16)
+	for _, tokens := range [][]Token{comic.TitleTokens, comic.AltTokens, comic.TranscriptTokens} {
+		for _, t := range tokens {
+			occurrences[t.Term]++
+		}
+	}
+	counts := make(map[string]uint16, len(comic.Words))
+	for _, word := range comic.Words {
+		if n := occurrences[word]; n > 0 {
+			counts[word] = n
+		} else {
+			counts[word]++
+		}
+	}
+	return counts
+}
+
+func NewIndex(comics []Comics) *Index {
+	sorted := slices.Clone(comics)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	idx := &Index{
+		docs:     make([]Document, 0, len(sorted)),
+		terms:    make(map[string]posting),
+		bigrams:  make(map[string]*bitmap.Bitmap),
+		speakers: make(map[string]*bitmap.Bitmap),
+		spoken:   make(map[string]*bitmap.Bitmap),
+		surface:  make(map[string]string),
+	}
+
+	docIDs := make(map[string][]uint32)
+	bigramDocs := make(map[string][]uint32)
+	speakerDocs := make(map[string][]uint32)
+	spokenDocs := make(map[string][]uint32)
+	freqs := make(map[string][]uint16)
+	fields := make(map[string][]Field)
+	docTerms := make([][]string, len(sorted))
+	formCounts := make(map[string]map[string]int)
+	for _, comic := range sorted {
+		doc := uint32(len(idx.docs))
+		idx.docs = append(idx.docs, Document{
+			ID:     comic.ID,
+			URL:    comic.URL,
+			Length: len(comic.Words),
+			Date:   comic.Date,
+
+			Title:      comic.Title,
+			Alt:        comic.Alt,
+			Transcript: comic.Transcript,
+			tokens:     fieldTokens(comic),
+		})
+
+		counts := termCounts(comic)
+		masks := comicFields(comic)
+		if !comic.Date.IsZero() {
+			idx.byDate = append(idx.byDate, doc)
+		}
+		d := &idx.docs[doc]
+		for word, count := range counts {
+			mask := masks[word]
+			if mask == 0 {
+				mask = FieldTranscript
+			}
+			docIDs[word] = append(docIDs[word], doc)
+			freqs[word] = append(freqs[word], count)
+			fields[word] = append(fields[word], mask)
+			docTerms[doc] = append(docTerms[doc], word)
+			d.tfs = append(d.tfs, count)
+		}
+		for _, bigram := range comic.Bigrams {
+			addDoc(bigramDocs, bigram, doc)
+		}
+		for _, line := range comic.Dialogue {
+			speaker := speakerKey(line.Speaker)
+			if speaker == "" {
+				continue
+			}
+			addDoc(speakerDocs, speaker, doc)
+			for _, word := range line.Words {
+				addDoc(spokenDocs, spokenKey(speaker, word), doc)
+			}
+		}
+		for stem, form := range comic.Forms {
+			if formCounts[stem] == nil {
+				formCounts[stem] = make(map[string]int)
+			}
+			formCounts[stem][form]++
+			idx.surface[form] = stem
+		}
+	}
+
+	for word, ids := range docIDs {
+		idx.terms[word] = newPosting(ids, freqs[word], fields[word])
+		idx.vocab = append(idx.vocab, word)
+	}
+	for bigram, ids := range bigramDocs {
+		idx.bigrams[bigram] = bitmap.FromSorted(ids)
+	}
+	for speaker, ids := range speakerDocs {
+		idx.speakers[speaker] = bitmap.FromSorted(ids)
+	}
+	for key, ids := range spokenDocs {
+		idx.spoken[key] = bitmap.FromSorted(ids)
+	}
+
+	slices.Sort(idx.vocab)
+	slices.SortStableFunc(idx.byDate, func(a, b uint32) int {
+		return idx.docs[a].Date.Compare(idx.docs[b].Date)
+	})
+	positions := make(map[string]uint32, len(idx.vocab))
+	for i, word := range idx.vocab {
+		positions[word] = uint32(i)
+	}
+	for i := range idx.docs {
+		d := &idx.docs[i]
+		d.terms = make([]uint32, len(docTerms[i]))
+		for j, term := range docTerms[i] {
+			d.terms[j] = positions[term]
+			w := tfidf(d.tfs[j], idx.idf(term))
+			d.norm += w * w
+		}
+		d.norm = math.Sqrt(d.norm)
+	}
+
+	idx.grams = make(map[string][]int32)
+	idx.lengths = make(map[int][]int32)
+	for i, word := range idx.vocab {
+		for _, gram := range trigrams(word) {
+			idx.grams[gram] = append(idx.grams[gram], int32(i))
+		}
+		n := utf8.RuneCountInString(word)
+		idx.lengths[n] = append(idx.lengths[n], int32(i))
+	}
+
+	idx.forms = make(map[string]string, len(idx.vocab))
+	idx.completions = make([]completion, 0, len(idx.vocab))
+	for _, stem := range idx.vocab {
+		form := representativeForm(stem, formCounts[stem])
+		idx.forms[stem] = form
+		idx.completions = append(idx.completions, completion{word: form, stem: stem})
+	}
+	slices.SortFunc(idx.completions, func(a, b completion) int {
+		return strings.Compare(a.word, b.word)
+	})
+
+	// слова без сохранённых исходных форм исправляются до основ
+	spelling := make(map[string]int)
+	for _, stem := range idx.vocab {
+		if len(formCounts[stem]) == 0 {
+			spelling[stem] += idx.DocFreq(stem)
+		}
+		for form, count := range formCounts[stem] {
+			spelling[form] += count
+		}
+	}
+	idx.speller = newSpeller(spelling)
+	return idx
+}
+
+// addDoc adds the document to the ones of the key unless it is already the
+// last of them, documents come in ascending order.
+func addDoc(docs map[string][]uint32, key string, doc uint32) {
+	if ids := docs[key]; len(ids) == 0 || ids[len(ids)-1] != doc {
+		docs[key] = append(ids, doc)
+	}
+}
+
+// checksum hashes everything the index is built from, so that a rebuild
+// can tell whether the comics have changed.
+func checksum(comics []Comics) uint64 {
+	h := fnv.New64a()
+	for _, c := range comics {
+		fmt.Fprintln(h, c.ID, c.URL, c.Date.Unix(), c.Title, c.Alt, c.Transcript)
+		fmt.Fprintln(h, c.Words, c.TitleWords, c.AltWords, c.TranscriptWords, c.Bigrams)
+		fmt.Fprintln(h, c.Dialogue, c.DialogueWords, c.SceneWords)
+		for _, stem := range slices.Sorted(maps.Keys(c.Forms)) {
+			fmt.Fprint(h, stem, c.Forms[stem], " ")
+		}
+	}
+	return h.Sum64()
+}
+
+// comicFields returns the fields every word of the comic occurs in. Words
+// of comics stored before fields were split count as transcript ones.
+func comicFields(comic Comics) map[string]Field {
+	masks := make(map[string]Field)
+	for _, f := range []struct {
+		words []string
+		field Field
+	}{
+		{comic.TitleWords, FieldTitle},
+		{comic.AltWords, FieldAlt},
+		{comic.TranscriptWords, FieldTranscript},
+		{comic.DialogueWords, FieldDialogue},
+		{comic.SceneWords, FieldScene},
+	} {
+		for _, word := range f.words {
+			masks[word] |= f.field
+		}
+	}
+	return masks
+}
+
+// representativeForm picks the surface word a stem occurs as in most comics.
+// Comics indexed before surface forms were stored fall back to the stem.
+func representativeForm(stem string, counts map[string]int) string {
+	best, bestCount := stem, 0
+	for form, count := range counts {
+		if count > bestCount || count == bestCount && form < best {
+			best, bestCount = form, count
+		}
+	}
+	return best
+}
+
+func (idx *Index) Len() int {
+	return len(idx.docs)
+}
+
+func (idx *Index) Terms() int {
+	return len(idx.terms)
+}
+
+// Form returns the representative surface word of an indexed stem.
+func (idx *Index) Form(stem string) string {
+	if form, ok := idx.forms[stem]; ok {
+		return form
+	}
+	return stem
+}
+
+// ordinal returns the position of the comic with the given ID in docs.
+func (idx *Index) ordinal(id int) (uint32, bool) {
+	i, ok := slices.BinarySearchFunc(idx.docs, id, func(d Document, id int) int {
+		return d.ID - id
+	})
+	return uint32(i), ok
+}
+
+// neighbours returns IDs of the indexed comics right before and after the
+// given one, which itself does not have to be indexed yet.
+func (idx *Index) neighbours(id int) (prev, next int) {
+	i, ok := slices.BinarySearchFunc(idx.docs, id, func(d Document, id int) int {
+		return d.ID - id
+	})
+	if i > 0 {
+		prev = idx.docs[i-1].ID
+	}
+	if ok {
+		i++
+	}
+	if i < len(idx.docs) {
+		next = idx.docs[i].ID
+	}
+	return prev, next
+}
+
+// random returns the ID of a random indexed comic.
+func (idx *Index) random() (int, bool) {
+	if len(idx.docs) == 0 {
+		return 0, false
+	}
+	return idx.docs[rand.IntN(len(idx.docs))].ID, true
+}
+
+// Suggest returns up to limit surface words starting with prefix, the ones
+// found in more comics first.
+func (idx *Index) Suggest(prefix string, limit int) []Suggestion {
+	from, _ := slices.BinarySearchFunc(idx.completions, prefix, func(c completion, p string) int {
+		return strings.Compare(c.word, p)
+	})
+
+	suggestions := []Suggestion{}
+	for _, c := range idx.completions[from:] {
+		if !strings.HasPrefix(c.word, prefix) {
+			break
+		}
+		suggestions = append(suggestions, Suggestion{Word: c.word, Count: idx.DocFreq(c.stem)})
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b Suggestion) int {
+		return b.Count - a.Count
+	})
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+func (idx *Index) DocFreq(term string) int {
+	p, ok := idx.terms[term]
+	if !ok {
+		return 0
+	}
+	return p.docs.Cardinality()
+}
+
+// Lookup returns IDs of comics containing the term in ascending order.
+func (idx *Index) Lookup(term string) []int {
+	p, ok := idx.terms[term]
+	if !ok {
+		return nil
+	}
+	ids := make([]int, 0, p.docs.Cardinality())
+	p.docs.Each(func(doc uint32) bool {
+		ids = append(ids, idx.docs[doc].ID)
+		return true
+	})
+	return ids
+}
+
+// Search ranks comics by the number of distinct query terms they contain,
+// then by the number of boosted phrases they contain, then by the weights of
+// the fields those terms are found in and then by the total number of
+// occurrences of the terms. A term restricted to some fields matches only
+// comics having it there, required phrases drop comics without them. The
+// filter drops comics published outside its date range and may order hits
+// by date or ID instead. Speakers of the filter drop comics they do not
+// speak in, and terms searched in the dialogue match only in their lines;
+// without terms they find all comics they speak in. It returns at most limit
+// comics (all of them if limit is not positive) and the number of comics
+// matching at least one term.
+func (idx *Index) Search(terms []Term, phrases Phrases, limit int, weights FieldWeights, filter Filter) ([]Comics, int) {
+	masks := make(map[string]Field, len(terms))
+	var words []Term
+	for _, t := range terms {
+		if _, ok := masks[t.Word]; !ok {
+			words = append(words, t)
+		}
+		masks[t.Word] |= t.Fields
+	}
+
+	// синонимы засчитываются как совпадения исходного слова запроса
+	concepts := make(map[string]int, len(words))
+	var matched []match
+	for _, t := range words {
+		p, ok := idx.terms[t.Word]
+		if !ok {
+			continue
+		}
+		m := match{posting: p, docs: p.docs, fields: masks[t.Word], boost: t.boost()}
+		if len(filter.Speakers) > 0 && m.fields&(FieldTranscript|FieldDialogue) != 0 {
+			m.fields = FieldDialogue
+			m.docs = bitmap.And(p.docs, idx.saidBy(t.Word, filter.Speakers))
+		} else if m.fields != AllFields {
+			m.docs = p.restrict(m.fields)
+		}
+		if m.docs.Cardinality() == 0 {
+			continue
+		}
+		c, ok := concepts[t.concept()]
+		if !ok {
+			c = len(concepts)
+			concepts[t.concept()] = c
+		}
+		m.concept = c
+		matched = append(matched, m)
+	}
+	if len(matched) == 0 && (len(terms) > 0 || len(filter.Speakers) == 0) {
+		return []Comics{}, 0
+	}
+
+	var union, inter *bitmap.Bitmap
+	if len(matched) > 0 {
+		union, inter = matched[0].docs, matched[0].docs
+		for _, m := range matched[1:] {
+			union = bitmap.Or(union, m.docs)
+			inter = bitmap.And(inter, m.docs)
+		}
+	}
+	if len(filter.Speakers) > 0 {
+		spoken := idx.speakerDocs(filter.Speakers)
+		if union == nil {
+			union, inter = spoken, spoken
+		}
+		union = bitmap.And(union, spoken)
+		inter = bitmap.And(inter, spoken)
+	}
+	if filter.dated() {
+		dated := idx.dateRange(filter)
+		union = bitmap.And(union, dated)
+		inter = bitmap.And(inter, dated)
+	}
+	for _, bigram := range phrases.Required {
+		docs := idx.phraseDocs(bigram)
+		union = bitmap.And(union, docs)
+		inter = bitmap.And(inter, docs)
+	}
+	total := union.Cardinality()
+
+	var boosts []*bitmap.Bitmap
+	for _, bigram := range phrases.Boost {
+		if docs, ok := idx.bigrams[bigram]; ok {
+			boosts = append(boosts, docs)
+		}
+	}
+
+	// Документы со всеми словами запроса всегда ранжируются выше остальных,
+	// поэтому если их достаточно, остальные кандидаты можно не оценивать.
+	// С синонимами это не так: исходное слово и его синоним - одно совпадение
+	relevance := filter.Sort == "" || filter.Sort == SortRelevance
+	candidates := union
+	if relevance && limit > 0 && len(concepts) == len(matched) && inter.Cardinality() >= limit {
+		candidates = inter
+	}
+
+	hits := make([]hit, 0, candidates.Cardinality())
+	seen := make([]uint32, len(concepts))
+	candidates.Each(func(doc uint32) bool {
+		h := hit{doc: doc}
+		for _, m := range matched {
+			if !m.docs.Contains(doc) {
+				continue
+			}
+			i := m.posting.docs.Rank(doc) - 1
+			if seen[m.concept] != doc+1 {
+				seen[m.concept] = doc + 1
+				h.unique++
+			}
+			h.weight += weights.Of(m.posting.field(i)&m.fields) * m.boost
+			h.total += int(m.posting.freq(i))
+		}
+		for _, docs := range boosts {
+			if docs.Contains(doc) {
+				h.phrases++
+			}
+		}
+		hits = append(hits, h)
+		return true
+	})
+
+	slices.SortStableFunc(hits, func(a, b hit) int {
+		switch filter.Sort {
+		case SortID:
+			return int(a.doc) - int(b.doc)
+		case SortNewest, SortOldest:
+			if c := compareDates(idx.docs[a.doc].Date, idx.docs[b.doc].Date, filter.Sort == SortNewest); c != 0 {
+				return c
+			}
+		}
+		if a.unique != b.unique {
+			return b.unique - a.unique
+		}
+		if a.phrases != b.phrases {
+			return b.phrases - a.phrases
+		}
+		if a.weight != b.weight {
+			if a.weight > b.weight {
+				return -1
+			}
+			return 1
+		}
+		return b.total - a.total
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	comics := make([]Comics, len(hits))
+	for i, h := range hits {
+		doc := idx.docs[h.doc]
+		comics[i] = Comics{ID: doc.ID, URL: doc.URL, Date: doc.Date}
+	}
+	return comics, total
+}
+
+// phraseDocs returns the documents having the bigram.
+func (idx *Index) phraseDocs(bigram string) *bitmap.Bitmap {
+	if docs, ok := idx.bigrams[bigram]; ok {
+		return docs
+	}
+	return bitmap.FromSorted(nil)
+}
+
+// dateRange returns the documents published within the filter dates.
+func (idx *Index) dateRange(filter Filter) *bitmap.Bitmap {
+	from := 0
+	if !filter.From.IsZero() {
+		from = sort.Search(len(idx.byDate), func(i int) bool {
+			return !idx.docs[idx.byDate[i]].Date.Before(filter.From)
+		})
+	}
+	to := len(idx.byDate)
+	if !filter.To.IsZero() {
+		to = sort.Search(len(idx.byDate), func(i int) bool {
+			return idx.docs[idx.byDate[i]].Date.After(filter.To)
+		})
+	}
+	if from >= to {
+		return bitmap.FromSorted(nil)
+	}
+	docs := slices.Clone(idx.byDate[from:to])
+	slices.Sort(docs)
+	return bitmap.FromSorted(docs)
+}
+
+// restrict returns the documents having the term in any of the fields.
+func (p posting) restrict(fields Field) *bitmap.Bitmap {
+	if p.fields == nil {
+		if p.only&fields != 0 {
+			return p.docs
+		}
+		return bitmap.FromSorted(nil)
+	}
+	var docs []uint32
+	n := 0
+	p.docs.Each(func(doc uint32) bool {
+		if p.fields[n]&fields != 0 {
+			docs = append(docs, doc)
+		}
+		n++
+		return true
+	})
+	return bitmap.FromSorted(docs)
+}
@@ -0,0 +1,162 @@
+package core
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"testing"
+)
+
+const (
+	benchComics  = 3000
+	benchVocab   = 12000
+	benchWords   = 80
+	benchQueries = 64
+)
+
+func benchCorpus() ([]Comics, [][]string) {
+	rnd := rand.New(rand.NewSource(42))
+	zipf := rand.NewZipf(rnd, 1.1, 4, benchVocab-1)
+
+	comics := make([]Comics, benchComics)
+	for i := range comics {
+		words := make([]string, benchWords)
+		for j := range words {
+			words[j] = fmt.Sprintf("w%d", zipf.Uint64())
+		}
+		comics[i] = Comics{
+			ID:    i + 1,
+			URL:   fmt.Sprintf("https://imgs.xkcd.com/comics/%d.png", i+1),
+			Words: words,
+		}
+	}
+
+	queries := make([][]string, benchQueries)
+	for i := range queries {
+		queries[i] = []string{
+			fmt.Sprintf("w%d", zipf.Uint64()),
+			fmt.Sprintf("w%d", zipf.Uint64()),
+			fmt.Sprintf("w%d", rnd.Intn(benchVocab)),
+		}
+	}
+	return comics, queries
+}
+
+// heapBytes returns how much the live heap grows by building a value, with
+// garbage collected before and after, so that both indexes are measured the
+// same way.
+func heapBytes[T any](build func() T) (T, int64) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	v := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	return v, int64(after.HeapAlloc) - int64(before.HeapAlloc)
+}
+
+func BenchmarkIndexSearch(b *testing.B) {
+	comics, queries := benchCorpus()
+	index, size := heapBytes(func() *Index { return NewIndex(comics) })
+	terms := make([][]Term, len(queries))
+	for i, query := range queries {
+		for _, word := range query {
+			terms[i] = append(terms[i], Term{Word: word, Fields: AllFields})
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.Search(terms[i%len(terms)], Phrases{}, 10, DefaultWeights, Filter{})
+	}
+	b.ReportMetric(float64(size), "index-bytes")
+}
+
+// BenchmarkMapIndexSearch measures the previous map[string][]int index that
+// had to fetch matched comics from the database and recount words while sorting.
+func BenchmarkMapIndexSearch(b *testing.B) {
+	comics, queries := benchCorpus()
+	index, size := heapBytes(func() map[string][]int {
+		index := make(map[string][]int)
+		for _, comic := range comics {
+			for _, word := range comic.Words {
+				index[word] = append(index[word], comic.ID)
+			}
+		}
+		return index
+	})
+	// the comics were rows of the database, not a part of the index
+	byID := make(map[int]Comics, len(comics))
+	for _, comic := range comics {
+		byID[comic.ID] = comic
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mapIndexSearch(index, byID, queries[i%len(queries)], 10)
+	}
+	b.ReportMetric(float64(size), "index-bytes")
+}
+
+func BenchmarkNewIndex(b *testing.B) {
+	comics, _ := benchCorpus()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewIndex(comics)
+	}
+}
+
+func mapIndexSearch(index map[string][]int, byID map[int]Comics, words []string, limit int) []Comics {
+	idSet := make(map[int]struct{})
+	wordToIds := make(map[string]map[int]struct{})
+	for _, word := range words {
+		if ids, exists := index[word]; exists {
+			wordToIds[word] = make(map[int]struct{})
+			for _, id := range ids {
+				wordToIds[word][id] = struct{}{}
+				idSet[id] = struct{}{}
+			}
+		}
+	}
+
+	// the old implementation loaded these rows with GetComicsByIDs
+	comics := make([]Comics, 0, len(idSet))
+	for id := range idSet {
+		comic := byID[id]
+		comics = append(comics, Comics{ID: comic.ID, URL: comic.URL, Words: append([]string(nil), comic.Words...)})
+	}
+
+	count := func(c Comics) (int, int) {
+		unique, total := 0, 0
+		counts := make(map[string]int)
+		for _, word := range c.Words {
+			counts[word]++
+		}
+		for _, word := range words {
+			if ids, ok := wordToIds[word]; ok {
+				if _, ok := ids[c.ID]; ok {
+					unique++
+					total += counts[word]
+				}
+			}
+		}
+		return unique, total
+	}
+	sort.Slice(comics, func(i, j int) bool {
+		iu, it := count(comics[i])
+		ju, jt := count(comics[j])
+		if iu != ju {
+			return iu > ju
+		}
+		return it > jt
+	})
+	if limit > 0 && len(comics) > limit {
+		comics = comics[:limit]
+	}
+	return comics
+}
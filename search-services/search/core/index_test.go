@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_Suggest(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"program", "python"}, Forms: map[string]string{"program": "programming", "python": "python"}},
+		{ID: 2, Words: []string{"program", "progress"}, Forms: map[string]string{"program": "program", "progress": "progress"}},
+		{ID: 3, Words: []string{"program", "pi"}, Forms: map[string]string{"program": "programming"}},
+	})
+
+	assert.Equal(t, []Suggestion{
+		{Word: "programming", Count: 3},
+		{Word: "progress", Count: 1},
+	}, index.Suggest("prog", 10))
+	assert.Equal(t, []Suggestion{{Word: "programming", Count: 3}}, index.Suggest("pro", 1))
+	assert.Equal(t, []Suggestion{{Word: "pi", Count: 1}}, index.Suggest("pi", 10))
+	assert.Empty(t, index.Suggest("xkcd", 10))
+}
+
+func TestIndex_Form(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"comput"}, Forms: map[string]string{"comput": "computers"}},
+		{ID: 2, Words: []string{"comput"}, Forms: map[string]string{"comput": "computer"}},
+		{ID: 3, Words: []string{"lazi"}},
+	})
+
+	assert.Equal(t, "computer", index.Form("comput"))
+	assert.Equal(t, "lazi", index.Form("lazi"))
+	assert.Equal(t, "unknown", index.Form("unknown"))
+}
+
+func TestIndex_CompactPostings(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"robot", "robot", "cat"}, TitleWords: []string{"robot", "cat"}, TranscriptWords: []string{"robot"}},
+		{ID: 2, Words: []string{"robot", "cat"}, TitleWords: []string{"cat"}, AltWords: []string{"robot"}},
+	})
+
+	// у cat одна частота и одни поля во всех комиксах, у robot - разные
+	cat := index.terms["cat"]
+	assert.Nil(t, cat.freqs)
+	assert.Nil(t, cat.fields)
+	assert.Equal(t, uint16(1), cat.freq(1))
+	assert.Equal(t, FieldTitle, cat.field(1))
+	assert.Equal(t, 2, cat.restrict(FieldTitle).Cardinality())
+	assert.Zero(t, cat.restrict(FieldAlt).Cardinality())
+
+	robot := index.terms["robot"]
+	assert.Equal(t, []uint16{2, 1}, robot.freqs)
+	assert.Equal(t, []Field{FieldTitle | FieldTranscript, FieldAlt}, robot.fields)
+	assert.Equal(t, 1, robot.restrict(FieldAlt).Cardinality())
+}
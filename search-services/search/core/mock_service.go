@@ -7,6 +7,7 @@ package core
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,34 +35,198 @@ func (m *MockSearcher) EXPECT() *MockSearcherMockRecorder {
 	return m.recorder
 }
 
+// Analytics mocks base method.
+func (m *MockSearcher) Analytics(ctx context.Context, window time.Duration, limit int) (Analytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Analytics", ctx, window, limit)
+	ret0, _ := ret[0].(Analytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Analytics indicates an expected call of Analytics.
+func (mr *MockSearcherMockRecorder) Analytics(ctx, window, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Analytics", reflect.TypeOf((*MockSearcher)(nil).Analytics), ctx, window, limit)
+}
+
+// Correct mocks base method.
+func (m *MockSearcher) Correct(ctx context.Context, phrase string) (Corrected, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Correct", ctx, phrase)
+	ret0, _ := ret[0].(Corrected)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Correct indicates an expected call of Correct.
+func (mr *MockSearcherMockRecorder) Correct(ctx, phrase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correct", reflect.TypeOf((*MockSearcher)(nil).Correct), ctx, phrase)
+}
+
+// Generation mocks base method.
+func (m *MockSearcher) Generation(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generation", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generation indicates an expected call of Generation.
+func (mr *MockSearcherMockRecorder) Generation(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generation", reflect.TypeOf((*MockSearcher)(nil).Generation), ctx)
+}
+
+// GetComic mocks base method.
+func (m *MockSearcher) GetComic(ctx context.Context, id int) (ComicDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComic", ctx, id)
+	ret0, _ := ret[0].(ComicDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComic indicates an expected call of GetComic.
+func (mr *MockSearcherMockRecorder) GetComic(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComic", reflect.TypeOf((*MockSearcher)(nil).GetComic), ctx, id)
+}
+
 // IndexSearch mocks base method.
-func (m *MockSearcher) IndexSearch(ctx context.Context, phrase string, limit int) (SearchResult, error) {
+func (m *MockSearcher) IndexSearch(ctx context.Context, phrase string, limit int, opts SearchOptions) (SearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IndexSearch", ctx, phrase, limit)
+	ret := m.ctrl.Call(m, "IndexSearch", ctx, phrase, limit, opts)
 	ret0, _ := ret[0].(SearchResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // IndexSearch indicates an expected call of IndexSearch.
-func (mr *MockSearcherMockRecorder) IndexSearch(ctx, phrase, limit interface{}) *gomock.Call {
+func (mr *MockSearcherMockRecorder) IndexSearch(ctx, phrase, limit, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexSearch", reflect.TypeOf((*MockSearcher)(nil).IndexSearch), ctx, phrase, limit, opts)
+}
+
+// IndexStats mocks base method.
+func (m *MockSearcher) IndexStats(ctx context.Context, limit int) (IndexStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexStats", ctx, limit)
+	ret0, _ := ret[0].(IndexStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexStats indicates an expected call of IndexStats.
+func (mr *MockSearcherMockRecorder) IndexStats(ctx, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexSearch", reflect.TypeOf((*MockSearcher)(nil).IndexSearch), ctx, phrase, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexStats", reflect.TypeOf((*MockSearcher)(nil).IndexStats), ctx, limit)
+}
+
+// RandomComic mocks base method.
+func (m *MockSearcher) RandomComic(ctx context.Context) (ComicDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RandomComic", ctx)
+	ret0, _ := ret[0].(ComicDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RandomComic indicates an expected call of RandomComic.
+func (mr *MockSearcherMockRecorder) RandomComic(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomComic", reflect.TypeOf((*MockSearcher)(nil).RandomComic), ctx)
 }
 
 // Search mocks base method.
-func (m *MockSearcher) Search(ctx context.Context, phrase string, limit int) (SearchResult, error) {
+func (m *MockSearcher) Search(ctx context.Context, phrase string, limit int, opts SearchOptions) (SearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, phrase, limit)
+	ret := m.ctrl.Call(m, "Search", ctx, phrase, limit, opts)
 	ret0, _ := ret[0].(SearchResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockSearcherMockRecorder) Search(ctx, phrase, limit interface{}) *gomock.Call {
+func (mr *MockSearcherMockRecorder) Search(ctx, phrase, limit, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearcher)(nil).Search), ctx, phrase, limit, opts)
+}
+
+// SetSynonyms mocks base method.
+func (m *MockSearcher) SetSynonyms(ctx context.Context, groups [][]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSynonyms", ctx, groups)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSynonyms indicates an expected call of SetSynonyms.
+func (mr *MockSearcherMockRecorder) SetSynonyms(ctx, groups interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSynonyms", reflect.TypeOf((*MockSearcher)(nil).SetSynonyms), ctx, groups)
+}
+
+// Similar mocks base method.
+func (m *MockSearcher) Similar(ctx context.Context, id, limit int) (SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Similar", ctx, id, limit)
+	ret0, _ := ret[0].(SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Similar indicates an expected call of Similar.
+func (mr *MockSearcherMockRecorder) Similar(ctx, id, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Similar", reflect.TypeOf((*MockSearcher)(nil).Similar), ctx, id, limit)
+}
+
+// Suggest mocks base method.
+func (m *MockSearcher) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, prefix, limit)
+	ret0, _ := ret[0].([]Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSearcherMockRecorder) Suggest(ctx, prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSearcher)(nil).Suggest), ctx, prefix, limit)
+}
+
+// Synonyms mocks base method.
+func (m *MockSearcher) Synonyms(ctx context.Context) ([][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Synonyms", ctx)
+	ret0, _ := ret[0].([][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Synonyms indicates an expected call of Synonyms.
+func (mr *MockSearcherMockRecorder) Synonyms(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearcher)(nil).Search), ctx, phrase, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Synonyms", reflect.TypeOf((*MockSearcher)(nil).Synonyms), ctx)
+}
+
+// TermInfo mocks base method.
+func (m *MockSearcher) TermInfo(ctx context.Context, term string, limit int) (TermInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TermInfo", ctx, term, limit)
+	ret0, _ := ret[0].(TermInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TermInfo indicates an expected call of TermInfo.
+func (mr *MockSearcherMockRecorder) TermInfo(ctx, term, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TermInfo", reflect.TypeOf((*MockSearcher)(nil).TermInfo), ctx, term, limit)
 }
 
 // MockIndexer is a mock of Indexer interface.
@@ -102,10 +267,10 @@ func (mr *MockIndexerMockRecorder) BuildIndex(ctx interface{}) *gomock.Call {
 }
 
 // GetIndex mocks base method.
-func (m *MockIndexer) GetIndex(ctx context.Context) Index {
+func (m *MockIndexer) GetIndex(ctx context.Context) *Index {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetIndex", ctx)
-	ret0, _ := ret[0].(Index)
+	ret0, _ := ret[0].(*Index)
 	return ret0
 }
 
@@ -169,18 +334,18 @@ func (mr *MockDBMockRecorder) GetComicsByIDs(ctx, ids interface{}) *gomock.Call
 }
 
 // SearchComics mocks base method.
-func (m *MockDB) SearchComics(ctx context.Context, words []string, limit int) ([]Comics, error) {
+func (m *MockDB) SearchComics(ctx context.Context, terms []Term, limit int, weights FieldWeights, filter Filter) ([]Comics, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchComics", ctx, words, limit)
+	ret := m.ctrl.Call(m, "SearchComics", ctx, terms, limit, weights, filter)
 	ret0, _ := ret[0].([]Comics)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchComics indicates an expected call of SearchComics.
-func (mr *MockDBMockRecorder) SearchComics(ctx, words, limit interface{}) *gomock.Call {
+func (mr *MockDBMockRecorder) SearchComics(ctx, terms, limit, weights, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchComics", reflect.TypeOf((*MockDB)(nil).SearchComics), ctx, words, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchComics", reflect.TypeOf((*MockDB)(nil).SearchComics), ctx, terms, limit, weights, filter)
 }
 
 // Stats mocks base method.
@@ -221,6 +386,21 @@ func (m *MockWords) EXPECT() *MockWordsMockRecorder {
 	return m.recorder
 }
 
+// Bigrams mocks base method.
+func (m *MockWords) Bigrams(ctx context.Context, phrase string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bigrams", ctx, phrase)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bigrams indicates an expected call of Bigrams.
+func (mr *MockWordsMockRecorder) Bigrams(ctx, phrase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bigrams", reflect.TypeOf((*MockWords)(nil).Bigrams), ctx, phrase)
+}
+
 // Norm mocks base method.
 func (m *MockWords) Norm(ctx context.Context, phrase string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -235,3 +415,119 @@ func (mr *MockWordsMockRecorder) Norm(ctx, phrase interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Norm", reflect.TypeOf((*MockWords)(nil).Norm), ctx, phrase)
 }
+
+// Stats mocks base method.
+func (m *MockWords) Stats() WordsClientStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(WordsClientStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockWordsMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockWords)(nil).Stats))
+}
+
+// MockQueryLog is a mock of QueryLog interface.
+type MockQueryLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueryLogMockRecorder
+}
+
+// MockQueryLogMockRecorder is the mock recorder for MockQueryLog.
+type MockQueryLogMockRecorder struct {
+	mock *MockQueryLog
+}
+
+// NewMockQueryLog creates a new mock instance.
+func NewMockQueryLog(ctrl *gomock.Controller) *MockQueryLog {
+	mock := &MockQueryLog{ctrl: ctrl}
+	mock.recorder = &MockQueryLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueryLog) EXPECT() *MockQueryLogMockRecorder {
+	return m.recorder
+}
+
+// Analytics mocks base method.
+func (m *MockQueryLog) Analytics(ctx context.Context, since time.Time, limit int) (Analytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Analytics", ctx, since, limit)
+	ret0, _ := ret[0].(Analytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Analytics indicates an expected call of Analytics.
+func (mr *MockQueryLogMockRecorder) Analytics(ctx, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Analytics", reflect.TypeOf((*MockQueryLog)(nil).Analytics), ctx, since, limit)
+}
+
+// Record mocks base method.
+func (m *MockQueryLog) Record(q Query) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", q)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockQueryLogMockRecorder) Record(q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockQueryLog)(nil).Record), q)
+}
+
+// MockSynonymStore is a mock of SynonymStore interface.
+type MockSynonymStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSynonymStoreMockRecorder
+}
+
+// MockSynonymStoreMockRecorder is the mock recorder for MockSynonymStore.
+type MockSynonymStoreMockRecorder struct {
+	mock *MockSynonymStore
+}
+
+// NewMockSynonymStore creates a new mock instance.
+func NewMockSynonymStore(ctrl *gomock.Controller) *MockSynonymStore {
+	mock := &MockSynonymStore{ctrl: ctrl}
+	mock.recorder = &MockSynonymStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSynonymStore) EXPECT() *MockSynonymStoreMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method.
+func (m *MockSynonymStore) Load() ([][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load")
+	ret0, _ := ret[0].([][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockSynonymStoreMockRecorder) Load() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockSynonymStore)(nil).Load))
+}
+
+// Save mocks base method.
+func (m *MockSynonymStore) Save(groups [][]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", groups)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSynonymStoreMockRecorder) Save(groups interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSynonymStore)(nil).Save), groups)
+}
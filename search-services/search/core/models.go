@@ -1,14 +1,115 @@
 package core
 
+import "time"
+
 type Comics struct {
-	ID    int
-	URL   string
-	Words []string
+	ID         int
+	URL        string
+	Words      []string
+	Forms      map[string]string
+	Title      string
+	Alt        string
+	Transcript string
+	// Date is the publish date, zero if unknown
+	Date time.Time
+
+	// normalized words of every field, empty for comics indexed before
+	// fields were stored
+	TitleWords      []string
+	AltWords        []string
+	TranscriptWords []string
+	// TitleTokens, AltTokens and TranscriptTokens are the words of the fields
+	// where the words service found them, empty for comics stored before
+	// they were kept
+	TitleTokens      []Token
+	AltTokens        []Token
+	TranscriptTokens []Token
+	// Bigrams are the pairs of adjacent words of the comic, empty for comics
+	// indexed before they were stored
+	Bigrams []string
+	// Dialogue is the transcript said by the characters, Speakers are the
+	// distinct speakers in the order of their first lines, Scenes are the
+	// scene descriptions, DialogueWords and SceneWords are the normalized
+	// words of the dialogue and of the scenes, all empty for comics stored
+	// before transcripts were parsed
+	Dialogue      []Line
+	Speakers      []string
+	Scenes        []string
+	DialogueWords []string
+	SceneWords    []string
+
+	// Score is the cosine similarity to the source comic of a Similar request
+	Score float64
+
+	// Terms and Snippet explain a search hit: the matched query words and
+	// a piece of the comic text with those words highlighted
+	Terms   []string
+	Snippet *Snippet
+	// Explanation is set only for searches with SearchOptions.Explain
+	Explanation *Explanation
+}
+
+// Token is a normalized word of a comic field: its bytes in the text and
+// the term it was normalized to.
+type Token struct {
+	Start int
+	End   int
+	Term  string
+}
+
+// Line is a line of dialogue with its speaker as the transcript names them.
+type Line struct {
+	Speaker string
+	Text    string
+	Words   []string
+}
+
+// ComicDetail is everything stored about a comic together with the IDs of
+// the previous and the next indexed comics, zero at the ends.
+type ComicDetail struct {
+	Comics
+	Prev int
+	Next int
+}
+
+type Snippet struct {
+	Field      string
+	Text       string
+	Highlights []Span
+}
+
+// Span is a half-open range of byte offsets in a snippet text.
+type Span struct {
+	Start int
+	End   int
+}
+
+type Suggestion struct {
+	Word  string
+	Count int
+}
+
+type SearchOptions struct {
+	// Fuzzy включает поиск по ближайшим словам индекса для неизвестных слов запроса
+	Fuzzy bool
+	// Weights переопределяет веса полей (title, alt, transcript) для запроса
+	Weights map[string]float64
+	// Filter ограничивает даты публикации и задаёт порядок результатов
+	Filter
+	// Explain добавляет к результатам объяснение ранжирования
+	Explain bool
+	// Mode помечает запрос в журнале запросов, например ModeDetect для
+	// поиска по распознанному тексту картинки
+	Mode string
 }
 
 type SearchResult struct {
-	Comics []Comics
-	Total  int
+	Comics     []Comics
+	Total      int
+	Suggestion string
+	Explain    *QueryExplanation
+	// Generation is the index generation the result was computed on
+	Generation uint64
 }
 
 type DBStats struct {
@@ -16,5 +117,3 @@ type DBStats struct {
 	WordsUnique   int
 	ComicsFetched int
 }
-
-type Index map[string][]int
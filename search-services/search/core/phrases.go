@@ -0,0 +1,43 @@
+package core
+
+import (
+	"slices"
+	"strings"
+)
+
+// Phrases are the word bigrams of a query. A comic having more of the Boost
+// ones ranks above others matching as many query words, the Required ones
+// come from quoted parts of the query and a comic must have all of them.
+type Phrases struct {
+	Boost    []string
+	Required []string
+}
+
+// quoted returns the parts of the phrase in double quotes. A quote left
+// open runs to the end of the phrase.
+func quoted(phrase string) []string {
+	var parts []string
+	for {
+		_, rest, ok := strings.Cut(phrase, `"`)
+		if !ok {
+			return parts
+		}
+		part, tail, _ := strings.Cut(rest, `"`)
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+		phrase = tail
+	}
+}
+
+// require adds bigrams of a quoted part, which are boosted as well.
+func (p *Phrases) require(bigrams []string) {
+	for _, bigram := range bigrams {
+		if !slices.Contains(p.Required, bigram) {
+			p.Required = append(p.Required, bigram)
+		}
+		if !slices.Contains(p.Boost, bigram) {
+			p.Boost = append(p.Boost, bigram)
+		}
+	}
+}
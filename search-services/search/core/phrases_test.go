@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoted(t *testing.T) {
+	assert.Empty(t, quoted("black hat"))
+	assert.Equal(t, []string{"black hat"}, quoted(`"black hat" guy`))
+	assert.Equal(t, []string{"sudo make", "sandwich"}, quoted(`"sudo make" me a " sandwich`))
+	assert.Empty(t, quoted(`"" ""`))
+}
+
+func TestIndex_SearchPhrases(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"black", "hat", "white"}, Bigrams: []string{"white black", "white hat"}},
+		{ID: 2, Words: []string{"black", "hat"}, Bigrams: []string{"black hat"}},
+		{ID: 3, Words: []string{"hat"}},
+	})
+	terms := []Term{{Word: "black", Fields: AllFields}, {Word: "hat", Fields: AllFields}}
+	ids := func(comics []Comics) []int {
+		var res []int
+		for _, c := range comics {
+			res = append(res, c.ID)
+		}
+		return res
+	}
+
+	t.Run("adjacent words first", func(t *testing.T) {
+		comics, total := index.Search(terms, Phrases{Boost: []string{"black hat"}}, 10, DefaultWeights, Filter{})
+		assert.Equal(t, []int{2, 1, 3}, ids(comics))
+		assert.Equal(t, 3, total)
+	})
+
+	t.Run("quoted phrase", func(t *testing.T) {
+		var phrases Phrases
+		phrases.require([]string{"black hat"})
+		comics, total := index.Search(terms, phrases, 10, DefaultWeights, Filter{})
+		assert.Equal(t, []int{2}, ids(comics))
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("unknown quoted phrase", func(t *testing.T) {
+		comics, total := index.Search(terms, Phrases{Required: []string{"hat black"}}, 10, DefaultWeights, Filter{})
+		assert.Empty(t, comics)
+		assert.Equal(t, 0, total)
+	})
+}
@@ -1,19 +1,35 @@
 package core
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Searcher interface {
-	Search(ctx context.Context, phrase string, limit int) (SearchResult, error)
-	IndexSearch(ctx context.Context, phrase string, limit int) (SearchResult, error)
+	Search(ctx context.Context, phrase string, limit int, opts SearchOptions) (SearchResult, error)
+	IndexSearch(ctx context.Context, phrase string, limit int, opts SearchOptions) (SearchResult, error)
+	Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+	Similar(ctx context.Context, id int, limit int) (SearchResult, error)
+	GetComic(ctx context.Context, id int) (ComicDetail, error)
+	RandomComic(ctx context.Context) (ComicDetail, error)
+	IndexStats(ctx context.Context, limit int) (IndexStats, error)
+	Generation(ctx context.Context) (uint64, error)
+	TermInfo(ctx context.Context, term string, limit int) (TermInfo, error)
+	Analytics(ctx context.Context, window time.Duration, limit int) (Analytics, error)
+	Synonyms(ctx context.Context) ([][]string, error)
+	SetSynonyms(ctx context.Context, groups [][]string) error
+	Correct(ctx context.Context, phrase string) (Corrected, error)
 }
 
 type Indexer interface {
 	BuildIndex(ctx context.Context) error
-	GetIndex(ctx context.Context) Index
+	GetIndex(ctx context.Context) *Index
 }
 
 type DB interface {
-	SearchComics(ctx context.Context, words []string, limit int) ([]Comics, error)
+	// SearchComics returns the matched comics with the Explanation numbers
+	// they were ordered by, without the terms.
+	SearchComics(ctx context.Context, terms []Term, limit int, weights FieldWeights, filter Filter) ([]Comics, error)
 	AllComics(ctx context.Context) ([]Comics, error)
 	Stats(ctx context.Context) (DBStats, error)
 	GetComicsByIDs(ctx context.Context, ids []int) ([]Comics, error)
@@ -21,4 +37,18 @@ type DB interface {
 
 type Words interface {
 	Norm(ctx context.Context, phrase string) ([]string, error)
+	Bigrams(ctx context.Context, phrase string) ([]string, error)
+	Stats() WordsClientStats
+}
+
+// QueryLog records served queries. Record must not block the search.
+type QueryLog interface {
+	Record(q Query)
+	Analytics(ctx context.Context, since time.Time, limit int) (Analytics, error)
+}
+
+// SynonymStore keeps the groups of synonyms.
+type SynonymStore interface {
+	Load() ([][]string, error)
+	Save(groups [][]string) error
 }
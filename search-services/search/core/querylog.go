@@ -0,0 +1,75 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Modes of logged queries.
+const (
+	ModeSearch      = "search"
+	ModeIndexSearch = "isearch"
+	ModeDetect      = "detect"
+)
+
+// queryMode returns the mode a query is logged with, the one of the search
+// path unless the options set another.
+func queryMode(opts SearchOptions, path string) string {
+	if opts.Mode != "" {
+		return opts.Mode
+	}
+	return path
+}
+
+// Query is a served search as it is kept in the query log.
+type Query struct {
+	Time   time.Time
+	Phrase string
+	// Terms are the normalized words the phrase was searched by
+	Terms   []string
+	Mode    string
+	Total   int
+	Latency time.Duration
+}
+
+// Analytics summarizes the queries logged since the given time.
+type Analytics struct {
+	Since   time.Time
+	Queries int
+	// TopQueries are the most frequent phrases, ZeroResults the most
+	// frequent of those that found nothing
+	TopQueries  []QueryCount
+	ZeroResults []QueryCount
+	Latency     []LatencyStats
+}
+
+type QueryCount struct {
+	Phrase string
+	Count  int
+}
+
+// LatencyStats are the latency percentiles of queries of one mode.
+type LatencyStats struct {
+	Mode  string
+	Count int
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+}
+
+// Analytics summarizes the queries of the last window with up to limit top
+// and zero result phrases.
+func (s *Service) Analytics(ctx context.Context, window time.Duration, limit int) (Analytics, error) {
+	if window <= 0 || limit < 0 {
+		return Analytics{}, ErrBadArguments
+	}
+	if s.queries == nil {
+		return Analytics{}, ErrNotFound
+	}
+	analytics, err := s.queries.Analytics(ctx, time.Now().Add(-window), limit)
+	if err != nil {
+		return Analytics{}, fmt.Errorf("failed to get analytics: %w", err)
+	}
+	return analytics, nil
+}
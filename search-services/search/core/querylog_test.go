@@ -0,0 +1,116 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_RecordQueries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := NewMockDB(ctrl)
+	mockWords := NewMockWords(ctrl)
+	mockLog := NewMockQueryLog(ctrl)
+	service, _ := NewService(slog.Default(), mockDB, mockWords, DefaultWeights, 10, mockLog, nil)
+
+	mockDB.EXPECT().AllComics(gomock.Any()).Return([]Comics{{ID: 1, URL: "u1", Words: []string{"robot"}}}, nil)
+	require.NoError(t, service.BuildIndex(context.Background()))
+
+	var queries []Query
+	mockLog.EXPECT().Record(gomock.Any()).Do(func(q Query) {
+		queries = append(queries, q)
+	}).Times(4)
+
+	mockWords.EXPECT().Norm(gomock.Any(), "robots").Return([]string{"robot"}, nil).Times(2)
+	_, err := service.IndexSearch(context.Background(), "robots", 10, SearchOptions{})
+	require.NoError(t, err)
+	// кешированный результат тоже попадает в журнал
+	_, err = service.IndexSearch(context.Background(), "robots", 10, SearchOptions{Mode: ModeDetect})
+	require.NoError(t, err)
+
+	mockWords.EXPECT().Norm(gomock.Any(), "laser").Return([]string{"laser"}, nil)
+	_, err = service.IndexSearch(context.Background(), "title:laser", 10, SearchOptions{})
+	require.NoError(t, err)
+
+	mockWords.EXPECT().Norm(gomock.Any(), "robot").Return([]string{"robot"}, nil)
+	mockDB.EXPECT().SearchComics(gomock.Any(), gomock.Any(), 10, gomock.Any(), gomock.Any()).
+		Return([]Comics{{ID: 1}}, nil)
+	_, err = service.Search(context.Background(), "robot", 10, SearchOptions{Mode: ModeDetect})
+	require.NoError(t, err)
+
+	require.Len(t, queries, 4)
+	assert.Equal(t, "robots", queries[0].Phrase)
+	assert.Equal(t, []string{"robot"}, queries[0].Terms)
+	assert.Equal(t, ModeIndexSearch, queries[0].Mode)
+	assert.Equal(t, 1, queries[0].Total)
+	assert.False(t, queries[0].Time.IsZero())
+
+	assert.Equal(t, ModeDetect, queries[1].Mode)
+	assert.Equal(t, []string{"robot"}, queries[1].Terms)
+
+	assert.Equal(t, []string{"title:laser"}, queries[2].Terms)
+	assert.Equal(t, 0, queries[2].Total)
+
+	assert.Equal(t, ModeDetect, queries[3].Mode)
+	assert.Equal(t, 1, queries[3].Total)
+}
+
+func TestService_RecordQueries_Failed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWords := NewMockWords(ctrl)
+	mockLog := NewMockQueryLog(ctrl)
+	service, _ := NewService(slog.Default(), NewMockDB(ctrl), mockWords, DefaultWeights, 0, mockLog, nil)
+
+	mockWords.EXPECT().Norm(gomock.Any(), "robot").Return(nil, errors.New("words down"))
+	mockLog.EXPECT().Record(gomock.Any()).Times(0)
+
+	_, err := service.IndexSearch(context.Background(), "robot", 10, SearchOptions{})
+	assert.ErrorContains(t, err, "normalization failed")
+}
+
+func TestService_Analytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := NewMockQueryLog(ctrl)
+	service, _ := NewService(slog.Default(), NewMockDB(ctrl), NewMockWords(ctrl), DefaultWeights, 0, mockLog, nil)
+
+	t.Run("success", func(t *testing.T) {
+		expected := Analytics{Queries: 3, TopQueries: []QueryCount{{Phrase: "robot", Count: 2}}}
+		mockLog.EXPECT().Analytics(gomock.Any(), gomock.Any(), 5).
+			DoAndReturn(func(_ context.Context, since time.Time, _ int) (Analytics, error) {
+				assert.WithinDuration(t, time.Now().Add(-time.Hour), since, time.Minute)
+				return expected, nil
+			})
+		analytics, err := service.Analytics(context.Background(), time.Hour, 5)
+		require.NoError(t, err)
+		assert.Equal(t, expected, analytics)
+	})
+
+	t.Run("bad window", func(t *testing.T) {
+		_, err := service.Analytics(context.Background(), 0, 5)
+		assert.ErrorIs(t, err, ErrBadArguments)
+	})
+
+	t.Run("log error", func(t *testing.T) {
+		mockLog.EXPECT().Analytics(gomock.Any(), gomock.Any(), 5).Return(Analytics{}, errors.New("db down"))
+		_, err := service.Analytics(context.Background(), time.Hour, 5)
+		assert.ErrorContains(t, err, "db down")
+	})
+
+	t.Run("no query log", func(t *testing.T) {
+		service, _ := NewService(slog.Default(), NewMockDB(ctrl), NewMockWords(ctrl), DefaultWeights, 0, nil, nil)
+		_, err := service.Analytics(context.Background(), time.Hour, 5)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -4,148 +4,415 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Service struct {
-	log   *slog.Logger
-	db    DB
-	words Words
-	index Index
-	mu    sync.RWMutex
+	log     *slog.Logger
+	db      DB
+	words   Words
+	weights FieldWeights
+	// index is the current snapshot, never modified after it is stored;
+	// a query loads it once and uses it to the end
+	index atomic.Pointer[Index]
+	// build serializes index builds, so that a slow build cannot replace
+	// the result of a later one with older comics
+	build    sync.Mutex
+	cache    *resultCache
+	queries  QueryLog
+	synonyms *Synonyms
 }
 
-func NewService(log *slog.Logger, db DB, words Words) (*Service, error) {
-	return &Service{
-		log:   log,
-		db:    db,
-		words: words,
-		index: make(Index),
-	}, nil
+// NewService creates the service keeping up to cacheSize latest search
+// results, zero disables the cache. Queries are recorded to the query log
+// and expanded with the synonyms unless they are nil.
+func NewService(log *slog.Logger, db DB, words Words, weights FieldWeights, cacheSize int, queries QueryLog, synonyms *Synonyms) (*Service, error) {
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+	s := &Service{
+		log:      log,
+		db:       db,
+		words:    words,
+		weights:  weights,
+		cache:    newResultCache(cacheSize),
+		queries:  queries,
+		synonyms: synonyms,
+	}
+	s.index.Store(NewIndex(nil))
+	return s, nil
 }
 
-func (s *Service) Search(ctx context.Context, phrase string, limit int) (SearchResult, error) {
-	words, err := s.words.Norm(ctx, phrase)
+func (s *Service) Search(ctx context.Context, phrase string, limit int, opts SearchOptions) (SearchResult, error) {
+	start := time.Now()
+	found, err := s.search(ctx, PathDB, phrase, limit, opts)
 	if err != nil {
-		return SearchResult{}, fmt.Errorf("normalization failed: %w", err)
+		return SearchResult{}, err
 	}
+	s.record(phrase, found, queryMode(opts, ModeSearch), start)
+	return found.result, nil
+}
 
-	allComics, err := s.db.SearchComics(ctx, words, limit)
+func (s *Service) IndexSearch(ctx context.Context, phrase string, limit int, opts SearchOptions) (SearchResult, error) {
+	start := time.Now()
+	found, err := s.search(ctx, PathIndex, phrase, limit, opts)
 	if err != nil {
-		return SearchResult{}, fmt.Errorf("db search failed: %w", err)
-	}
-
-	resultComics := allComics
-	if limit > 0 && len(allComics) > limit {
-		resultComics = allComics[:limit]
+		return SearchResult{}, err
 	}
+	s.record(phrase, found, queryMode(opts, ModeIndexSearch), start)
+	return found.result, nil
+}
 
-	return SearchResult{
-		Comics: resultComics,
-		Total:  len(allComics),
-	}, nil
+// search is a computed search with the normalized query it ran with.
+type search struct {
+	result SearchResult
+	terms  []Term
 }
 
-func (s *Service) IndexSearch(ctx context.Context, phrase string, limit int) (SearchResult, error) {
-	words, err := s.words.Norm(ctx, phrase)
+// search runs the query in the database or in the index. Results of the
+// database are cached until the next index rebuild too, since that is when
+// changes of comics are noticed.
+func (s *Service) search(ctx context.Context, path, phrase string, limit int, opts SearchOptions) (search, error) {
+	weights, err := s.weights.Override(opts.Weights)
 	if err != nil {
-		return SearchResult{}, err
+		return search{}, err
+	}
+	if err := opts.Filter.Validate(); err != nil {
+		return search{}, err
 	}
 
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	index := s.GetIndex(ctx)
 
-	idSet := make(map[int]struct{})
-	wordToIds := make(map[string]map[int]struct{})
+	// говорящие ограничивают комиксы, а не нормализуются как слова
+	phrase, speakers := parseSpeakers(phrase)
+	filter := opts.Filter
+	filter.Speakers = speakers
 
-	for _, word := range words {
-		if ids, exists := s.index[word]; exists {
-			wordToIds[word] = make(map[int]struct{})
-			for _, id := range ids {
-				wordToIds[word][id] = struct{}{}
-				idSet[id] = struct{}{}
+	terms, err := s.terms(ctx, phrase)
+	if err != nil {
+		return search{}, fmt.Errorf("normalization failed: %w", err)
+	}
+	var phrases Phrases
+	if path != PathDB {
+		if phrases, err = s.phrases(ctx, phrase); err != nil {
+			return search{}, fmt.Errorf("normalization failed: %w", err)
+		}
+	}
+	key := cacheKey(path, terms, phrases, speakers, limit, opts)
+	if found, ok := s.cache.get(index.generation, key); ok {
+		return found, nil
+	}
+
+	terms, suggestion := index.correct(terms, opts.Fuzzy)
+	if s.synonyms != nil {
+		if terms, err = s.synonyms.expand(ctx, s.words, terms); err != nil {
+			s.log.Warn("searching without synonyms", "error", err)
+		}
+	}
+
+	var comics []Comics
+	var total int
+	if path == PathDB {
+		comics, err = s.db.SearchComics(ctx, terms, limit, weights, filter)
+		if err != nil {
+			return search{}, fmt.Errorf("db search failed: %w", err)
+		}
+		total = len(comics)
+		if limit > 0 && len(comics) > limit {
+			comics = comics[:limit]
+		}
+		if !opts.Explain {
+			for i := range comics {
+				comics[i].Explanation = nil
 			}
 		}
+	} else {
+		comics, total = index.Search(terms, phrases, limit, weights, filter)
+	}
+	index.annotate(comics, termWords(terms))
+
+	result := SearchResult{
+		Comics:     comics,
+		Total:      total,
+		Suggestion: suggestion,
+		Generation: index.generation,
+	}
+	if opts.Explain {
+		// в базе говорящие отбирают комиксы целиком, слова ищутся во всём тексте
+		if path == PathDB {
+			speakers = nil
+		}
+		result.Explain = index.explain(path, terms, phrases, speakers, comics, weights)
+	}
+	found := search{result: result, terms: terms}
+	s.cache.put(index.generation, key, found)
+	return found, nil
+}
+
+// record passes the query to the query log, if there is one.
+func (s *Service) record(phrase string, found search, mode string, start time.Time) {
+	if s.queries == nil {
+		return
+	}
+	s.queries.Record(Query{
+		Time:    start,
+		Phrase:  phrase,
+		Terms:   queryTerms(found.terms),
+		Mode:    mode,
+		Total:   found.result.Total,
+		Latency: time.Since(start),
+	})
+}
+
+// terms normalizes the phrase. Words of field-qualified parts like
+// title:robot are searched only in that field, the rest in all fields.
+func (s *Service) terms(ctx context.Context, phrase string) ([]Term, error) {
+	plain, scoped := parseQuery(phrase)
+
+	var terms []Term
+	if scoped == nil || strings.TrimSpace(plain) != "" {
+		words, err := s.words.Norm(ctx, plain)
+		if err != nil {
+			return nil, err
+		}
+		for _, word := range words {
+			terms = append(terms, Term{Word: word, Fields: AllFields})
+		}
+	}
+
+	for _, f := range fieldNames {
+		values, ok := scoped[f.field]
+		if !ok {
+			continue
+		}
+		words, err := s.words.Norm(ctx, strings.Join(values, " "))
+		if err != nil {
+			return nil, err
+		}
+		for _, word := range words {
+			terms = append(terms, Term{Word: word, Fields: f.field})
+		}
+	}
+	return terms, nil
+}
+
+// phrases returns the bigrams of the words searched in all fields and of the
+// quoted parts of the phrase. Only the index keeps bigrams, so the database
+// search goes without them.
+func (s *Service) phrases(ctx context.Context, phrase string) (Phrases, error) {
+	var phrases Phrases
+	plain, _ := parseQuery(phrase)
+	if len(strings.Fields(plain)) > 1 {
+		bigrams, err := s.words.Bigrams(ctx, plain)
+		if err != nil {
+			return Phrases{}, err
+		}
+		phrases.Boost = bigrams
+	}
+	for _, part := range quoted(phrase) {
+		if len(strings.Fields(part)) < 2 {
+			continue
+		}
+		bigrams, err := s.words.Bigrams(ctx, part)
+		if err != nil {
+			return Phrases{}, err
+		}
+		phrases.require(bigrams)
 	}
+	return phrases, nil
+}
 
-	ids := make([]int, 0, len(idSet))
-	for id := range idSet {
-		ids = append(ids, id)
+func (s *Service) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if prefix == "" {
+		return nil, ErrBadArguments
 	}
+	return s.GetIndex(ctx).Suggest(prefix, limit), nil
+}
 
-	comics, err := s.db.GetComicsByIDs(ctx, ids)
+func (s *Service) Similar(ctx context.Context, id int, limit int) (SearchResult, error) {
+	if id <= 0 {
+		return SearchResult{}, ErrBadArguments
+	}
+	index := s.GetIndex(ctx)
+	comics, total, err := index.Similar(id, limit)
 	if err != nil {
 		return SearchResult{}, err
 	}
+	return SearchResult{Comics: comics, Total: total, Generation: index.generation}, nil
+}
 
-	sort.Slice(comics, func(i, j int) bool {
-		iUnique, iTotal := countMatches(comics[i].ID, comics[i].Words, words, wordToIds)
-		jUnique, jTotal := countMatches(comics[j].ID, comics[j].Words, words, wordToIds)
+func (s *Service) GetComic(ctx context.Context, id int) (ComicDetail, error) {
+	if id <= 0 {
+		return ComicDetail{}, ErrBadArguments
+	}
+	comics, err := s.db.GetComicsByIDs(ctx, []int{id})
+	if err != nil {
+		return ComicDetail{}, fmt.Errorf("failed to get comic %d: %w", id, err)
+	}
+	if len(comics) == 0 {
+		return ComicDetail{}, ErrNotFound
+	}
+	prev, next := s.GetIndex(ctx).neighbours(id)
+	return ComicDetail{Comics: comics[0], Prev: prev, Next: next}, nil
+}
 
-		// Сначала комиксы с большим количеством уникальных совпадений
-		if iUnique != jUnique {
-			return iUnique > jUnique
+// RandomComic picks a comic among the indexed ones.
+func (s *Service) RandomComic(ctx context.Context) (ComicDetail, error) {
+	id, ok := s.GetIndex(ctx).random()
+	if !ok {
+		return ComicDetail{}, ErrNotFound
+	}
+	return s.GetComic(ctx, id)
+}
+
+func (s *Service) IndexStats(ctx context.Context, limit int) (IndexStats, error) {
+	stats := s.GetIndex(ctx).Stats(limit)
+	stats.Cache = s.cache.stats()
+	stats.WordsClient = s.words.Stats()
+	return stats, nil
+}
+
+// Generation returns the generation of the index serving queries, zero
+// before it is built.
+func (s *Service) Generation(ctx context.Context) (uint64, error) {
+	return s.GetIndex(ctx).generation, nil
+}
+
+// TermInfo returns the posting list of a term given either as an indexed
+// stem or as a word that normalizes to one.
+func (s *Service) TermInfo(ctx context.Context, term string, limit int) (TermInfo, error) {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" {
+		return TermInfo{}, ErrBadArguments
+	}
+
+	index := s.GetIndex(ctx)
+	if info, ok := index.Term(term, limit); ok {
+		return info, nil
+	}
+	if stem, ok := index.surface[term]; ok {
+		if info, ok := index.Term(stem, limit); ok {
+			return info, nil
 		}
-		// Затем по общему количеству совпадений
-		return iTotal > jTotal
-	})
+	}
+
+	words, err := s.words.Norm(ctx, term)
+	if err != nil {
+		return TermInfo{}, fmt.Errorf("normalization failed: %w", err)
+	}
+	if len(words) == 1 {
+		if info, ok := index.Term(words[0], limit); ok {
+			return info, nil
+		}
+	}
+	return TermInfo{}, ErrNotFound
+}
 
-	if limit > 0 && len(comics) > limit {
-		comics = comics[:limit]
+func (s *Service) Synonyms(ctx context.Context) ([][]string, error) {
+	if s.synonyms == nil {
+		return nil, ErrNotFound
 	}
+	return s.synonyms.Groups(), nil
+}
 
-	return SearchResult{Comics: comics, Total: len(comics)}, nil
+func (s *Service) SetSynonyms(ctx context.Context, groups [][]string) error {
+	if s.synonyms == nil {
+		return ErrNotFound
+	}
+	if err := s.synonyms.Set(ctx, s.words, groups); err != nil {
+		return err
+	}
+	s.refresh("synonyms updated")
+	return nil
 }
 
-func countMatches(id int, comicWords, searchWords []string, wordToIds map[string]map[int]struct{}) (int, int) {
-	uniqueMatches := 0
-	totalMatches := 0
+// ReloadSynonyms reads the synonyms again, e.g. after the file was edited.
+func (s *Service) ReloadSynonyms(ctx context.Context) error {
+	if s.synonyms == nil {
+		return ErrNotFound
+	}
+	if err := s.synonyms.Load(); err != nil {
+		return err
+	}
+	s.refresh("synonyms reloaded")
+	return nil
+}
 
-	comicWordCount := make(map[string]int)
-	for _, word := range comicWords {
-		comicWordCount[word]++
+// WordsChanged starts a new generation of the index after the words service
+// changed its dictionaries or config, as the cached results and the stems of
+// the synonyms were computed with the old normalization.
+func (s *Service) WordsChanged() {
+	if s.synonyms != nil {
+		s.synonyms.invalidate()
 	}
+	s.refresh("words version changed")
+}
 
-	for _, word := range searchWords {
-		if ids, exists := wordToIds[word]; exists {
-			if _, hasWord := ids[id]; hasWord {
-				uniqueMatches++
-				totalMatches += comicWordCount[word]
-			}
+// refresh starts a new generation of the current index, since results
+// computed before a change of the search settings are no longer valid.
+// This drops the cached results and the ETags of the gateway.
+func (s *Service) refresh(reason string) {
+	index := s.swap(func(current *Index) *Index {
+		index := *current
+		return &index
+	})
+	s.log.Info("Index generation increased", "generation", index.generation, "reason", reason)
+}
+
+// swap stores the index made by next from the current one under the next
+// generation. If another swap happens in between, next is called again for
+// the newer index, so no change is lost and generations never repeat.
+func (s *Service) swap(next func(current *Index) *Index) *Index {
+	for {
+		current := s.index.Load()
+		index := next(current)
+		index.generation = current.generation + 1
+		if s.index.CompareAndSwap(current, index) {
+			return index
 		}
 	}
-
-	return uniqueMatches, totalMatches
 }
 
-func (s *Service) GetIndex(ctx context.Context) Index {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.index
+// GetIndex returns the current snapshot of the index. It does not change
+// while in use, rebuilds replace it with a new one.
+func (s *Service) GetIndex(ctx context.Context) *Index {
+	return s.index.Load()
 }
 
 func (s *Service) BuildIndex(ctx context.Context) error {
+	s.build.Lock()
+	defer s.build.Unlock()
+
+	start := time.Now()
 	comics, err := s.db.AllComics(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get comics: %w", err)
 	}
 
-	newIndex := make(Index)
-	for _, comic := range comics {
-		for _, word := range comic.Words {
-			newIndex[word] = append(newIndex[word], comic.ID)
-		}
+	// Новое поколение нужно только при изменении комиксов, иначе
+	// кеш результатов и ETag шлюза сбрасывались бы каждые index_ttl
+	sum := checksum(comics)
+	if sum == s.GetIndex(ctx).checksum {
+		s.log.Debug("Index is up to date", "total_comics", len(comics))
+		return nil
 	}
 
-	s.mu.RLock()
-	s.index = newIndex
-	s.mu.RUnlock()
+	newIndex := NewIndex(comics)
+	newIndex.checksum = sum
+	newIndex.builtAt = time.Now()
+	newIndex.buildDuration = newIndex.builtAt.Sub(start)
 
-	s.log.Info("Index rebuilt",
-		"total_comics", len(comics),
-		"unique_words", len(newIndex))
+	s.swap(func(*Index) *Index { return newIndex })
 
+	s.log.Info("Index rebuilt",
+		"generation", newIndex.generation,
+		"total_comics", newIndex.Len(),
+		"unique_words", newIndex.Terms(),
+		"took", newIndex.buildDuration)
 	return nil
 }
 
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewService(t *testing.T) {
@@ -19,7 +23,7 @@ func TestNewService(t *testing.T) {
 	logger := slog.Default()
 
 	t.Run("successful creation", func(t *testing.T) {
-		service, err := NewService(logger, mockDB, mockWords)
+		service, err := NewService(logger, mockDB, mockWords, DefaultWeights, 0, nil, nil)
 		assert.NoError(t, err)
 		assert.NotNil(t, service)
 		assert.Equal(t, mockDB, service.db)
@@ -34,7 +38,7 @@ func TestService_Search(t *testing.T) {
 	mockDB := NewMockDB(ctrl)
 	mockWords := NewMockWords(ctrl)
 	logger := slog.Default()
-	service, _ := NewService(logger, mockDB, mockWords)
+	service, _ := NewService(logger, mockDB, mockWords, DefaultWeights, 0, nil, nil)
 
 	t.Run("successful search", func(t *testing.T) {
 		expectedWords := []string{"test", "phrase"}
@@ -48,10 +52,10 @@ func TestService_Search(t *testing.T) {
 			Return(expectedWords, nil)
 
 		mockDB.EXPECT().
-			SearchComics(gomock.Any(), expectedWords, 10).
+			SearchComics(gomock.Any(), []Term{{Word: "test", Fields: AllFields}, {Word: "phrase", Fields: AllFields}}, 10, DefaultWeights, Filter{}).
 			Return(expectedComics, nil)
 
-		result, err := service.Search(context.Background(), "test phrase", 10)
+		result, err := service.Search(context.Background(), "test phrase", 10, SearchOptions{})
 		assert.NoError(t, err)
 		assert.Equal(t, expectedComics, result.Comics)
 		assert.Equal(t, 2, result.Total)
@@ -62,7 +66,7 @@ func TestService_Search(t *testing.T) {
 			Norm(gomock.Any(), "error phrase").
 			Return(nil, errors.New("normalization error"))
 
-		_, err := service.Search(context.Background(), "error phrase", 10)
+		_, err := service.Search(context.Background(), "error phrase", 10, SearchOptions{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "normalization failed")
 	})
@@ -73,13 +77,75 @@ func TestService_Search(t *testing.T) {
 			Return([]string{"test"}, nil)
 
 		mockDB.EXPECT().
-			SearchComics(gomock.Any(), []string{"test"}, 10).
+			SearchComics(gomock.Any(), []Term{{Word: "test", Fields: AllFields}}, 10, DefaultWeights, Filter{}).
 			Return(nil, errors.New("db error"))
 
-		_, err := service.Search(context.Background(), "db error", 10)
+		_, err := service.Search(context.Background(), "db error", 10, SearchOptions{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "db search failed")
 	})
+
+	t.Run("explain keeps the numbers of the database", func(t *testing.T) {
+		mockWords.EXPECT().
+			Norm(gomock.Any(), "explained").
+			Return([]string{"explain"}, nil).Times(2)
+		mockDB.EXPECT().
+			SearchComics(gomock.Any(), []Term{{Word: "explain", Fields: AllFields}}, 10, DefaultWeights, Filter{}).
+			DoAndReturn(func(context.Context, []Term, int, FieldWeights, Filter) ([]Comics, error) {
+				return []Comics{{ID: 7, Explanation: &Explanation{Unique: 1, Weight: 3, Total: 2}}}, nil
+			}).Times(2)
+
+		result, err := service.Search(context.Background(), "explained", 10, SearchOptions{Explain: true})
+		assert.NoError(t, err)
+		// комикса нет в индексе, объяснение состоит из чисел базы
+		assert.Equal(t, &Explanation{Terms: []TermMatch{}, Unique: 1, Weight: 3, Total: 2}, result.Comics[0].Explanation)
+
+		result, err = service.Search(context.Background(), "explained", 10, SearchOptions{})
+		assert.NoError(t, err)
+		assert.Nil(t, result.Comics[0].Explanation)
+	})
+
+	t.Run("field query with weights", func(t *testing.T) {
+		mockWords.EXPECT().
+			Norm(gomock.Any(), "laser").
+			Return([]string{"laser"}, nil)
+		mockWords.EXPECT().
+			Norm(gomock.Any(), "robots").
+			Return([]string{"robot"}, nil)
+
+		mockDB.EXPECT().
+			SearchComics(gomock.Any(), []Term{{Word: "laser", Fields: AllFields}, {Word: "robot", Fields: FieldTitle}}, 10,
+				FieldWeights{Title: 10, Alt: 1.5, Transcript: 0}, Filter{Sort: SortNewest}).
+			Return(nil, nil)
+
+		_, err := service.Search(context.Background(), "title:robots laser", 10, SearchOptions{
+			Weights: map[string]float64{"title": 10, "transcript": 0},
+			Filter:  Filter{Sort: SortNewest},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("bad filter", func(t *testing.T) {
+		_, err := service.Search(context.Background(), "test", 10, SearchOptions{
+			Filter: Filter{Sort: "random"},
+		})
+		assert.ErrorIs(t, err, ErrBadArguments)
+
+		_, err = service.Search(context.Background(), "test", 10, SearchOptions{
+			Filter: Filter{
+				From: time.Date(2012, time.January, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC),
+			},
+		})
+		assert.ErrorIs(t, err, ErrBadArguments)
+	})
+
+	t.Run("bad weights", func(t *testing.T) {
+		_, err := service.Search(context.Background(), "test", 10, SearchOptions{
+			Weights: map[string]float64{"body": 1},
+		})
+		assert.ErrorIs(t, err, ErrBadArguments)
+	})
 }
 
 func TestService_IndexSearch(t *testing.T) {
@@ -89,46 +155,267 @@ func TestService_IndexSearch(t *testing.T) {
 	mockDB := NewMockDB(ctrl)
 	mockWords := NewMockWords(ctrl)
 	logger := slog.Default()
-	service, _ := NewService(logger, mockDB, mockWords)
+	service, _ := NewService(logger, mockDB, mockWords, DefaultWeights, 0, nil, nil)
 
-	service.index = Index{
-		"test":  []int{1, 2},
-		"word":  []int{2, 3},
-		"other": []int{4},
-	}
+	service.index.Store(NewIndex([]Comics{
+		{ID: 1, URL: "http://example.com/1", Words: []string{"test"}},
+		{ID: 2, URL: "http://example.com/2", Words: []string{"test", "word"}, Bigrams: []string{"test word"}},
+		{ID: 3, URL: "http://example.com/3", Words: []string{"word", "word"}},
+		{ID: 4, URL: "http://example.com/4", Words: []string{"other"}},
+	}))
 
 	t.Run("successful index search", func(t *testing.T) {
-		expectedWords := []string{"test", "word"}
-		expectedComics := []Comics{
-			{ID: 2, URL: "http://example.com/2", Words: []string{"test", "word"}},
-			{ID: 1, URL: "http://example.com/1", Words: []string{"test"}},
-			{ID: 3, URL: "http://example.com/3", Words: []string{"word"}},
-		}
+		mockWords.EXPECT().
+			Norm(gomock.Any(), "test word").
+			Return([]string{"test", "word"}, nil)
+		mockWords.EXPECT().
+			Bigrams(gomock.Any(), "test word").
+			Return([]string{"test word"}, nil)
+
+		result, err := service.IndexSearch(context.Background(), "test word", 10, SearchOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Comics{
+			{ID: 2, URL: "http://example.com/2", Terms: []string{"test", "word"}},
+			{ID: 3, URL: "http://example.com/3", Terms: []string{"word"}},
+			{ID: 1, URL: "http://example.com/1", Terms: []string{"test"}},
+		}, result.Comics)
+		assert.Equal(t, 3, result.Total)
+	})
 
+	t.Run("limit keeps total", func(t *testing.T) {
 		mockWords.EXPECT().
 			Norm(gomock.Any(), "test word").
-			Return(expectedWords, nil)
+			Return([]string{"test", "word"}, nil)
+		mockWords.EXPECT().
+			Bigrams(gomock.Any(), "test word").
+			Return([]string{"test word"}, nil)
 
-		mockDB.EXPECT().
-			GetComicsByIDs(gomock.Any(), []int{1, 2, 3}).
-			Return(expectedComics, nil)
+		result, err := service.IndexSearch(context.Background(), "test word", 1, SearchOptions{})
 
-		result, err := service.IndexSearch(context.Background(), "test word", 10)
 		assert.NoError(t, err)
-		assert.Len(t, result.Comics, 3)
+		assert.Equal(t, []Comics{{ID: 2, URL: "http://example.com/2", Terms: []string{"test", "word"}}}, result.Comics)
 		assert.Equal(t, 3, result.Total)
 	})
 
+	t.Run("explain", func(t *testing.T) {
+		mockWords.EXPECT().
+			Norm(gomock.Any(), "test word").
+			Return([]string{"test", "word"}, nil)
+		mockWords.EXPECT().
+			Bigrams(gomock.Any(), "test word").
+			Return([]string{"test word"}, nil)
+
+		result, err := service.IndexSearch(context.Background(), "test word", 2, SearchOptions{Explain: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &QueryExplanation{Path: PathIndex, Terms: []string{"test", "word"}, Phrases: []string{"test word"}}, result.Explain)
+		require.Len(t, result.Comics, 2)
+		assert.Equal(t, 2, result.Comics[0].Explanation.Unique)
+		assert.Equal(t, 1, result.Comics[1].Explanation.Unique)
+		assert.Equal(t, 2, result.Comics[1].Explanation.Total)
+	})
+
+	t.Run("quoted phrase", func(t *testing.T) {
+		mockWords.EXPECT().
+			Norm(gomock.Any(), `"test word"`).
+			Return([]string{"test", "word"}, nil)
+		mockWords.EXPECT().
+			Bigrams(gomock.Any(), `"test word"`).
+			Return([]string{"test word"}, nil)
+		mockWords.EXPECT().
+			Bigrams(gomock.Any(), "test word").
+			Return([]string{"test word"}, nil)
+
+		result, err := service.IndexSearch(context.Background(), `"test word"`, 10, SearchOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Comics{{ID: 2, URL: "http://example.com/2", Terms: []string{"test", "word"}}}, result.Comics)
+		assert.Equal(t, 1, result.Total)
+	})
+
+	t.Run("unknown words", func(t *testing.T) {
+		mockWords.EXPECT().
+			Norm(gomock.Any(), "missing").
+			Return([]string{"missing"}, nil)
+
+		result, err := service.IndexSearch(context.Background(), "missing", 10, SearchOptions{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Comics)
+		assert.Equal(t, 0, result.Total)
+		assert.Empty(t, result.Suggestion)
+	})
+
+	t.Run("typo suggestion", func(t *testing.T) {
+		mockWords.EXPECT().
+			Norm(gomock.Any(), "tset wrd").
+			Return([]string{"tset", "wrd"}, nil)
+		mockWords.EXPECT().
+			Bigrams(gomock.Any(), "tset wrd").
+			Return([]string{"tset wrd"}, nil)
+
+		result, err := service.IndexSearch(context.Background(), "tset wrd", 10, SearchOptions{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Comics)
+		assert.Equal(t, "test word", result.Suggestion)
+	})
+
+	t.Run("fuzzy search", func(t *testing.T) {
+		mockWords.EXPECT().
+			Norm(gomock.Any(), "othr").
+			Return([]string{"othr"}, nil)
+
+		result, err := service.IndexSearch(context.Background(), "othr", 10, SearchOptions{Fuzzy: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Comics{{ID: 4, URL: "http://example.com/4", Terms: []string{"other"}}}, result.Comics)
+		assert.Equal(t, 1, result.Total)
+		assert.Equal(t, "other", result.Suggestion)
+	})
+
 	t.Run("normalization error", func(t *testing.T) {
 		mockWords.EXPECT().
 			Norm(gomock.Any(), "error phrase").
 			Return(nil, errors.New("normalization error"))
 
-		_, err := service.IndexSearch(context.Background(), "error phrase", 10)
+		_, err := service.IndexSearch(context.Background(), "error phrase", 10, SearchOptions{})
 		assert.Error(t, err)
 	})
 }
 
+func TestService_Suggest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _ := NewService(slog.Default(), NewMockDB(ctrl), NewMockWords(ctrl), DefaultWeights, 0, nil, nil)
+	service.index.Store(NewIndex([]Comics{
+		{ID: 1, Words: []string{"run"}, Forms: map[string]string{"run": "running"}},
+		{ID: 2, Words: []string{"run", "rubi"}, Forms: map[string]string{"run": "running", "rubi": "ruby"}},
+	}))
+
+	t.Run("surface words", func(t *testing.T) {
+		suggestions, err := service.Suggest(context.Background(), " RU", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []Suggestion{{Word: "running", Count: 2}, {Word: "ruby", Count: 1}}, suggestions)
+	})
+
+	t.Run("empty prefix", func(t *testing.T) {
+		_, err := service.Suggest(context.Background(), " ", 5)
+		assert.ErrorIs(t, err, ErrBadArguments)
+	})
+}
+
+func TestService_Similar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _ := NewService(slog.Default(), NewMockDB(ctrl), NewMockWords(ctrl), DefaultWeights, 0, nil, nil)
+	service.index.Store(NewIndex([]Comics{
+		{ID: 1, URL: "http://example.com/1", Words: []string{"robot", "laser"}},
+		{ID: 2, URL: "http://example.com/2", Words: []string{"robot"}},
+		{ID: 3, URL: "http://example.com/3", Words: []string{"cat"}},
+	}))
+
+	t.Run("similar comics", func(t *testing.T) {
+		result, err := service.Similar(context.Background(), 2, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Total)
+		assert.Len(t, result.Comics, 1)
+		assert.Equal(t, 1, result.Comics[0].ID)
+		assert.Equal(t, "http://example.com/1", result.Comics[0].URL)
+	})
+
+	t.Run("unknown comic", func(t *testing.T) {
+		_, err := service.Similar(context.Background(), 42, 5)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("bad id", func(t *testing.T) {
+		_, err := service.Similar(context.Background(), 0, 5)
+		assert.ErrorIs(t, err, ErrBadArguments)
+	})
+}
+
+func TestService_GetComic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := NewMockDB(ctrl)
+	service, _ := NewService(slog.Default(), mockDB, NewMockWords(ctrl), DefaultWeights, 0, nil, nil)
+	service.index.Store(NewIndex([]Comics{
+		{ID: 1, URL: "http://example.com/1"},
+		{ID: 3, URL: "http://example.com/3"},
+		{ID: 5, URL: "http://example.com/5"},
+	}))
+
+	t.Run("comic with neighbours", func(t *testing.T) {
+		comic := Comics{ID: 3, URL: "http://example.com/3", Title: "Three", Words: []string{"three"}}
+		mockDB.EXPECT().GetComicsByIDs(gomock.Any(), []int{3}).Return([]Comics{comic}, nil)
+
+		detail, err := service.GetComic(context.Background(), 3)
+		assert.NoError(t, err)
+		assert.Equal(t, ComicDetail{Comics: comic, Prev: 1, Next: 5}, detail)
+	})
+
+	t.Run("not indexed yet", func(t *testing.T) {
+		mockDB.EXPECT().GetComicsByIDs(gomock.Any(), []int{6}).Return([]Comics{{ID: 6}}, nil)
+
+		detail, err := service.GetComic(context.Background(), 6)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, detail.Prev)
+		assert.Equal(t, 0, detail.Next)
+	})
+
+	t.Run("first comic", func(t *testing.T) {
+		mockDB.EXPECT().GetComicsByIDs(gomock.Any(), []int{1}).Return([]Comics{{ID: 1}}, nil)
+
+		detail, err := service.GetComic(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, detail.Prev)
+		assert.Equal(t, 3, detail.Next)
+	})
+
+	t.Run("unknown comic", func(t *testing.T) {
+		mockDB.EXPECT().GetComicsByIDs(gomock.Any(), []int{42}).Return([]Comics{}, nil)
+
+		_, err := service.GetComic(context.Background(), 42)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mockDB.EXPECT().GetComicsByIDs(gomock.Any(), []int{2}).Return(nil, errors.New("db error"))
+
+		_, err := service.GetComic(context.Background(), 2)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("bad id", func(t *testing.T) {
+		_, err := service.GetComic(context.Background(), -1)
+		assert.ErrorIs(t, err, ErrBadArguments)
+	})
+}
+
+func TestService_RandomComic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := NewMockDB(ctrl)
+	service, _ := NewService(slog.Default(), mockDB, NewMockWords(ctrl), DefaultWeights, 0, nil, nil)
+
+	_, err := service.RandomComic(context.Background())
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	service.index.Store(NewIndex([]Comics{{ID: 7, URL: "http://example.com/7"}}))
+	mockDB.EXPECT().GetComicsByIDs(gomock.Any(), []int{7}).Return([]Comics{{ID: 7, URL: "http://example.com/7"}}, nil)
+
+	detail, err := service.RandomComic(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 7, detail.ID)
+}
+
 func TestService_BuildIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -136,7 +423,7 @@ func TestService_BuildIndex(t *testing.T) {
 	mockDB := NewMockDB(ctrl)
 	mockWords := NewMockWords(ctrl)
 	logger := slog.Default()
-	service, _ := NewService(logger, mockDB, mockWords)
+	service, _ := NewService(logger, mockDB, mockWords, DefaultWeights, 0, nil, nil)
 
 	t.Run("successful build", func(t *testing.T) {
 		comics := []Comics{
@@ -153,10 +440,18 @@ func TestService_BuildIndex(t *testing.T) {
 		assert.NoError(t, err)
 
 		index := service.GetIndex(context.Background())
-		assert.Len(t, index["test"], 2)
-		assert.Len(t, index["one"], 1)
-		assert.Len(t, index["two"], 1)
-		assert.Len(t, index["three"], 1)
+		assert.Equal(t, 3, index.Len())
+		assert.Equal(t, []int{1, 2}, index.Lookup("test"))
+		assert.Equal(t, []int{1}, index.Lookup("one"))
+		assert.Equal(t, []int{2}, index.Lookup("two"))
+		assert.Equal(t, []int{3}, index.Lookup("three"))
+
+		mockWords.EXPECT().Stats().Return(WordsClientStats{})
+		stats, err := service.IndexStats(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), stats.Generation)
+		assert.False(t, stats.BuiltAt.IsZero())
+		assert.Equal(t, []TermStats{{Term: "test", Docs: 2, Total: 2}}, stats.TopTerms)
 	})
 
 	t.Run("db error", func(t *testing.T) {
@@ -167,9 +462,115 @@ func TestService_BuildIndex(t *testing.T) {
 		err := service.BuildIndex(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get comics")
+		assert.Equal(t, uint64(1), service.GetIndex(context.Background()).generation)
 	})
 }
 
+func TestService_BuildIndexConcurrentSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// build n indexes comics n*100+1..n*100+3, so every result shows which
+	// snapshot it was computed on
+	var builds atomic.Int32
+	mockDB := NewMockDB(ctrl)
+	mockDB.EXPECT().AllComics(gomock.Any()).DoAndReturn(func(context.Context) ([]Comics, error) {
+		n := int(builds.Add(1))
+		comics := make([]Comics, 3)
+		for i := range comics {
+			comics[i] = Comics{ID: n*100 + i + 1, Words: []string{"robot"}, TitleWords: []string{"robot"}}
+		}
+		return comics, nil
+	}).AnyTimes()
+	mockWords := NewMockWords(ctrl)
+	mockWords.EXPECT().Norm(gomock.Any(), gomock.Any()).Return([]string{"robot"}, nil).AnyTimes()
+	service, _ := NewService(slog.Default(), mockDB, mockWords, DefaultWeights, 100, nil, nil)
+
+	ctx := context.Background()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var last uint64
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				result, err := service.IndexSearch(ctx, "robot", 10, SearchOptions{Explain: true})
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.GreaterOrEqual(t, result.Generation, last, "generation went back")
+				last = result.Generation
+				if len(result.Comics) == 0 {
+					continue
+				}
+				assert.Len(t, result.Comics, 3)
+				snapshot := result.Comics[0].ID / 100
+				for _, c := range result.Comics {
+					assert.Equal(t, snapshot, c.ID/100, "comics of different snapshots in one result")
+				}
+				assert.Equal(t, result.Generation, result.Explain.Generation)
+			}
+		}()
+	}
+
+	var builders sync.WaitGroup
+	for range 2 {
+		builders.Add(1)
+		go func() {
+			defer builders.Done()
+			for range 20 {
+				assert.NoError(t, service.BuildIndex(ctx))
+				service.refresh("test")
+			}
+		}()
+	}
+	builders.Wait()
+	close(done)
+	wg.Wait()
+
+	// every build and every refresh started exactly one generation
+	assert.Equal(t, uint64(80), service.GetIndex(ctx).generation)
+}
+
+func TestService_TermInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWords := NewMockWords(ctrl)
+	service, _ := NewService(slog.Default(), NewMockDB(ctrl), mockWords, DefaultWeights, 0, nil, nil)
+	service.index.Store(NewIndex([]Comics{
+		{ID: 1, Words: []string{"robot"}, Forms: map[string]string{"robot": "robots"}},
+		{ID: 2, Words: []string{"run"}},
+	}))
+
+	info, err := service.TermInfo(context.Background(), "Robot", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "robot", info.Term)
+
+	// известная форма слова не требует нормализации
+	info, err = service.TermInfo(context.Background(), "robots", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "robot", info.Term)
+
+	mockWords.EXPECT().Norm(gomock.Any(), "running").Return([]string{"run"}, nil)
+	info, err = service.TermInfo(context.Background(), "running", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []Posting{{ID: 2, Freq: 1, Fields: []string{"transcript"}}}, info.Postings)
+
+	mockWords.EXPECT().Norm(gomock.Any(), "laser").Return([]string{"laser"}, nil)
+	_, err = service.TermInfo(context.Background(), "laser", 10)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = service.TermInfo(context.Background(), " ", 10)
+	assert.ErrorIs(t, err, ErrBadArguments)
+}
+
 func TestService_Stats(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -177,7 +578,7 @@ func TestService_Stats(t *testing.T) {
 	mockDB := NewMockDB(ctrl)
 	mockWords := NewMockWords(ctrl)
 	logger := slog.Default()
-	service, _ := NewService(logger, mockDB, mockWords)
+	service, _ := NewService(logger, mockDB, mockWords, DefaultWeights, 0, nil, nil)
 
 	t.Run("successful stats", func(t *testing.T) {
 		expectedStats := DBStats{
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"math"
+	"slices"
+)
+
+// idf is the inverse document frequency of an indexed term. Terms found in
+// every comic get zero weight and do not make comics similar.
+func (idx *Index) idf(term string) float64 {
+	df := idx.DocFreq(term)
+	if df == 0 {
+		return 0
+	}
+	return math.Log(float64(len(idx.docs)) / float64(df))
+}
+
+func tfidf(tf uint16, idf float64) float64 {
+	return (1 + math.Log(float64(tf))) * idf
+}
+
+// Similar returns up to limit comics closest to the given one by cosine
+// similarity of their TF-IDF vectors, excluding the comic itself, and the
+// number of comics sharing at least one weighted term with it.
+func (idx *Index) Similar(id, limit int) ([]Comics, int, error) {
+	src, ok := idx.ordinal(id)
+	if !ok {
+		return nil, 0, ErrNotFound
+	}
+	doc := idx.docs[src]
+
+	dots := make(map[uint32]float64)
+	for i, t := range doc.terms {
+		term := idx.vocab[t]
+		idf := idx.idf(term)
+		if idf == 0 {
+			continue
+		}
+		w := tfidf(doc.tfs[i], idf)
+
+		// posting перебирается по возрастанию, в том же порядке, что и частоты
+		p := idx.terms[term]
+		n := 0
+		p.docs.Each(func(d uint32) bool {
+			if d != src {
+				dots[d] += w * tfidf(p.freq(n), idf)
+			}
+			n++
+			return true
+		})
+	}
+
+	comics := make([]Comics, 0, len(dots))
+	for d, dot := range dots {
+		other := idx.docs[d]
+		comics = append(comics, Comics{
+			ID:    other.ID,
+			URL:   other.URL,
+			Date:  other.Date,
+			Score: dot / (doc.norm * other.norm),
+		})
+	}
+	slices.SortFunc(comics, func(a, b Comics) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return a.ID - b.ID
+	})
+
+	total := len(comics)
+	if limit > 0 && len(comics) > limit {
+		comics = comics[:limit]
+	}
+	return comics, total, nil
+}
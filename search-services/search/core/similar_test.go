@@ -0,0 +1,79 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Similar(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, URL: "u1", Words: []string{"robot", "robot", "laser", "comic"}},
+		{ID: 2, URL: "u2", Words: []string{"robot", "laser", "comic"}},
+		{ID: 3, URL: "u3", Words: []string{"robot", "cat", "comic"}},
+		{ID: 4, URL: "u4", Words: []string{"cat", "dog", "comic"}},
+		{ID: 5, URL: "u5", Words: []string{"physic", "comic"}},
+	})
+
+	comics, total, err := index.Similar(1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, comics, 2)
+	assert.Equal(t, 2, comics[0].ID)
+	assert.Equal(t, 3, comics[1].ID)
+	assert.Greater(t, comics[0].Score, comics[1].Score)
+	assert.LessOrEqual(t, comics[0].Score, 1.0)
+
+	comics, total, err = index.Similar(1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, comics, 1)
+
+	// "comic" есть во всех комиксах и не делает их похожими
+	comics, total, err = index.Similar(5, 10)
+	require.NoError(t, err)
+	assert.Empty(t, comics)
+	assert.Zero(t, total)
+
+	_, _, err = index.Similar(42, 10)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestIndex_SimilarIdentical(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"robot", "laser"}},
+		{ID: 2, Words: []string{"robot", "laser"}},
+		{ID: 3, Words: []string{"cat"}},
+	})
+
+	comics, _, err := index.Similar(2, 10)
+	require.NoError(t, err)
+	require.Len(t, comics, 1)
+	assert.Equal(t, 1, comics[0].ID)
+	assert.InDelta(t, 1.0, comics[0].Score, 1e-9)
+}
+
+func TestIndex_SimilarTermCounts(t *testing.T) {
+	// слова комиксов различны, частоты берутся из токенов
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"robot", "laser"}, TranscriptTokens: []Token{
+			{Term: "robot"}, {Term: "robot"}, {Term: "robot"}, {Term: "laser"},
+		}},
+		{ID: 2, Words: []string{"robot", "laser"}, TranscriptTokens: []Token{
+			{Term: "robot"}, {Term: "laser"}, {Term: "laser"}, {Term: "laser"},
+		}},
+		{ID: 3, Words: []string{"robot", "laser"}, TitleTokens: []Token{{Term: "robot"}}, TranscriptTokens: []Token{
+			{Term: "robot"}, {Term: "robot"}, {Term: "laser"},
+		}},
+		{ID: 4, Words: []string{"cat"}},
+	})
+
+	comics, _, err := index.Similar(1, 10)
+	require.NoError(t, err)
+	require.Len(t, comics, 2)
+	assert.Equal(t, 3, comics[0].ID)
+	assert.InDelta(t, 1.0, comics[0].Score, 1e-9)
+	assert.Equal(t, 2, comics[1].ID)
+	assert.Less(t, comics[1].Score, 1.0)
+}
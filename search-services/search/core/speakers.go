@@ -0,0 +1,71 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"yadro.com/course/search/core/bitmap"
+)
+
+// speakerKey identifies a speaker by the letters and digits of the name in
+// lower case, so that speaker:blackhat and speaker:Black_Hat both find the
+// lines of Black Hat.
+func speakerKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// parseSpeakers splits parts such as speaker:cueball out of the phrase and
+// returns the rest of it with the keys of the speakers.
+func parseSpeakers(phrase string) (string, []string) {
+	var speakers []string
+	var rest []string
+	for _, part := range strings.Fields(phrase) {
+		name, value, ok := strings.Cut(part, ":")
+		key := speakerKey(value)
+		if !ok || !strings.EqualFold(name, "speaker") || key == "" {
+			rest = append(rest, part)
+			continue
+		}
+		if !slices.Contains(speakers, key) {
+			speakers = append(speakers, key)
+		}
+	}
+	if speakers == nil {
+		return phrase, nil
+	}
+	return strings.Join(rest, " "), speakers
+}
+
+// spokenKey is the key of the documents where the speaker says the word.
+func spokenKey(speaker, word string) string {
+	return speaker + ":" + word
+}
+
+// speakerDocs returns the documents any of the speakers has a line in.
+func (idx *Index) speakerDocs(speakers []string) *bitmap.Bitmap {
+	docs := bitmap.FromSorted(nil)
+	for _, speaker := range speakers {
+		if d, ok := idx.speakers[speaker]; ok {
+			docs = bitmap.Or(docs, d)
+		}
+	}
+	return docs
+}
+
+// saidBy returns the documents where any of the speakers says the word.
+func (idx *Index) saidBy(word string, speakers []string) *bitmap.Bitmap {
+	docs := bitmap.FromSorted(nil)
+	for _, speaker := range speakers {
+		if d, ok := idx.spoken[spokenKey(speaker, word)]; ok {
+			docs = bitmap.Or(docs, d)
+		}
+	}
+	return docs
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpeakers(t *testing.T) {
+	rest, speakers := parseSpeakers("what did  Black Hat say")
+	assert.Equal(t, "what did  Black Hat say", rest)
+	assert.Nil(t, speakers)
+
+	rest, speakers = parseSpeakers("Speaker:Black_Hat chess speaker:cueball speaker:black-hat speaker: title:robot")
+	assert.Equal(t, "chess speaker: title:robot", rest)
+	assert.Equal(t, []string{"blackhat", "cueball"}, speakers)
+
+	assert.Equal(t, "man1", speakerKey("Man #1"))
+}
+
+func TestIndex_SearchSpeakers(t *testing.T) {
+	index := NewIndex([]Comics{
+		{
+			ID: 1, Words: []string{"chess", "board"}, TranscriptWords: []string{"chess", "board"},
+			Dialogue:      []Line{{Speaker: "Black Hat", Words: []string{"chess"}}},
+			DialogueWords: []string{"chess"}, SceneWords: []string{"board"},
+		},
+		{
+			ID: 2, Words: []string{"chess"}, TranscriptWords: []string{"chess"},
+			Dialogue:      []Line{{Speaker: "Black Hat", Text: "Hm."}, {Speaker: "Cueball", Words: []string{"chess"}}},
+			DialogueWords: []string{"chess"},
+		},
+		{
+			ID: 3, Words: []string{"chess"}, TitleWords: []string{"chess"},
+			Dialogue: []Line{{Speaker: "Megan", Words: []string{"board"}}},
+		},
+	})
+	ids := func(comics []Comics) []int {
+		var res []int
+		for _, c := range comics {
+			res = append(res, c.ID)
+		}
+		return res
+	}
+	chess := []Term{{Word: "chess", Fields: AllFields}}
+
+	t.Run("said by the speaker", func(t *testing.T) {
+		comics, total := index.Search(chess, Phrases{}, 10, DefaultWeights, Filter{Speakers: []string{"blackhat"}})
+		assert.Equal(t, []int{1}, ids(comics))
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("any of the speakers", func(t *testing.T) {
+		comics, _ := index.Search(chess, Phrases{}, 10, DefaultWeights, Filter{Speakers: []string{"blackhat", "cueball"}})
+		assert.Equal(t, []int{1, 2}, ids(comics))
+	})
+
+	t.Run("other fields are not said", func(t *testing.T) {
+		comics, total := index.Search([]Term{{Word: "chess", Fields: FieldTitle}}, Phrases{}, 10, DefaultWeights,
+			Filter{Speakers: []string{"megan"}})
+		assert.Equal(t, []int{3}, ids(comics))
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("speaker alone", func(t *testing.T) {
+		comics, total := index.Search(nil, Phrases{}, 10, DefaultWeights, Filter{Speakers: []string{"blackhat"}})
+		assert.Equal(t, []int{1, 2}, ids(comics))
+		assert.Equal(t, 2, total)
+	})
+
+	t.Run("unknown speaker", func(t *testing.T) {
+		comics, total := index.Search(chess, Phrases{}, 10, DefaultWeights, Filter{Speakers: []string{"whitehat"}})
+		assert.Empty(t, comics)
+		assert.Equal(t, 0, total)
+	})
+
+	t.Run("dialogue and scenes", func(t *testing.T) {
+		comics, _ := index.Search([]Term{{Word: "chess", Fields: FieldDialogue}}, Phrases{}, 10, DefaultWeights, Filter{})
+		assert.Equal(t, []int{1, 2}, ids(comics))
+		comics, _ = index.Search([]Term{{Word: "board", Fields: FieldScene}}, Phrases{}, 10, DefaultWeights, Filter{})
+		assert.Equal(t, []int{1}, ids(comics))
+	})
+}
+
+func TestService_IndexSearchSpeakers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWords := NewMockWords(ctrl)
+	service, err := NewService(slog.Default(), NewMockDB(ctrl), mockWords, DefaultWeights, 0, nil, nil)
+	require.NoError(t, err)
+	service.index.Store(NewIndex([]Comics{
+		{
+			ID: 1, Words: []string{"chess"}, TranscriptWords: []string{"chess"}, DialogueWords: []string{"chess"},
+			Dialogue: []Line{{Speaker: "Black Hat", Text: "Chess.", Words: []string{"chess"}}},
+		},
+		{
+			ID: 2, Words: []string{"chess"}, TranscriptWords: []string{"chess"}, DialogueWords: []string{"chess"},
+			Dialogue: []Line{{Speaker: "Cueball", Text: "Chess.", Words: []string{"chess"}}},
+		},
+	}))
+
+	mockWords.EXPECT().Norm(gomock.Any(), "what did say about chess").Return([]string{"chess"}, nil)
+	mockWords.EXPECT().Bigrams(gomock.Any(), "what did say about chess").Return([]string{}, nil)
+
+	result, err := service.IndexSearch(context.Background(), "what did speaker:black_hat say about chess", 10,
+		SearchOptions{Explain: true})
+	require.NoError(t, err)
+	require.Len(t, result.Comics, 1)
+	assert.Equal(t, 1, result.Comics[0].ID)
+	assert.Equal(t, []TermMatch{{
+		Term: "chess", Fields: []string{"dialogue"}, Matched: []string{"dialogue"}, Freq: 1, Weight: 1,
+	}}, result.Comics[0].Explanation.Terms)
+}
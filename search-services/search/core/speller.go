@@ -0,0 +1,226 @@
+package core
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// spellPrefix bounds the part of a word its deletes are made of, the rest
+// of the word is only compared by the edit distance. This keeps the deletes
+// of long words from taking most of the memory.
+const spellPrefix = 7
+
+// Speller corrects misspelled words with the surface words of the indexed
+// comics by symmetric delete: the known words and the misspelled one are
+// reduced to all their variants with up to maxEdits letters deleted, and
+// every known word sharing a variant with the misspelled one is a candidate.
+// Of the candidates within maxEdits edits the nearest one wins and among
+// equally near ones the one found in more comics, i.e. the most likely word
+// of a noisy channel with a constant cost of a typo.
+type Speller struct {
+	words   []string
+	counts  []int
+	known   map[string]struct{}
+	deletes map[string][]int32
+}
+
+// Correction is a word of a query replaced with a known one.
+type Correction struct {
+	Word       string
+	Correction string
+	Distance   int
+	// Count is the number of comics the correction is found in
+	Count int
+}
+
+// Corrected is a query with the unknown words replaced, the phrase is the
+// same as the query if there was nothing to correct.
+type Corrected struct {
+	Phrase      string
+	Corrections []Correction
+	// Generation is the index generation the words were taken from
+	Generation uint64
+}
+
+// newSpeller makes a speller of the words with the numbers of comics they
+// are found in.
+func newSpeller(counts map[string]int) *Speller {
+	sp := &Speller{
+		words:   make([]string, 0, len(counts)),
+		known:   make(map[string]struct{}, len(counts)),
+		deletes: make(map[string][]int32),
+	}
+	for _, word := range slices.Sorted(maps.Keys(counts)) {
+		i := int32(len(sp.words))
+		sp.words = append(sp.words, word)
+		sp.counts = append(sp.counts, counts[word])
+		sp.known[word] = struct{}{}
+		for _, variant := range deletes(word, maxEdits(word)) {
+			sp.deletes[variant] = append(sp.deletes[variant], i)
+		}
+	}
+	return sp
+}
+
+// Known tells whether the word is found in the indexed comics.
+func (sp *Speller) Known(word string) bool {
+	_, ok := sp.known[word]
+	return ok
+}
+
+// Correct returns the most likely known word for a misspelled one. Known
+// words and words too short to tell a typo in are not corrected.
+func (sp *Speller) Correct(word string) (Correction, bool) {
+	if sp.Known(word) {
+		return Correction{}, false
+	}
+	k := maxEdits(word)
+	if k == 0 {
+		return Correction{}, false
+	}
+
+	best := Correction{Word: word, Distance: k + 1}
+	seen := make(map[int32]struct{})
+	for _, variant := range deletes(word, k) {
+		for _, i := range sp.deletes[variant] {
+			if _, ok := seen[i]; ok {
+				continue
+			}
+			seen[i] = struct{}{}
+
+			candidate, count := sp.words[i], sp.counts[i]
+			dist := editDistance(word, candidate, k)
+			if dist > k {
+				continue
+			}
+			if dist < best.Distance || dist == best.Distance && count > best.Count {
+				best.Correction, best.Distance, best.Count = candidate, dist, count
+			}
+		}
+	}
+	return best, best.Correction != ""
+}
+
+// deletes returns the distinct variants of the prefix of the word with up to
+// k letters deleted, the prefix itself included.
+func deletes(word string, k int) []string {
+	runes := []rune(word)
+	if len(runes) > spellPrefix {
+		runes = runes[:spellPrefix]
+	}
+	seen := map[string]struct{}{string(runes): {}}
+	variants := []string{string(runes)}
+	level := [][]rune{runes}
+	for range k {
+		var next [][]rune
+		for _, v := range level {
+			for i := range v {
+				d := slices.Concat(v[:i], v[i+1:])
+				s := string(d)
+				if _, ok := seen[s]; ok {
+					continue
+				}
+				seen[s] = struct{}{}
+				variants = append(variants, s)
+				next = append(next, d)
+			}
+		}
+		level = next
+	}
+	return variants
+}
+
+// Correct replaces the words of the phrase missing from the indexed comics
+// with the most likely known ones. Stop words and words normalizing to an
+// indexed stem are kept, as are field prefixes, quotes and everything else
+// between the words.
+func (s *Service) Correct(ctx context.Context, phrase string) (Corrected, error) {
+	if strings.TrimSpace(phrase) == "" {
+		return Corrected{}, ErrBadArguments
+	}
+	index := s.GetIndex(ctx)
+	result := Corrected{Phrase: phrase, Corrections: []Correction{}, Generation: index.generation}
+
+	var b strings.Builder
+	last := 0
+	for _, span := range spellWords(phrase) {
+		word := strings.ToLower(phrase[span.Start:span.End])
+		if index.speller.Known(word) {
+			continue
+		}
+		stems, err := s.words.Norm(ctx, word)
+		if err != nil {
+			return Corrected{}, err
+		}
+		if len(stems) == 0 || slices.ContainsFunc(stems, func(stem string) bool {
+			_, ok := index.terms[stem]
+			return ok
+		}) {
+			continue
+		}
+		c, ok := index.speller.Correct(word)
+		if !ok {
+			continue
+		}
+		b.WriteString(phrase[last:span.Start])
+		b.WriteString(c.Correction)
+		last = span.End
+		result.Corrections = append(result.Corrections, c)
+	}
+	if len(result.Corrections) > 0 {
+		b.WriteString(phrase[last:])
+		result.Phrase = b.String()
+	}
+	return result, nil
+}
+
+// spellWords returns the spans of the words of the phrase worth correcting:
+// runs of letters with apostrophes inside, like don't, without numbers,
+// field names like title: and speakers like speaker:black_hat.
+func spellWords(phrase string) []Span {
+	var spans []Span
+	start := -1
+	digits := false
+	// the end of the name of a speaker
+	speaker := -1
+	end := func(i int) {
+		if start < 0 {
+			return
+		}
+		word := strings.ToLower(phrase[start:i])
+		_, field := parseField(word)
+		colon := strings.HasPrefix(phrase[i:], ":")
+		switch {
+		case word == "speaker" && colon:
+			speaker = len(phrase)
+			if n := strings.IndexFunc(phrase[i:], unicode.IsSpace); n >= 0 {
+				speaker = i + n
+			}
+		case digits, field && colon, start < speaker:
+		default:
+			spans = append(spans, Span{Start: start, End: i})
+		}
+		start, digits = -1, false
+	}
+	for i, r := range phrase {
+		if (r == '\'' || r == '’') && start >= 0 {
+			if next, _ := utf8.DecodeRuneInString(phrase[i+utf8.RuneLen(r):]); unicode.IsLetter(next) {
+				continue
+			}
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			end(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		digits = digits || unicode.IsDigit(r)
+	}
+	end(len(phrase))
+	return spans
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpeller_Correct(t *testing.T) {
+	speller := newSpeller(map[string]int{
+		"kernel":       5,
+		"kennel":       1,
+		"linux":        3,
+		"sandwich":     2,
+		"encyclopedia": 1,
+		"dog":          4,
+	})
+
+	tests := []struct {
+		word       string
+		correction string
+		distance   int
+		found      bool
+	}{
+		{word: "kernal", correction: "kernel", distance: 1, found: true},
+		{word: "kenrel", correction: "kernel", distance: 1, found: true},
+		{word: "linx", correction: "linux", distance: 1, found: true},
+		{word: "sandwhich", correction: "sandwich", distance: 1, found: true},
+		{word: "sanwdich", correction: "sandwich", distance: 1, found: true},
+		{word: "encyclopaedia", correction: "encyclopedia", distance: 1, found: true},
+		{word: "encyclopdia", correction: "encyclopedia", distance: 1, found: true},
+		{word: "kernel", found: false},
+		{word: "dg", found: false},
+		{word: "xkcd", found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			c, ok := speller.Correct(tt.word)
+			require.Equal(t, tt.found, ok)
+			if ok {
+				assert.Equal(t, tt.correction, c.Correction)
+				assert.Equal(t, tt.distance, c.Distance)
+				assert.Equal(t, tt.word, c.Word)
+			}
+		})
+	}
+}
+
+func TestSpellWords(t *testing.T) {
+	phrase := `title:kernal "don't panic" speaker:Black_Hat xkcd936 alt`
+	var words []string
+	for _, span := range spellWords(phrase) {
+		words = append(words, phrase[span.Start:span.End])
+	}
+	assert.Equal(t, []string{"kernal", "don't", "panic", "alt"}, words)
+}
+
+func TestService_Correct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWords := NewMockWords(ctrl)
+	service, err := NewService(slog.Default(), NewMockDB(ctrl), mockWords, DefaultWeights, 0, nil, nil)
+	require.NoError(t, err)
+	service.index.Store(NewIndex([]Comics{
+		{ID: 1, Words: []string{"kernel", "panic"}, Forms: map[string]string{"kernel": "kernel", "panic": "panic"}},
+		{ID: 2, Words: []string{"compil"}, Forms: map[string]string{"compil": "compiling"}},
+	}))
+
+	mockWords.EXPECT().Norm(gomock.Any(), "kernal").Return([]string{"kernal"}, nil)
+	mockWords.EXPECT().Norm(gomock.Any(), "the").Return([]string{}, nil)
+	mockWords.EXPECT().Norm(gomock.Any(), "compiled").Return([]string{"compil"}, nil)
+	mockWords.EXPECT().Norm(gomock.Any(), "pancake").Return([]string{"pancak"}, nil)
+
+	result, err := service.Correct(context.Background(), `title:Kernal panic the "compiled" pancake`)
+	require.NoError(t, err)
+	assert.Equal(t, `title:kernel panic the "compiled" pancake`, result.Phrase)
+	assert.Equal(t, []Correction{{Word: "kernal", Correction: "kernel", Distance: 1, Count: 1}}, result.Corrections)
+
+	_, err = service.Correct(context.Background(), " ")
+	assert.ErrorIs(t, err, ErrBadArguments)
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// IndexStats describes the index currently serving queries.
+type IndexStats struct {
+	// Generation is increased by every rebuild, zero for the empty index
+	// the service starts with
+	Generation    uint64
+	BuiltAt       time.Time
+	BuildDuration time.Duration
+	Documents     int
+	Terms         int
+	// TopTerms are the terms found in most comics, RareTerms in fewest
+	TopTerms    []TermStats
+	RareTerms   []TermStats
+	Cache       CacheStats
+	WordsClient WordsClientStats
+}
+
+// WordsClientStats are the counters of the cache of normalized phrases of
+// the words client and of the calls it repeated.
+type WordsClientStats struct {
+	CacheStats
+	Invalidations uint64
+	Retries       uint64
+}
+
+type TermStats struct {
+	Term string
+	// Docs is the number of comics with the term, Total the number of its
+	// occurrences in all of them
+	Docs  int
+	Total int
+}
+
+// TermInfo is the posting list of an indexed term.
+type TermInfo struct {
+	TermStats
+	Form     string
+	IDF      float64
+	Postings []Posting
+}
+
+type Posting struct {
+	ID     int
+	Freq   int
+	Fields []string
+}
+
+// Stats returns the numbers of the index with up to limit top and rare terms.
+func (idx *Index) Stats(limit int) IndexStats {
+	stats := IndexStats{
+		Generation:    idx.generation,
+		BuiltAt:       idx.builtAt,
+		BuildDuration: idx.buildDuration,
+		Documents:     len(idx.docs),
+		Terms:         len(idx.terms),
+	}
+	if limit <= 0 {
+		return stats
+	}
+
+	terms := make([]TermStats, 0, len(idx.vocab))
+	for _, term := range idx.vocab {
+		terms = append(terms, idx.termStats(term))
+	}
+	// vocab is sorted, so terms with equal counts stay in alphabetical order
+	slices.SortStableFunc(terms, func(a, b TermStats) int {
+		return b.Docs - a.Docs
+	})
+
+	n := min(limit, len(terms))
+	rare := make([]TermStats, n)
+	for i := range n {
+		rare[i] = terms[len(terms)-1-i]
+	}
+	slices.SortStableFunc(rare, func(a, b TermStats) int {
+		if a.Docs != b.Docs {
+			return a.Docs - b.Docs
+		}
+		return strings.Compare(a.Term, b.Term)
+	})
+
+	stats.TopTerms = slices.Clip(terms[:n])
+	stats.RareTerms = rare
+	return stats
+}
+
+// Term returns the posting list of the term with up to limit comics
+// (all of them if limit is not positive).
+func (idx *Index) Term(term string, limit int) (TermInfo, bool) {
+	p, ok := idx.terms[term]
+	if !ok {
+		return TermInfo{}, false
+	}
+
+	info := TermInfo{
+		TermStats: idx.termStats(term),
+		Form:      idx.Form(term),
+		IDF:       idx.idf(term),
+		Postings:  []Posting{},
+	}
+	n := 0
+	p.docs.Each(func(doc uint32) bool {
+		if limit > 0 && n == limit {
+			return false
+		}
+		info.Postings = append(info.Postings, Posting{
+			ID:     idx.docs[doc].ID,
+			Freq:   int(p.freq(n)),
+			Fields: p.field(n).names(),
+		})
+		n++
+		return true
+	})
+	return info, true
+}
+
+func (idx *Index) termStats(term string) TermStats {
+	p := idx.terms[term]
+	total := p.docs.Cardinality()
+	for _, f := range p.freqs {
+		total += int(f) - 1
+	}
+	return TermStats{Term: term, Docs: p.docs.Cardinality(), Total: total}
+}
+
+// names returns the names of the fields in the set.
+func (f Field) names() []string {
+	var names []string
+	for _, n := range fieldNames {
+		if f&n.field != 0 {
+			names = append(names, n.name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Stats(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"robot", "robot", "laser"}},
+		{ID: 2, Words: []string{"robot", "cat"}},
+		{ID: 3, Words: []string{"robot", "laser", "dog"}},
+	})
+
+	stats := index.Stats(2)
+	assert.Equal(t, 3, stats.Documents)
+	assert.Equal(t, 4, stats.Terms)
+	assert.Equal(t, []TermStats{
+		{Term: "robot", Docs: 3, Total: 4},
+		{Term: "laser", Docs: 2, Total: 2},
+	}, stats.TopTerms)
+	assert.Equal(t, []TermStats{
+		{Term: "cat", Docs: 1, Total: 1},
+		{Term: "dog", Docs: 1, Total: 1},
+	}, stats.RareTerms)
+
+	stats = index.Stats(10)
+	assert.Len(t, stats.TopTerms, 4)
+	assert.Len(t, stats.RareTerms, 4)
+
+	stats = NewIndex(nil).Stats(10)
+	assert.Empty(t, stats.TopTerms)
+	assert.Empty(t, stats.RareTerms)
+}
+
+func TestIndex_Term(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"robot", "robot"}, Forms: map[string]string{"robot": "robots"},
+			TitleWords: []string{"robot"}, TranscriptWords: []string{"robot"}},
+		{ID: 2, Words: []string{"robot", "cat"}},
+		{ID: 5, Words: []string{"robot"}},
+	})
+
+	info, ok := index.Term("robot", 2)
+	require.True(t, ok)
+	assert.Equal(t, TermStats{Term: "robot", Docs: 3, Total: 4}, info.TermStats)
+	assert.Equal(t, "robots", info.Form)
+	assert.Zero(t, info.IDF)
+	assert.Equal(t, []Posting{
+		{ID: 1, Freq: 2, Fields: []string{"title", "transcript"}},
+		{ID: 2, Freq: 1, Fields: []string{"transcript"}},
+	}, info.Postings)
+
+	info, ok = index.Term("robot", 0)
+	require.True(t, ok)
+	assert.Len(t, info.Postings, 3)
+
+	_, ok = index.Term("laser", 0)
+	assert.False(t, ok)
+}
@@ -0,0 +1,197 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultSynonymWeight is how much a match of a synonym counts compared to a
+// match of the query word itself.
+const DefaultSynonymWeight = 0.5
+
+// Synonyms is a dictionary of groups of words meaning the same, e.g.
+// car, automobile and auto. Groups are kept as written and normalized through
+// the words service on first use, so that they match the normalized query
+// and index terms.
+type Synonyms struct {
+	store  SynonymStore
+	weight float64
+
+	mu sync.Mutex
+	// groups are replaced as a whole and never changed in place
+	groups [][]string
+	// stems maps the stem of every word to the stems of its group, nil
+	// until the groups are normalized
+	stems map[string][]string
+	// revision grows whenever the stems are dropped, so that stems
+	// normalized meanwhile are not kept
+	revision uint64
+}
+
+func NewSynonyms(store SynonymStore, weight float64) (*Synonyms, error) {
+	if weight <= 0 || weight > 1 {
+		return nil, fmt.Errorf("synonym weight %g is out of (0, 1]", weight)
+	}
+	return &Synonyms{store: store, weight: weight}, nil
+}
+
+// Load replaces the groups with the stored ones.
+func (s *Synonyms) Load() error {
+	groups, err := s.store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load synonyms: %w", err)
+	}
+	groups, err = cleanGroups(groups)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups = groups
+	s.stems = nil
+	s.revision++
+	return nil
+}
+
+func (s *Synonyms) Groups() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	groups := make([][]string, len(s.groups))
+	for i, g := range s.groups {
+		groups[i] = slices.Clone(g)
+	}
+	return groups
+}
+
+// Set normalizes and stores the groups and replaces the current ones with
+// them.
+func (s *Synonyms) Set(ctx context.Context, words Words, groups [][]string) error {
+	groups, err := cleanGroups(groups)
+	if err != nil {
+		return err
+	}
+	stems, err := normalizeGroups(ctx, words, groups)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Save(groups); err != nil {
+		return fmt.Errorf("failed to save synonyms: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups = groups
+	s.stems = stems
+	s.revision++
+	return nil
+}
+
+// invalidate drops the stems, e.g. after the words service changed its
+// normalization, so that the groups are normalized again on next use.
+func (s *Synonyms) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stems = nil
+	s.revision++
+}
+
+// expand adds the synonyms of the terms after them. A synonym is searched in
+// the fields of the term it expands and counts as a match of that term with
+// the synonym weight. Words already in the query are not added again.
+func (s *Synonyms) expand(ctx context.Context, words Words, terms []Term) ([]Term, error) {
+	stems, err := s.dictionary(ctx, words)
+	if err != nil || len(stems) == 0 {
+		return terms, err
+	}
+
+	seen := make(map[string]bool, len(terms))
+	for _, t := range terms {
+		seen[t.Word] = true
+	}
+	expanded := slices.Clip(terms)
+	for _, t := range terms {
+		for _, syn := range stems[t.Word] {
+			if seen[syn] {
+				continue
+			}
+			seen[syn] = true
+			expanded = append(expanded, Term{Word: syn, Fields: t.Fields, Origin: t.Word, Weight: s.weight})
+		}
+	}
+	return expanded, nil
+}
+
+// dictionary returns the stems of the groups, normalizing them first if
+// they have changed. The words service is called without the lock, and the
+// stems are kept only if nothing changed meanwhile.
+func (s *Synonyms) dictionary(ctx context.Context, words Words) (map[string][]string, error) {
+	s.mu.Lock()
+	groups, stems, revision := s.groups, s.stems, s.revision
+	s.mu.Unlock()
+	if stems != nil {
+		return stems, nil
+	}
+
+	stems, err := normalizeGroups(ctx, words, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.revision == revision {
+		s.stems = stems
+	}
+	return stems, nil
+}
+
+// normalizeGroups maps the stem of every word of the groups to the other
+// stems of its group. Words normalizing to several stems or to none, like
+// stop words, are skipped.
+func normalizeGroups(ctx context.Context, words Words, groups [][]string) (map[string][]string, error) {
+	stems := make(map[string][]string)
+	for _, group := range groups {
+		var normalized []string
+		for _, word := range group {
+			norm, err := words.Norm(ctx, word)
+			if err != nil {
+				return nil, fmt.Errorf("failed to normalize synonym %q: %w", word, err)
+			}
+			if len(norm) == 1 && !slices.Contains(normalized, norm[0]) {
+				normalized = append(normalized, norm[0])
+			}
+		}
+		for _, stem := range normalized {
+			for _, other := range normalized {
+				if other != stem && !slices.Contains(stems[stem], other) {
+					stems[stem] = append(stems[stem], other)
+				}
+			}
+		}
+	}
+	return stems, nil
+}
+
+// cleanGroups lowercases and trims the words, drops duplicates and checks
+// that every group has at least two words.
+func cleanGroups(groups [][]string) ([][]string, error) {
+	cleaned := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		var words []string
+		for _, word := range group {
+			word = strings.ToLower(strings.TrimSpace(word))
+			if word != "" && !slices.Contains(words, word) {
+				words = append(words, word)
+			}
+		}
+		if len(words) < 2 {
+			return nil, fmt.Errorf("%w: synonym group %q has less than two words", ErrBadArguments, group)
+		}
+		cleaned = append(cleaned, words)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,261 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stemWords normalizes a word by cutting the plural s off, as the words
+// service would.
+func stemWords(ctrl *gomock.Controller) *MockWords {
+	words := NewMockWords(ctrl)
+	words.EXPECT().Norm(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, phrase string) ([]string, error) {
+			var res []string
+			for _, w := range strings.Fields(phrase) {
+				res = append(res, strings.TrimSuffix(w, "s"))
+			}
+			return res, nil
+		}).AnyTimes()
+	return words
+}
+
+func TestNewSynonyms(t *testing.T) {
+	_, err := NewSynonyms(nil, 0)
+	assert.Error(t, err)
+	_, err = NewSynonyms(nil, 1.5)
+	assert.Error(t, err)
+	s, err := NewSynonyms(nil, DefaultSynonymWeight)
+	require.NoError(t, err)
+	assert.Empty(t, s.Groups())
+}
+
+func TestSynonyms_Load(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := NewMockSynonymStore(ctrl)
+	s, _ := NewSynonyms(store, DefaultSynonymWeight)
+
+	t.Run("groups are cleaned", func(t *testing.T) {
+		store.EXPECT().Load().Return([][]string{{" Car ", "automobile", "car"}}, nil)
+		require.NoError(t, s.Load())
+		assert.Equal(t, [][]string{{"car", "automobile"}}, s.Groups())
+	})
+
+	t.Run("single word group", func(t *testing.T) {
+		store.EXPECT().Load().Return([][]string{{"car", "CAR"}}, nil)
+		err := s.Load()
+		assert.ErrorIs(t, err, ErrBadArguments)
+		assert.Equal(t, [][]string{{"car", "automobile"}}, s.Groups())
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		store.EXPECT().Load().Return(nil, errors.New("read error"))
+		assert.Error(t, s.Load())
+	})
+}
+
+func TestSynonyms_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := NewMockSynonymStore(ctrl)
+	s, _ := NewSynonyms(store, DefaultSynonymWeight)
+	words := stemWords(ctrl)
+
+	t.Run("saved", func(t *testing.T) {
+		store.EXPECT().Save([][]string{{"cars", "automobiles"}}).Return(nil)
+		require.NoError(t, s.Set(context.Background(), words, [][]string{{"Cars", "automobiles"}}))
+		assert.Equal(t, [][]string{{"cars", "automobiles"}}, s.Groups())
+
+		terms, err := s.expand(context.Background(), words, []Term{{Word: "car", Fields: AllFields}})
+		require.NoError(t, err)
+		assert.Equal(t, []Term{
+			{Word: "car", Fields: AllFields},
+			{Word: "automobile", Fields: AllFields, Origin: "car", Weight: DefaultSynonymWeight},
+		}, terms)
+	})
+
+	t.Run("invalid group", func(t *testing.T) {
+		err := s.Set(context.Background(), words, [][]string{{"car"}})
+		assert.ErrorIs(t, err, ErrBadArguments)
+	})
+
+	t.Run("save error", func(t *testing.T) {
+		store.EXPECT().Save(gomock.Any()).Return(errors.New("write error"))
+		assert.Error(t, s.Set(context.Background(), words, [][]string{{"cat", "kitten"}}))
+		assert.Equal(t, [][]string{{"cars", "automobiles"}}, s.Groups())
+	})
+}
+
+func TestSynonyms_Expand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := NewMockSynonymStore(ctrl)
+	store.EXPECT().Load().Return([][]string{
+		{"car", "automobile", "auto"},
+		{"picture", "image"},
+	}, nil)
+	s, _ := NewSynonyms(store, 0.25)
+	require.NoError(t, s.Load())
+	words := stemWords(ctrl)
+
+	t.Run("fields of the origin", func(t *testing.T) {
+		terms, err := s.expand(context.Background(), words, []Term{
+			{Word: "car", Fields: FieldTitle},
+			{Word: "robot", Fields: AllFields},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []Term{
+			{Word: "car", Fields: FieldTitle},
+			{Word: "robot", Fields: AllFields},
+			{Word: "automobile", Fields: FieldTitle, Origin: "car", Weight: 0.25},
+			{Word: "auto", Fields: FieldTitle, Origin: "car", Weight: 0.25},
+		}, terms)
+	})
+
+	t.Run("query words are not repeated", func(t *testing.T) {
+		terms, err := s.expand(context.Background(), words, []Term{
+			{Word: "image", Fields: AllFields},
+			{Word: "picture", Fields: AllFields},
+		})
+		require.NoError(t, err)
+		assert.Len(t, terms, 2)
+	})
+
+	t.Run("normalization error", func(t *testing.T) {
+		failing := NewMockWords(ctrl)
+		failing.EXPECT().Norm(gomock.Any(), gomock.Any()).Return(nil, errors.New("words unavailable"))
+		store.EXPECT().Load().Return([][]string{{"cat", "kitten"}}, nil)
+		require.NoError(t, s.Load())
+
+		query := []Term{{Word: "cat", Fields: AllFields}}
+		terms, err := s.expand(context.Background(), failing, query)
+		assert.Error(t, err)
+		assert.Equal(t, query, terms)
+	})
+}
+
+func TestSynonyms_Invalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := NewMockSynonymStore(ctrl)
+	store.EXPECT().Load().Return([][]string{{"car", "automobile"}}, nil)
+	s, _ := NewSynonyms(store, DefaultSynonymWeight)
+	require.NoError(t, s.Load())
+
+	calls := 0
+	words := NewMockWords(ctrl)
+	words.EXPECT().Norm(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, word string) ([]string, error) {
+			calls++
+			if calls == 1 {
+				// the words service changes while the groups are normalized
+				s.invalidate()
+			}
+			return []string{word}, nil
+		}).AnyTimes()
+	expand := func() {
+		terms, err := s.expand(context.Background(), words, []Term{{Word: "car", Fields: AllFields}})
+		require.NoError(t, err)
+		assert.Len(t, terms, 2)
+	}
+
+	expand()
+	assert.Equal(t, 2, calls)
+	// the stale stems were not kept
+	expand()
+	assert.Equal(t, 4, calls)
+	expand()
+	assert.Equal(t, 4, calls)
+
+	s.invalidate()
+	expand()
+	assert.Equal(t, 6, calls)
+}
+
+func TestIndex_SearchSynonyms(t *testing.T) {
+	index := NewIndex([]Comics{
+		{ID: 1, Words: []string{"automobile"}, TranscriptWords: []string{"automobile"}},
+		{ID: 2, Words: []string{"car"}, TranscriptWords: []string{"car"}},
+		{ID: 3, Words: []string{"car", "automobile", "robot"}, TranscriptWords: []string{"car", "automobile"}, TitleWords: []string{"robot"}},
+	})
+	terms := []Term{
+		{Word: "car", Fields: AllFields},
+		{Word: "robot", Fields: AllFields},
+		{Word: "automobile", Fields: AllFields, Origin: "car", Weight: DefaultSynonymWeight},
+	}
+
+	comics, total := index.Search(terms, Phrases{}, 10, DefaultWeights, Filter{})
+	require.Equal(t, 3, total)
+	var ids []int
+	for _, c := range comics {
+		ids = append(ids, c.ID)
+	}
+	// the synonym counts as the query word, so comic 3 matches two words
+	// rather than three, and a synonym match ranks below the word itself
+	assert.Equal(t, []int{3, 2, 1}, ids)
+}
+
+func TestService_SetSynonyms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := NewMockDB(ctrl)
+	store := NewMockSynonymStore(ctrl)
+	words := stemWords(ctrl)
+	synonyms, _ := NewSynonyms(store, DefaultSynonymWeight)
+
+	t.Run("no synonyms", func(t *testing.T) {
+		service, _ := NewService(slog.Default(), mockDB, words, DefaultWeights, 0, nil, nil)
+		_, err := service.Synonyms(context.Background())
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorIs(t, service.SetSynonyms(context.Background(), nil), ErrNotFound)
+		assert.ErrorIs(t, service.ReloadSynonyms(context.Background()), ErrNotFound)
+	})
+
+	service, _ := NewService(slog.Default(), mockDB, words, DefaultWeights, 0, nil, synonyms)
+	generation := service.GetIndex(context.Background()).generation
+
+	t.Run("set", func(t *testing.T) {
+		store.EXPECT().Save([][]string{{"car", "automobile"}}).Return(nil)
+		require.NoError(t, service.SetSynonyms(context.Background(), [][]string{{"car", "automobile"}}))
+		groups, err := service.Synonyms(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"car", "automobile"}}, groups)
+		assert.Equal(t, generation+1, service.GetIndex(context.Background()).generation)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := service.SetSynonyms(context.Background(), [][]string{{"car"}})
+		assert.ErrorIs(t, err, ErrBadArguments)
+		assert.Equal(t, generation+1, service.GetIndex(context.Background()).generation)
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		store.EXPECT().Load().Return([][]string{{"cat", "kitten"}}, nil)
+		require.NoError(t, service.ReloadSynonyms(context.Background()))
+		assert.Equal(t, generation+2, service.GetIndex(context.Background()).generation)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		mockDB.EXPECT().
+			SearchComics(gomock.Any(), []Term{
+				{Word: "cat", Fields: AllFields},
+				{Word: "kitten", Fields: AllFields, Origin: "cat", Weight: DefaultSynonymWeight},
+			}, 10, DefaultWeights, Filter{}).
+			Return(nil, nil)
+		_, err := service.Search(context.Background(), "cats", 10, SearchOptions{})
+		require.NoError(t, err)
+	})
+}
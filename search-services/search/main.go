@@ -16,6 +16,7 @@ import (
 	"yadro.com/course/search/adapters/db"
 	searchgrpc "yadro.com/course/search/adapters/grpc"
 	"yadro.com/course/search/adapters/initiator"
+	"yadro.com/course/search/adapters/synonyms"
 	"yadro.com/course/search/adapters/words"
 	"yadro.com/course/search/config"
 	"yadro.com/course/search/core"
@@ -37,22 +38,50 @@ func main() {
 }
 
 func run(cfg config.Config, log *slog.Logger) error {
-	dbAdapter, err := db.New(log, cfg.DBAddress)
+	var dbAdapter core.DB
+	var err error
+	switch cfg.DBSearch {
+	case "array":
+		dbAdapter, err = db.New(log, cfg.DBAddress)
+	case "fts":
+		dbAdapter, err = db.NewFTS(log, cfg.DBAddress)
+	default:
+		return fmt.Errorf("unknown db_search %q", cfg.DBSearch)
+	}
 	if err != nil {
 		log.Error("failed to connect to db", "error", err)
 		os.Exit(1)
 	}
 
-	wordsAdapter, err := words.NewClient(cfg.WordsAddress, log)
+	wordsAdapter, err := words.NewClient(cfg.WordsAddress, cfg.WordsClient, log)
 	if err != nil {
 		log.Error("failed to connect to words service", "error", err)
 		os.Exit(1)
 	}
 
-	service, err := core.NewService(log, dbAdapter, wordsAdapter)
+	queryLog, err := db.NewQueryLog(log, cfg.DBAddress, cfg.QueryLog.Retention, cfg.QueryLog.Buffer)
+	if err != nil {
+		log.Error("failed to connect to db", "error", err)
+		os.Exit(1)
+	}
+
+	synonymsDict, err := core.NewSynonyms(synonyms.NewFile(cfg.Synonyms.File), cfg.Synonyms.Weight)
+	if err != nil {
+		return fmt.Errorf("invalid synonyms config: %w", err)
+	}
+	if err := synonymsDict.Load(); err != nil {
+		return err
+	}
+
+	service, err := core.NewService(log, dbAdapter, wordsAdapter, core.FieldWeights{
+		Title:      cfg.FieldWeights.Title,
+		Alt:        cfg.FieldWeights.Alt,
+		Transcript: cfg.FieldWeights.Transcript,
+	}, cfg.CacheSize, queryLog, synonymsDict)
 	if err != nil {
 		return fmt.Errorf("failed to serve: %w", err)
 	}
+	wordsAdapter.OnVersionChange(service.WordsChanged)
 
 	go func() {
 		init := initiator.NewInit(log, service, cfg.SearchConfig.IndexTTL)
@@ -72,6 +101,23 @@ func run(cfg config.Config, log *slog.Logger) error {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
 	defer stop()
 
+	// SIGHUP перечитывает файл синонимов
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if err := service.ReloadSynonyms(ctx); err != nil {
+				log.Error("failed to reload synonyms", "error", err)
+			}
+		}
+	}()
+
+	logDone := make(chan struct{})
+	go func() {
+		queryLog.Run(ctx)
+		close(logDone)
+	}()
+
 	go func() {
 		<-ctx.Done()
 		log.Info("shutting down server")
@@ -83,5 +129,7 @@ func run(cfg config.Config, log *slog.Logger) error {
 	if err := s.Serve(listener); err != nil {
 		return fmt.Errorf("failed to serve: %w", err)
 	}
+	// запросы, ещё не записанные в журнал, дописываются перед выходом
+	<-logDone
 	return nil
 }
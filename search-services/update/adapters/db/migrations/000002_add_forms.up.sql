@@ -0,0 +1 @@
+ALTER TABLE comics ADD COLUMN forms JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+ALTER TABLE comics
+    DROP COLUMN IF EXISTS title,
+    DROP COLUMN IF EXISTS alt,
+    DROP COLUMN IF EXISTS transcript;
@@ -0,0 +1,4 @@
+ALTER TABLE comics
+    ADD COLUMN title      TEXT NOT NULL DEFAULT '',
+    ADD COLUMN alt        TEXT NOT NULL DEFAULT '',
+    ADD COLUMN transcript TEXT NOT NULL DEFAULT '';
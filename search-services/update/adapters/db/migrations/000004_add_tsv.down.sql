@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS comics_tsv_idx;
+
+ALTER TABLE comics DROP COLUMN IF EXISTS tsv;
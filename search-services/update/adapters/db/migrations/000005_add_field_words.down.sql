@@ -0,0 +1,4 @@
+ALTER TABLE comics
+    DROP COLUMN IF EXISTS title_words,
+    DROP COLUMN IF EXISTS alt_words,
+    DROP COLUMN IF EXISTS transcript_words;
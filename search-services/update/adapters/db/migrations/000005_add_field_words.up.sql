@@ -0,0 +1,7 @@
+ALTER TABLE comics
+    ADD COLUMN title_words      TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN alt_words        TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN transcript_words TEXT[] NOT NULL DEFAULT '{}';
+
+-- у ранее загруженных комиксов поля не различались, все слова считаются транскриптом
+UPDATE comics SET transcript_words = words WHERE words IS NOT NULL;
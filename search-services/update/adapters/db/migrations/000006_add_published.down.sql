@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS comics_published_idx;
+
+ALTER TABLE comics DROP COLUMN IF EXISTS published;
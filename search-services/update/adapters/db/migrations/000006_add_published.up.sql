@@ -0,0 +1,3 @@
+ALTER TABLE comics ADD COLUMN published DATE;
+
+CREATE INDEX comics_published_idx ON comics (published);
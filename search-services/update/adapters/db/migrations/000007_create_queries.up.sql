@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS queries (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    phrase TEXT NOT NULL,
+    terms TEXT[] NOT NULL DEFAULT '{}',
+    mode TEXT NOT NULL,
+    total INT NOT NULL,
+    latency_ms DOUBLE PRECISION NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS queries_created_at_idx ON queries (created_at);
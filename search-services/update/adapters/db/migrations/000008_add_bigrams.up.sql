@@ -0,0 +1 @@
+ALTER TABLE comics ADD COLUMN bigrams TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,7 @@
+ALTER TABLE comics
+    DROP COLUMN IF EXISTS dialogue,
+    DROP COLUMN IF EXISTS speakers,
+    DROP COLUMN IF EXISTS scenes,
+    DROP COLUMN IF EXISTS title_text,
+    DROP COLUMN IF EXISTS dialogue_words,
+    DROP COLUMN IF EXISTS scene_words;
@@ -0,0 +1,7 @@
+ALTER TABLE comics
+    ADD COLUMN dialogue       JSONB  NOT NULL DEFAULT '[]',
+    ADD COLUMN speakers       TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN scenes         TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN title_text     TEXT   NOT NULL DEFAULT '',
+    ADD COLUMN dialogue_words TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN scene_words    TEXT[] NOT NULL DEFAULT '{}';
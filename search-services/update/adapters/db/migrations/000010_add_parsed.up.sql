@@ -0,0 +1,3 @@
+-- комиксы, загруженные до разбора транскриптов, остаются без отметки и
+-- загружаются заново при следующем update
+ALTER TABLE comics ADD COLUMN parsed BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+-- слова полей с их позициями в тексте, чтобы поиск подсвечивал найденные
+-- формы слов; сохраненные раньше комиксы загружаются заново
+ALTER TABLE comics ADD COLUMN tokens JSONB NOT NULL DEFAULT '{}';
+UPDATE comics SET parsed = FALSE;
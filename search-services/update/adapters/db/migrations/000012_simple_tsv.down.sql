@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS comics_tsv_idx;
+
+ALTER TABLE comics DROP COLUMN IF EXISTS tsv;
+ALTER TABLE comics
+    ADD COLUMN tsv tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title), 'A') ||
+        setweight(to_tsvector('english', alt), 'B') ||
+        setweight(to_tsvector('english', transcript), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS comics_tsv_idx ON comics USING GIN (tsv);
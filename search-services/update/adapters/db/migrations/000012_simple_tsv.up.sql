@@ -0,0 +1,15 @@
+-- tsv строится из основ, которые words-сервис сохранил в tokens, с конфигурацией
+-- simple: запрос ищет те же основы, а english стеммер менял бы их ещё раз.
+-- Основы идут в порядке текста с повторами, поэтому ts_rank_cd учитывает их
+-- частоту и близость
+DROP INDEX IF EXISTS comics_tsv_idx;
+
+ALTER TABLE comics DROP COLUMN IF EXISTS tsv;
+ALTER TABLE comics
+    ADD COLUMN tsv tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', jsonb_path_query_array(tokens, '$.title[*].term')), 'A') ||
+        setweight(to_tsvector('simple', jsonb_path_query_array(tokens, '$.alt[*].term')), 'B') ||
+        setweight(to_tsvector('simple', jsonb_path_query_array(tokens, '$.transcript[*].term')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS comics_tsv_idx ON comics USING GIN (tsv);
@@ -2,8 +2,11 @@ package db
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
@@ -28,11 +31,50 @@ func New(log *slog.Logger, address string) (*DB, error) {
 	}, nil
 }
 
+// Add stores the comic, replacing a stored one with the same ID that was
+// loaded before its transcript was parsed.
 func (db *DB) Add(ctx context.Context, comics core.Comics) error {
-	_, err := db.conn.ExecContext(ctx, `
-		INSERT INTO comics (id, url, words) VALUES ($1, $2, $3)
-		ON CONFLICT (id) DO NOTHING
-	`, comics.ID, comics.URL, comics.Words)
+	if comics.Forms == nil {
+		comics.Forms = map[string]string{}
+	}
+	forms, err := json.Marshal(comics.Forms)
+	if err != nil {
+		return fmt.Errorf("failed to encode forms: %w", err)
+	}
+	dialogue, err := json.Marshal(toLines(comics.Dialogue))
+	if err != nil {
+		return fmt.Errorf("failed to encode dialogue: %w", err)
+	}
+	tokens, err := json.Marshal(fieldTokens{
+		Title:      toTokens(comics.TitleTokens),
+		Alt:        toTokens(comics.AltTokens),
+		Transcript: toTokens(comics.TranscriptTokens),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode tokens: %w", err)
+	}
+
+	_, err = db.conn.ExecContext(ctx, `
+		INSERT INTO comics (
+			id, url, words, forms, title, alt, transcript,
+			title_words, alt_words, transcript_words, published, bigrams,
+			dialogue, speakers, scenes, title_text, dialogue_words, scene_words, tokens, parsed
+		)
+		VALUES ($1, $2, $3, $4::jsonb, $5, $6, $7, $8, $9, $10, $11, $12, $13::jsonb, $14, $15, $16, $17, $18, $19::jsonb, TRUE)
+		ON CONFLICT (id) DO UPDATE SET
+			url = EXCLUDED.url, words = EXCLUDED.words, forms = EXCLUDED.forms,
+			title = EXCLUDED.title, alt = EXCLUDED.alt, transcript = EXCLUDED.transcript,
+			title_words = EXCLUDED.title_words, alt_words = EXCLUDED.alt_words,
+			transcript_words = EXCLUDED.transcript_words, published = EXCLUDED.published,
+			bigrams = EXCLUDED.bigrams, dialogue = EXCLUDED.dialogue, speakers = EXCLUDED.speakers,
+			scenes = EXCLUDED.scenes, title_text = EXCLUDED.title_text,
+			dialogue_words = EXCLUDED.dialogue_words, scene_words = EXCLUDED.scene_words,
+			tokens = EXCLUDED.tokens, parsed = TRUE
+		WHERE NOT comics.parsed
+	`, comics.ID, comics.URL, comics.Words, string(forms), comics.Title, comics.Alt, comics.Transcript,
+		nonNil(comics.TitleWords), nonNil(comics.AltWords), nonNil(comics.TranscriptWords), published(comics.Date),
+		nonNil(comics.Bigrams), string(dialogue), speakers(comics.Dialogue), nonNil(comics.Scenes), comics.TitleText,
+		nonNil(comics.DialogueWords), nonNil(comics.SceneWords), string(tokens))
 	if err != nil {
 		return fmt.Errorf("failed to insert comic: %w", err)
 	}
@@ -40,6 +82,71 @@ func (db *DB) Add(ctx context.Context, comics core.Comics) error {
 	return nil
 }
 
+// published stores an unknown date as NULL.
+func published(date time.Time) *time.Time {
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
+
+// line is a line of dialogue as the dialogue column stores it.
+type line struct {
+	Speaker string   `json:"speaker"`
+	Text    string   `json:"text"`
+	Words   []string `json:"words"`
+}
+
+func toLines(dialogue []core.Line) []line {
+	lines := make([]line, len(dialogue))
+	for i, l := range dialogue {
+		lines[i] = line{Speaker: l.Speaker, Text: l.Text, Words: nonNil(l.Words)}
+	}
+	return lines
+}
+
+// token is a word of a field as the tokens column stores it.
+type token struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Term  string `json:"term"`
+}
+
+// fieldTokens are the words of the searched fields of a comic.
+type fieldTokens struct {
+	Title      []token `json:"title"`
+	Alt        []token `json:"alt"`
+	Transcript []token `json:"transcript"`
+}
+
+func toTokens(tokens []core.Token) []token {
+	stored := make([]token, len(tokens))
+	for i, t := range tokens {
+		stored[i] = token{Start: t.Start, End: t.End, Term: t.Term}
+	}
+	return stored
+}
+
+// speakers returns the distinct speakers of the dialogue in the order they
+// first speak, so that the database search can filter by them.
+func speakers(dialogue []core.Line) []string {
+	names := []string{}
+	for _, l := range dialogue {
+		if !slices.Contains(names, l.Speaker) {
+			names = append(names, l.Speaker)
+		}
+	}
+	return names
+}
+
+// nonNil keeps empty field words from being stored as NULL.
+func nonNil(words []string) []string {
+	if words == nil {
+		return []string{}
+	}
+	return words
+}
+
 func (db *DB) Stats(ctx context.Context) (core.DBStats, error) {
 	var stats core.DBStats
 
@@ -63,9 +170,12 @@ func (db *DB) Stats(ctx context.Context) (core.DBStats, error) {
 	return stats, nil
 }
 
+// IDs returns the stored comics that need not be fetched again. Comics
+// stored before their transcripts were parsed are left out, so that the
+// next update fetches and parses them.
 func (db *DB) IDs(ctx context.Context) ([]int, error) {
 	var ids []int
-	if err := db.conn.SelectContext(ctx, &ids, `SELECT id FROM comics`); err != nil {
+	if err := db.conn.SelectContext(ctx, &ids, `SELECT id FROM comics WHERE parsed`); err != nil {
 		return nil, fmt.Errorf("failed to get comic IDs: %w", err)
 	}
 
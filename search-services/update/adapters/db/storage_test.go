@@ -93,7 +93,7 @@ func TestIDs(t *testing.T) {
 	t.Run("successful IDs retrieval", func(t *testing.T) {
 		expectedIDs := []int{1, 2, 3}
 
-		mock.ExpectQuery("SELECT id FROM comics").
+		mock.ExpectQuery("SELECT id FROM comics WHERE parsed").
 			WillReturnRows(sqlxmock.NewRows([]string{"id"}).
 				AddRow(expectedIDs[0]).
 				AddRow(expectedIDs[1]).
@@ -106,7 +106,7 @@ func TestIDs(t *testing.T) {
 	})
 
 	t.Run("error in IDs retrieval", func(t *testing.T) {
-		mock.ExpectQuery("SELECT id FROM comics").
+		mock.ExpectQuery("SELECT id FROM comics WHERE parsed").
 			WillReturnError(errors.New("query failed"))
 
 		_, err := d.IDs(context.Background())
@@ -4,34 +4,90 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	wordspb "yadro.com/course/proto/words"
+	"yadro.com/course/update/core"
+	"yadro.com/course/wordsclient"
 )
 
+// maxBatch is the most phrases the words service takes in one batch.
+const maxBatch = 1000
+
 type Client struct {
 	log    *slog.Logger
 	client wordspb.WordsClient
 }
 
-func NewClient(address string, log *slog.Logger) (*Client, error) {
-	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewClient(address string, cfg wordsclient.Config, log *slog.Logger) (*Client, error) {
+	client, err := wordsclient.Dial(log, address, cfg)
 	if err != nil {
 		return nil, err
 	}
 	return &Client{
-		client: wordspb.NewWordsClient(conn),
+		client: client,
 		log:    log,
 	}, nil
 }
 
-func (c Client) Norm(ctx context.Context, phrase string) ([]string, error) {
-	resp, err := c.client.Norm(ctx, &wordspb.WordsRequest{Phrase: phrase})
-	if err != nil {
-		return nil, fmt.Errorf("failed to normalize words: %w", err)
+// NormBatch normalizes the phrases with as few calls as the batch limit of
+// the words service allows. Results are matched to the phrases by their IDs,
+// so the service may answer them in any order.
+func (c Client) NormBatch(ctx context.Context, phrases []string) ([]core.NormResult, error) {
+	results := make([]core.NormResult, 0, len(phrases))
+	for start := 0; start < len(phrases); start += maxBatch {
+		chunk := phrases[start:min(start+maxBatch, len(phrases))]
+		req := &wordspb.NormBatchRequest{Items: make([]*wordspb.NormItem, len(chunk))}
+		for i, phrase := range chunk {
+			req.Items[i] = &wordspb.NormItem{Id: strconv.Itoa(start + i), Phrase: phrase, Bigrams: true, Detailed: true}
+		}
+
+		resp, err := c.client.NormBatch(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize words: %w", err)
+		}
+		if len(resp.Results) != len(chunk) {
+			return nil, fmt.Errorf("words service returned %d results for %d phrases", len(resp.Results), len(chunk))
+		}
+		byID := make(map[string]*wordspb.NormResult, len(resp.Results))
+		for _, r := range resp.Results {
+			if _, ok := byID[r.Id]; ok {
+				return nil, fmt.Errorf("words service returned phrase %q twice", r.Id)
+			}
+			byID[r.Id] = r
+		}
+		for i := range chunk {
+			r, ok := byID[req.Items[i].Id]
+			if !ok {
+				return nil, fmt.Errorf("words service returned no result for phrase %q", req.Items[i].Id)
+			}
+			if codes.Code(r.Code) != codes.OK {
+				results = append(results, core.NormResult{Err: status.Error(codes.Code(r.Code), r.Error)})
+				continue
+			}
+			results = append(results, core.NormResult{Terms: core.Terms{
+				Words:   r.Reply.GetWords(),
+				Forms:   r.Reply.GetForms(),
+				Bigrams: r.Reply.GetBigrams(),
+				Tokens:  tokens(r.Detailed),
+			}})
+		}
+	}
+	return results, nil
+}
+
+// tokens returns the words of the phrase the reply kept.
+func tokens(detailed *wordspb.DetailedReply) []core.Token {
+	var tokens []core.Token
+	for _, t := range detailed.GetTokens() {
+		if t.Stop {
+			continue
+		}
+		tokens = append(tokens, core.Token{Start: int(t.Start), End: int(t.End), Term: t.Term})
 	}
-	return resp.Words, nil
+	return tokens
 }
 
 func (c Client) Ping(ctx context.Context) error {
@@ -5,87 +5,100 @@ import (
 	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"testing"
 	mockwords "yadro.com/course/api/adapters/words/mock"
 
 	wordspb "yadro.com/course/proto/words"
+	"yadro.com/course/update/core"
+	"yadro.com/course/wordsclient"
 )
 
 func TestNewClient(t *testing.T) {
 	t.Run("successful creation", func(t *testing.T) {
-		client, err := NewClient("localhost:50051", nil)
+		client, err := NewClient("localhost:50051", wordsclient.Config{}, nil)
 		assert.NoError(t, err)
 		assert.NotNil(t, client)
 	})
 }
 
-func TestClient_Norm(t *testing.T) {
-	tests := []struct {
-		name        string
-		phrase      string
-		mockSetup   func(*mockwords.MockWordsClient)
-		expected    []string
-		expectedErr string
-	}{
-		{
-			name:   "successful normalization",
-			phrase: "test phrase",
-			mockSetup: func(m *mockwords.MockWordsClient) {
-				m.EXPECT().Norm(
-					gomock.Any(),
-					&wordspb.WordsRequest{Phrase: "test phrase"},
-				).Return(&wordspb.WordsReply{Words: []string{"test", "phrase"}}, nil)
-			},
-			expected: []string{"test", "phrase"},
-		},
-		{
-			name:   "empty phrase",
-			phrase: "",
-			mockSetup: func(m *mockwords.MockWordsClient) {
-				m.EXPECT().Norm(
-					gomock.Any(),
-					&wordspb.WordsRequest{Phrase: ""},
-				).Return(&wordspb.WordsReply{Words: []string{}}, nil)
-			},
-			expected: []string{},
-		},
-		{
-			name:   "gRPC error",
-			phrase: "error",
-			mockSetup: func(m *mockwords.MockWordsClient) {
-				m.EXPECT().Norm(
-					gomock.Any(),
-					&wordspb.WordsRequest{Phrase: "error"},
-				).Return(nil, errors.New("gRPC error"))
-			},
-			expectedErr: "failed to normalize words: gRPC error",
-		},
-	}
+func TestClient_NormBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
+	mockClient := mockwords.NewMockWordsClient(ctrl)
+	c := &Client{client: mockClient}
 
-			mockClient := mockwords.NewMockWordsClient(ctrl)
-			tt.mockSetup(mockClient)
+	t.Run("results in order", func(t *testing.T) {
+		mockClient.EXPECT().NormBatch(gomock.Any(), &wordspb.NormBatchRequest{Items: []*wordspb.NormItem{
+			{Id: "0", Phrase: "robots", Bigrams: true, Detailed: true},
+			{Id: "1", Phrase: "too long", Bigrams: true, Detailed: true},
+		}}).Return(&wordspb.NormBatchReply{Results: []*wordspb.NormResult{
+			{Id: "0", Reply: &wordspb.WordsReply{Words: []string{"robot"}}, Detailed: &wordspb.DetailedReply{Tokens: []*wordspb.Token{
+				{Text: "the", Start: 0, End: 3, Stop: true},
+				{Text: "robots", Start: 4, End: 10, Term: "robot"},
+			}}},
+			{Id: "1", Code: uint32(codes.ResourceExhausted), Error: "phrase is too large"},
+		}}, nil)
 
-			c := &Client{
-				client: mockClient,
-				log:    nil,
-			}
+		results, err := c.NormBatch(context.Background(), []string{"robots", "too long"})
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, core.Terms{
+			Words:  []string{"robot"},
+			Tokens: []core.Token{{Start: 4, End: 10, Term: "robot"}},
+		}, results[0].Terms)
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(results[1].Err))
+	})
 
-			result, err := c.Norm(context.Background(), tt.phrase)
+	t.Run("results out of order", func(t *testing.T) {
+		mockClient.EXPECT().NormBatch(gomock.Any(), gomock.Any()).Return(&wordspb.NormBatchReply{Results: []*wordspb.NormResult{
+			{Id: "1", Reply: &wordspb.WordsReply{Words: []string{"comic"}}},
+			{Id: "0", Reply: &wordspb.WordsReply{Words: []string{"robot"}}},
+		}}, nil)
 
-			if tt.expectedErr != "" {
-				assert.Error(t, err)
-				assert.Contains(t, err.Error(), tt.expectedErr)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.expected, result)
-			}
-		})
-	}
+		results, err := c.NormBatch(context.Background(), []string{"robots", "comics"})
+		assert.NoError(t, err)
+		assert.Equal(t, []core.NormResult{
+			{Terms: core.Terms{Words: []string{"robot"}}},
+			{Terms: core.Terms{Words: []string{"comic"}}},
+		}, results)
+	})
+
+	t.Run("duplicate result", func(t *testing.T) {
+		mockClient.EXPECT().NormBatch(gomock.Any(), gomock.Any()).Return(&wordspb.NormBatchReply{Results: []*wordspb.NormResult{
+			{Id: "0", Reply: &wordspb.WordsReply{Words: []string{"robot"}}},
+			{Id: "0", Reply: &wordspb.WordsReply{Words: []string{"robot"}}},
+		}}, nil)
+
+		_, err := c.NormBatch(context.Background(), []string{"robots", "comics"})
+		assert.EqualError(t, err, `words service returned phrase "0" twice`)
+	})
+
+	t.Run("unknown result", func(t *testing.T) {
+		mockClient.EXPECT().NormBatch(gomock.Any(), gomock.Any()).Return(&wordspb.NormBatchReply{Results: []*wordspb.NormResult{
+			{Id: "7", Reply: &wordspb.WordsReply{Words: []string{"robot"}}},
+		}}, nil)
+
+		_, err := c.NormBatch(context.Background(), []string{"robots"})
+		assert.EqualError(t, err, `words service returned no result for phrase "0"`)
+	})
+
+	t.Run("missing results", func(t *testing.T) {
+		mockClient.EXPECT().NormBatch(gomock.Any(), gomock.Any()).Return(&wordspb.NormBatchReply{}, nil)
+
+		_, err := c.NormBatch(context.Background(), []string{"robots"})
+		assert.EqualError(t, err, "words service returned 0 results for 1 phrases")
+	})
+
+	t.Run("gRPC error", func(t *testing.T) {
+		mockClient.EXPECT().NormBatch(gomock.Any(), gomock.Any()).Return(nil, errors.New("gRPC error"))
+
+		_, err := c.NormBatch(context.Background(), []string{"robots"})
+		assert.EqualError(t, err, "failed to normalize words: gRPC error")
+	})
 }
 
 func TestClient_Ping(t *testing.T) {
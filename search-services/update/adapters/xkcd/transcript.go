@@ -0,0 +1,73 @@
+package xkcd
+
+import (
+	"regexp"
+	"strings"
+
+	"yadro.com/course/update/core"
+)
+
+var (
+	// blocks are scene descriptions in [[double brackets]] and notes in
+	// {{double braces}}, both may span lines
+	blocks = regexp.MustCompile(`(?s)\[\[(.*?)\]\]|\{\{(.*?)\}\}`)
+	// titleText starts the title text, which the transcript repeats after
+	// the comic
+	titleText = regexp.MustCompile(`(?i)^(?:title[ -]?text|alt[ -]?text|alt)\s*:\s*`)
+	// speakerName is the name before the colon of a dialogue line: a few
+	// words like Black Hat or Man #1, with an optional remark in parentheses
+	speakerName = regexp.MustCompile(`^(\p{L}[\p{L}\p{N}'.\-]*(?: [\p{L}\p{N}#'.\-]+){0,3})\s*(?:\([^)]*\))?$`)
+)
+
+// parseTranscript splits a transcript by the conventions of xkcd into the
+// dialogue lines with their speakers, the scene descriptions and the title
+// text. Lines said by nobody, like captions or sounds, count as scene
+// descriptions.
+func parseTranscript(text string) (dialogue []core.Line, scenes []string, title string) {
+	rest := blocks.ReplaceAllStringFunc(text, func(block string) string {
+		m := blocks.FindStringSubmatch(block)
+		inner := squeeze(m[1] + m[2])
+		if strings.HasPrefix(block, "{{") && titleText.MatchString(inner) {
+			title = titleText.ReplaceAllString(inner, "")
+		} else if inner != "" {
+			scenes = append(scenes, inner)
+		}
+		// a block inside a dialogue line leaves the rest of it said
+		return " "
+	})
+
+	for _, line := range strings.Split(rest, "\n") {
+		line = squeeze(line)
+		switch speaker, said, ok := cutSpeaker(line); {
+		case line == "":
+		case titleText.MatchString(line):
+			title = titleText.ReplaceAllString(line, "")
+		case ok:
+			if said != "" {
+				dialogue = append(dialogue, core.Line{Speaker: speaker, Text: said})
+			}
+		default:
+			scenes = append(scenes, line)
+		}
+	}
+	return dialogue, scenes, title
+}
+
+// cutSpeaker splits a dialogue line into the speaker and what is said.
+func cutSpeaker(line string) (string, string, bool) {
+	name, said, ok := strings.Cut(line, ":")
+	// a link is not a speaker
+	if !ok || strings.HasPrefix(said, "//") {
+		return "", "", false
+	}
+	m := speakerName.FindStringSubmatch(strings.TrimSpace(name))
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], strings.TrimSpace(said), true
+}
+
+// squeeze collapses runs of whitespace into single spaces.
+func squeeze(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,59 @@
+package xkcd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"yadro.com/course/update/core"
+)
+
+func TestParseTranscript(t *testing.T) {
+	tests := []struct {
+		name             string
+		transcript       string
+		expectedDialogue []core.Line
+		expectedScenes   []string
+		expectedTitle    string
+	}{
+		{
+			name: "conventions",
+			transcript: "[[Black Hat and Cueball are standing.\nCueball holds a chess piece.]]\n" +
+				"Black Hat: I play chess to win.\n" +
+				"Cueball (offscreen):   Against whom?\n" +
+				"Man #1: [[Points.]] Him.\n" +
+				"<<CRASH>>\n" +
+				"{{Title text: Checkmate is a state of mind.}}",
+			expectedDialogue: []core.Line{
+				{Speaker: "Black Hat", Text: "I play chess to win."},
+				{Speaker: "Cueball", Text: "Against whom?"},
+				{Speaker: "Man #1", Text: "Him."},
+			},
+			expectedScenes: []string{"Black Hat and Cueball are standing. Cueball holds a chess piece.", "Points.", "<<CRASH>>"},
+			expectedTitle:  "Checkmate is a state of mind.",
+		},
+		{
+			name:           "title text without braces",
+			transcript:     "The sign on the door reads: closed\nAlt-text: Sorry.",
+			expectedScenes: []string{"The sign on the door reads: closed"},
+			expectedTitle:  "Sorry.",
+		},
+		{
+			name:           "not a speaker",
+			transcript:     "See http://xkcd.com\nhttp://xkcd.com/1\n12:30 on a clock",
+			expectedScenes: []string{"See http://xkcd.com", "http://xkcd.com/1", "12:30 on a clock"},
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialogue, scenes, title := parseTranscript(tt.transcript)
+			assert.Equal(t, tt.expectedDialogue, dialogue)
+			assert.Equal(t, tt.expectedScenes, scenes)
+			assert.Equal(t, tt.expectedTitle, title)
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -41,7 +43,9 @@ func (c *Client) Get(ctx context.Context, id int) (core.XKCDInfo, error) {
 
 	if resp.StatusCode == http.StatusNotFound {
 		c.mu.Lock()
-		c.missingIDs = make(map[int]bool)
+		if c.missingIDs == nil {
+			c.missingIDs = make(map[int]bool)
+		}
 		c.missingIDs[id] = true
 		c.mu.Unlock()
 		return core.XKCDInfo{}, core.ErrNotFound
@@ -56,16 +60,25 @@ func (c *Client) Get(ctx context.Context, id int) (core.XKCDInfo, error) {
 		Title      string `json:"title"`
 		Transcript string `json:"transcript"`
 		Alt        string `json:"alt"`
+		Year       string `json:"year"`
+		Month      string `json:"month"`
+		Day        string `json:"day"`
 	}{}
 	if err = json.NewDecoder(resp.Body).Decode(&info); err != nil {
 		return core.XKCDInfo{}, fmt.Errorf("failed to decode comics: %v", err)
 	}
 
+	dialogue, scenes, titleText := parseTranscript(info.Transcript)
 	return core.XKCDInfo{
-		NUM:         info.ID,
-		URL:         info.URL,
-		Title:       info.Title,
-		Description: info.Transcript + info.Alt + info.Title,
+		NUM:        info.ID,
+		URL:        info.URL,
+		Title:      info.Title,
+		Alt:        info.Alt,
+		Transcript: info.Transcript,
+		Date:       parseDate(info.Year, info.Month, info.Day),
+		Dialogue:   dialogue,
+		Scenes:     scenes,
+		TitleText:  titleText,
 	}, nil
 }
 
@@ -89,5 +102,19 @@ func (c *Client) LastID(ctx context.Context) (int, error) {
 }
 
 func (c *Client) MissingIds(ctx context.Context) map[int]bool {
-	return c.missingIDs
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return maps.Clone(c.missingIDs)
+}
+
+// parseDate builds the publish date from xkcd's string fields, zero time if
+// any of them is missing or malformed.
+func parseDate(year, month, day string) time.Time {
+	y, errY := strconv.Atoi(year)
+	m, errM := strconv.Atoi(month)
+	d, errD := strconv.Atoi(day)
+	if errY != nil || errM != nil || errD != nil || m < 1 || m > 12 || d < 1 || d > 31 {
+		return time.Time{}
+	}
+	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
 }
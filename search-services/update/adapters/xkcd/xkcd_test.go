@@ -60,8 +60,11 @@ func TestClient_Get(t *testing.T) {
 				"num":        123,
 				"img":        "http://example.com/123.png",
 				"title":      "Test Comic",
-				"transcript": "T",
+				"transcript": "[[A room.]]\nMegan: Hi",
 				"alt":        " ",
+				"year":       "2010",
+				"month":      "3",
+				"day":        "5",
 			})
 		case "/404/info.0.json":
 			w.WriteHeader(http.StatusNotFound)
@@ -86,10 +89,14 @@ func TestClient_Get(t *testing.T) {
 			name: "successful get",
 			id:   123,
 			expected: core.XKCDInfo{
-				NUM:         123,
-				URL:         "http://example.com/123.png",
-				Title:       "Test Comic",
-				Description: "T Test Comic",
+				NUM:        123,
+				URL:        "http://example.com/123.png",
+				Title:      "Test Comic",
+				Alt:        " ",
+				Transcript: "[[A room.]]\nMegan: Hi",
+				Date:       time.Date(2010, time.March, 5, 0, 0, 0, 0, time.UTC),
+				Dialogue:   []core.Line{{Speaker: "Megan", Text: "Hi"}},
+				Scenes:     []string{"A room."},
 			},
 		},
 		{
@@ -206,14 +213,19 @@ func TestClient_MissingIds(t *testing.T) {
 	})
 
 	t.Run("with missing ids", func(t *testing.T) {
-		client, err := NewClient("http://example.com", time.Second, nil)
+		ts := httptest.NewServer(http.NotFoundHandler())
+		defer ts.Close()
+
+		client, err := NewClient(ts.URL, time.Second, nil)
 		assert.NoError(t, err)
 
 		_, _ = client.Get(context.Background(), 404)
+		_, _ = client.Get(context.Background(), 405)
 
 		missing := client.MissingIds(context.Background())
 		assert.Contains(t, missing, 404)
-		assert.Len(t, missing, 1)
+		assert.Contains(t, missing, 405)
+		assert.Len(t, missing, 2)
 	})
 }
 
@@ -225,10 +237,9 @@ func TestXKCDInterface(t *testing.T) {
 
 	t.Run("Get with mock", func(t *testing.T) {
 		expected := core.XKCDInfo{
-			NUM:         123,
-			URL:         "http://example.com/123.png",
-			Title:       "Test Comic",
-			Description: "Test Description",
+			NUM:   123,
+			URL:   "http://example.com/123.png",
+			Title: "Test Comic",
 		}
 
 		mockXKCD.EXPECT().
@@ -260,3 +271,10 @@ func TestXKCDInterface(t *testing.T) {
 		assert.Equal(t, expected, result)
 	})
 }
+
+func TestParseDate(t *testing.T) {
+	assert.Equal(t, time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC), parseDate("2006", "1", "1"))
+	assert.True(t, parseDate("", "", "").IsZero())
+	assert.True(t, parseDate("2006", "13", "1").IsZero())
+	assert.True(t, parseDate("2006", "x", "1").IsZero())
+}
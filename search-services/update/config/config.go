@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"yadro.com/course/wordsclient"
 )
 
 type XKCD struct {
@@ -15,11 +16,13 @@ type XKCD struct {
 }
 
 type Config struct {
-	LogLevel     string `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
-	Address      string `yaml:"update_address" env:"UPDATE_ADDRESS" env-default:"localhost:80"`
-	XKCD         XKCD   `yaml:"xkcd"`
-	DBAddress    string `yaml:"db_address" env:"DB_ADDRESS" env-default:"localhost:82"`
-	WordsAddress string `yaml:"words_address" env:"WORDS_ADDRESS" env-default:"localhost:81"`
+	LogLevel     string             `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
+	Address      string             `yaml:"update_address" env:"UPDATE_ADDRESS" env-default:"localhost:80"`
+	XKCD         XKCD               `yaml:"xkcd"`
+	DBAddress    string             `yaml:"db_address" env:"DB_ADDRESS" env-default:"localhost:82"`
+	WordsAddress string             `yaml:"words_address" env:"WORDS_ADDRESS" env-default:"localhost:81"`
+	WordsBatch   int                `yaml:"words_batch" env:"WORDS_BATCH" env-default:"100"`
+	WordsClient  wordsclient.Config `yaml:"words_client"`
 }
 
 func MustLoad(configPath string) Config {
@@ -15,6 +15,7 @@ log_level: INFO
 update_address: "update-service:8080"
 db_address: "db-service:5432"
 words_address: "words-service:8081"
+words_batch: 50
 xkcd:
   url: "https://xkcd-api.com"
   concurrency: 5
@@ -37,6 +38,7 @@ xkcd:
 		assert.Equal(t, "update-service:8080", cfg.Address)
 		assert.Equal(t, "db-service:5432", cfg.DBAddress)
 		assert.Equal(t, "words-service:8081", cfg.WordsAddress)
+		assert.Equal(t, 50, cfg.WordsBatch)
 
 		// Проверяем XKCD конфигурацию
 		assert.Equal(t, "https://xkcd-api.com", cfg.XKCD.URL)
@@ -227,10 +227,10 @@ func (mr *MockXKCDMockRecorder) LastID(arg0 interface{}) *gomock.Call {
 }
 
 // MissingIds mocks base method.
-func (m *MockXKCD) MissingIds(arg0 context.Context) []int {
+func (m *MockXKCD) MissingIds(arg0 context.Context) map[int]bool {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "MissingIds", arg0)
-	ret0, _ := ret[0].([]int)
+	ret0, _ := ret[0].(map[int]bool)
 	return ret0
 }
 
@@ -263,17 +263,17 @@ func (m *MockWords) EXPECT() *MockWordsMockRecorder {
 	return m.recorder
 }
 
-// Norm mocks base method.
-func (m *MockWords) Norm(ctx context.Context, phrase string) ([]string, error) {
+// NormBatch mocks base method.
+func (m *MockWords) NormBatch(ctx context.Context, phrases []string) ([]core.NormResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Norm", ctx, phrase)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "NormBatch", ctx, phrases)
+	ret0, _ := ret[0].([]core.NormResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Norm indicates an expected call of Norm.
-func (mr *MockWordsMockRecorder) Norm(ctx, phrase interface{}) *gomock.Call {
+// NormBatch indicates an expected call of NormBatch.
+func (mr *MockWordsMockRecorder) NormBatch(ctx, phrases interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Norm", reflect.TypeOf((*MockWords)(nil).Norm), ctx, phrase)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormBatch", reflect.TypeOf((*MockWords)(nil).NormBatch), ctx, phrases)
 }
@@ -1,5 +1,7 @@
 package core
 
+import "time"
+
 type ServiceStatus string
 
 const (
@@ -19,15 +21,79 @@ type ServiceStats struct {
 }
 
 type Comics struct {
-	ID    int
-	URL   string
+	ID         int
+	URL        string
+	Words      []string
+	Forms      map[string]string
+	Title      string
+	Alt        string
+	Transcript string
+	// Date is the publish date, zero if xkcd did not report it
+	Date time.Time
+
+	// normalized words of every field, so that search can weight them
+	TitleWords      []string
+	AltWords        []string
+	TranscriptWords []string
+	// tokens of every field, so that search can highlight the words it
+	// matched where the words service found them
+	TitleTokens      []Token
+	AltTokens        []Token
+	TranscriptTokens []Token
+	// Bigrams are the pairs of adjacent words of every field, so that search
+	// can match phrases
+	Bigrams []string
+
+	// the transcript split into dialogue and scene descriptions, so that
+	// search can tell who said what
+	Dialogue      []Line
+	Scenes        []string
+	TitleText     string
+	DialogueWords []string
+	SceneWords    []string
+}
+
+// Line is a line of dialogue of a transcript, Words are its normalized words.
+type Line struct {
+	Speaker string
+	Text    string
+	Words   []string
+}
+
+// Token is a normalized word of a phrase: its bytes in the phrase and the
+// term it was normalized to.
+type Token struct {
+	Start int
+	End   int
+	Term  string
+}
+
+type Terms struct {
 	Words []string
+	// Forms maps a stem to the surface word it came from
+	Forms map[string]string
+	// Bigrams are the pairs of adjacent words of the phrase joined by a space
+	Bigrams []string
+	// Tokens are the words of the phrase that were not dropped, in order
+	Tokens []Token
+}
+
+// NormResult is the normalization of one phrase of a batch, Err is set if
+// that phrase failed.
+type NormResult struct {
+	Terms
+	Err error
 }
 
 type XKCDInfo struct {
-	NUM         int
-	URL         string
-	Title       string
-	Description string
-	Alt         string
+	NUM        int
+	URL        string
+	Title      string
+	Alt        string
+	Transcript string
+	Date       time.Time
+	// Dialogue, Scenes and TitleText are parsed from the transcript
+	Dialogue  []Line
+	Scenes    []string
+	TitleText string
 }
@@ -17,6 +17,7 @@ type DB interface {
 	Add(context.Context, Comics) error
 	Stats(context.Context) (DBStats, error)
 	Drop(context.Context) error
+	// IDs returns the stored comics that need not be fetched again
 	IDs(context.Context) ([]int, error)
 }
 
@@ -27,5 +28,6 @@ type XKCD interface {
 }
 
 type Words interface {
-	Norm(ctx context.Context, phrase string) ([]string, error)
+	// NormBatch returns the results in the order of the phrases
+	NormBatch(ctx context.Context, phrases []string) ([]NormResult, error)
 }
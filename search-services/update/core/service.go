@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 )
 
@@ -14,22 +15,29 @@ type Service struct {
 	xkcd        XKCD
 	words       Words
 	concurrency int
+	batchSize   int
 	mu          sync.Mutex
 	updates     bool
 }
 
+// NewService creates the service fetching up to concurrency comics at once
+// and normalizing them batchSize comics per call to the words service.
 func NewService(
-	log *slog.Logger, db DB, xkcd XKCD, words Words, concurrency int,
+	log *slog.Logger, db DB, xkcd XKCD, words Words, concurrency, batchSize int,
 ) (*Service, error) {
 	if concurrency < 1 {
 		return nil, fmt.Errorf("wrong concurrency specified: %d", concurrency)
 	}
+	if batchSize < 1 {
+		return nil, fmt.Errorf("wrong batch size specified: %d", batchSize)
+	}
 	return &Service{
 		log:         log,
 		db:          db,
 		xkcd:        xkcd,
 		words:       words,
 		concurrency: concurrency,
+		batchSize:   batchSize,
 	}, nil
 }
 
@@ -49,60 +57,204 @@ func (s *Service) Update(ctx context.Context) (err error) {
 		existIDsMap[id] = struct{}{}
 	}
 
-	var wg sync.WaitGroup
-	sem := make(chan struct{}, s.concurrency)
 	var errFinal error
 	var once sync.Once
+	fail := func(err error) {
+		once.Do(func() {
+			errFinal = err
+		})
+	}
 
-	for id := 1; id <= lastID; id++ {
-		if _, exists := existIDsMap[id]; exists {
-			continue
-		}
+	// comics are fetched concurrently and normalized in batches, so that
+	// the words service is not called for every field of every comic
+	fetched := make(chan Comics, s.batchSize)
+	go func() {
+		defer close(fetched)
 
-		wg.Add(1)
-		sem <- struct{}{}
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, s.concurrency)
+		for id := 1; id <= lastID; id++ {
+			if _, exists := existIDsMap[id]; exists {
+				continue
+			}
+
+			wg.Add(1)
+			sem <- struct{}{}
 
-		go func(id int) {
-			defer wg.Done()
-			defer func() { <-sem }()
+			go func(id int) {
+				defer wg.Done()
+				defer func() { <-sem }()
 
-			info, err := s.xkcd.Get(ctx, id)
-			if err != nil {
-				if errors.Is(err, ErrNotFound) {
+				info, err := s.xkcd.Get(ctx, id)
+				if err != nil {
+					if errors.Is(err, ErrNotFound) {
+						return
+					}
+					fail(fmt.Errorf("failed to get comics %d: %w", id, err))
 					return
 				}
-				once.Do(func() {
-					errFinal = fmt.Errorf("failed to get comics %d: %w", id, err)
-				})
-				return
-			}
 
-			words, err := s.words.Norm(ctx, info.Title+" "+info.Description)
-			if err != nil {
-				once.Do(func() {
-					errFinal = fmt.Errorf("failed to normalize words for comics %d: %w", id, err)
-				})
+				fetched <- Comics{
+					ID:         info.NUM,
+					URL:        info.URL,
+					Title:      info.Title,
+					Alt:        info.Alt,
+					Transcript: info.Transcript,
+					Date:       info.Date,
+					Dialogue:   info.Dialogue,
+					Scenes:     info.Scenes,
+					TitleText:  info.TitleText,
+				}
+			}(id)
+		}
+		wg.Wait()
+	}()
+
+	batch := make([]Comics, 0, s.batchSize)
+	for comics := range fetched {
+		batch = append(batch, comics)
+		if len(batch) == s.batchSize {
+			s.store(ctx, batch, fail)
+			batch = batch[:0]
+		}
+	}
+	s.store(ctx, batch, fail)
+
+	return errFinal
+}
+
+// store normalizes the batch and adds its comics to the database, reporting
+// the comics that failed to fail.
+func (s *Service) store(ctx context.Context, batch []Comics, fail func(error)) {
+	if len(batch) == 0 {
+		return
+	}
+	for _, comics := range s.normalize(ctx, batch, fail) {
+		if err := s.db.Add(ctx, comics); err != nil {
+			fail(fmt.Errorf("failed to add comics %d to db: %w", comics.ID, err))
+		}
+	}
+}
+
+// normalize normalizes title, alt and transcript of all comics of the batch
+// in one call to the words service and merges their words and surface forms
+// into the whole comic ones, the title first. Scene descriptions and every
+// line of dialogue are normalized in the same call, their words are already
+// among the transcript ones. It returns the comics whose fields were all
+// normalized.
+func (s *Service) normalize(ctx context.Context, batch []Comics, fail func(error)) []Comics {
+	type field struct {
+		comics int
+		words  *[]string
+		tokens *[]Token
+		// part is a part of the transcript, not merged into the comic
+		part bool
+	}
+	var phrases []string
+	var fields []field
+	for i := range batch {
+		c := &batch[i]
+		add := func(text string, f field) {
+			if strings.TrimSpace(text) == "" {
 				return
 			}
+			f.comics = i
+			phrases = append(phrases, text)
+			fields = append(fields, f)
+		}
+		add(c.Title, field{words: &c.TitleWords, tokens: &c.TitleTokens})
+		add(c.Alt, field{words: &c.AltWords, tokens: &c.AltTokens})
+		add(c.Transcript, field{words: &c.TranscriptWords, tokens: &c.TranscriptTokens})
+		add(strings.Join(c.Scenes, "\n"), field{words: &c.SceneWords, part: true})
+		for j := range c.Dialogue {
+			add(c.Dialogue[j].Text, field{words: &c.Dialogue[j].Words, part: true})
+		}
+	}
 
-			comics := Comics{
-				ID:    info.NUM,
-				URL:   info.URL,
-				Words: words,
-			}
+	var results []NormResult
+	if len(phrases) > 0 {
+		var err error
+		results, err = s.words.NormBatch(ctx, phrases)
+		if err != nil {
+			fail(fmt.Errorf("failed to normalize words for a batch of %d comics: %w", len(batch), err))
+			return nil
+		}
+	}
 
-			if err := s.db.Add(ctx, comics); err != nil {
-				once.Do(func() {
-					errFinal = fmt.Errorf("failed to add comics %d to db: %w", id, err)
-				})
-				return
+	terms := make([][]Terms, len(batch))
+	failed := make([]bool, len(batch))
+	for i, r := range results {
+		f := fields[i]
+		if r.Err != nil {
+			if !failed[f.comics] {
+				fail(fmt.Errorf("failed to normalize words for comics %d: %w", batch[f.comics].ID, r.Err))
 			}
-		}(id)
+			failed[f.comics] = true
+			continue
+		}
+		*f.words = r.Words
+		if f.tokens != nil {
+			*f.tokens = r.Tokens
+		}
+		if !f.part {
+			terms[f.comics] = append(terms[f.comics], r.Terms)
+		}
 	}
 
-	wg.Wait()
+	normalized := make([]Comics, 0, len(batch))
+	for i := range batch {
+		if failed[i] {
+			continue
+		}
+		merge(&batch[i], terms[i])
+		batch[i].DialogueWords = dialogueWords(batch[i].Dialogue)
+		normalized = append(normalized, batch[i])
+	}
+	return normalized
+}
 
-	return errFinal
+// dialogueWords returns the distinct words of all lines of the dialogue.
+func dialogueWords(dialogue []Line) []string {
+	var words []string
+	seen := make(map[string]struct{})
+	for _, line := range dialogue {
+		for _, word := range line.Words {
+			if _, ok := seen[word]; !ok {
+				seen[word] = struct{}{}
+				words = append(words, word)
+			}
+		}
+	}
+	return words
+}
+
+// merge adds the words, bigrams and forms of the fields to the comic ones,
+// keeping the first form of every stem.
+func merge(comics *Comics, fields []Terms) {
+	seen := make(map[string]struct{})
+	seenBigrams := make(map[string]struct{})
+	for _, terms := range fields {
+		for _, word := range terms.Words {
+			if _, ok := seen[word]; !ok {
+				seen[word] = struct{}{}
+				comics.Words = append(comics.Words, word)
+			}
+		}
+		for _, bigram := range terms.Bigrams {
+			if _, ok := seenBigrams[bigram]; !ok {
+				seenBigrams[bigram] = struct{}{}
+				comics.Bigrams = append(comics.Bigrams, bigram)
+			}
+		}
+		for stem, form := range terms.Forms {
+			if comics.Forms == nil {
+				comics.Forms = make(map[string]string)
+			}
+			if _, ok := comics.Forms[stem]; !ok {
+				comics.Forms[stem] = form
+			}
+		}
+	}
 }
 
 func (s *Service) Stats(ctx context.Context) (ServiceStats, error) {
@@ -3,7 +3,9 @@ package core_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 	"yadro.com/course/update/core"
 
 	"github.com/golang/mock/gomock"
@@ -16,22 +18,31 @@ func TestNewService(t *testing.T) {
 	tests := []struct {
 		name        string
 		concurrency int
+		batchSize   int
 		expectedErr string
 	}{
 		{
 			name:        "successful creation",
 			concurrency: 5,
+			batchSize:   100,
 		},
 		{
 			name:        "zero concurrency",
 			concurrency: 0,
+			batchSize:   100,
 			expectedErr: "wrong concurrency specified: 0",
 		},
 		{
 			name:        "negative concurrency",
 			concurrency: -1,
+			batchSize:   100,
 			expectedErr: "wrong concurrency specified: -1",
 		},
+		{
+			name:        "zero batch size",
+			concurrency: 5,
+			expectedErr: "wrong batch size specified: 0",
+		},
 	}
 
 	for _, tt := range tests {
@@ -43,7 +54,7 @@ func TestNewService(t *testing.T) {
 			mockXKCD := mocks.NewMockXKCD(ctrl)
 			mockWords := mocks.NewMockWords(ctrl)
 
-			service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, tt.concurrency)
+			service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, tt.concurrency, tt.batchSize)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
@@ -72,30 +83,61 @@ func TestService_Update(t *testing.T) {
 
 				// Comics 2
 				xkcd.EXPECT().Get(gomock.Any(), 2).Return(core.XKCDInfo{
-					NUM:         2,
-					URL:         "http://example.com/2",
-					Title:       "Test 2",
-					Description: "Description 2",
+					NUM:        2,
+					URL:        "http://example.com/2",
+					Title:      "Test 2",
+					Alt:        "Alt 2",
+					Transcript: "Transcript 2",
+					Date:       time.Date(2007, time.May, 2, 0, 0, 0, 0, time.UTC),
+				}, nil)
+				words.EXPECT().NormBatch(gomock.Any(), []string{"Test 2", "Alt 2", "Transcript 2"}).Return([]core.NormResult{
+					{Terms: core.Terms{
+						Words:   []string{"test", "two"},
+						Forms:   map[string]string{"test": "test", "two": "2"},
+						Bigrams: []string{"test two"},
+						Tokens:  []core.Token{{Start: 0, End: 4, Term: "test"}, {Start: 5, End: 6, Term: "two"}},
+					}},
+					{Terms: core.Terms{
+						Words:   []string{"alt", "two"},
+						Forms:   map[string]string{"alt": "alt", "two": "two"},
+						Bigrams: []string{"alt two"},
+					}},
+					{Terms: core.Terms{
+						Words:   []string{"transcript", "two"},
+						Bigrams: []string{"transcript two", "test two"},
+					}},
 				}, nil)
-				words.EXPECT().Norm(gomock.Any(), "Test 2 Description 2").Return([]string{"test", "two"}, nil)
 				db.EXPECT().Add(gomock.Any(), core.Comics{
-					ID:    2,
-					URL:   "http://example.com/2",
-					Words: []string{"test", "two"},
+					ID:              2,
+					URL:             "http://example.com/2",
+					Words:           []string{"test", "two", "alt", "transcript"},
+					Forms:           map[string]string{"test": "test", "two": "2", "alt": "alt"},
+					Title:           "Test 2",
+					Alt:             "Alt 2",
+					Transcript:      "Transcript 2",
+					Date:            time.Date(2007, time.May, 2, 0, 0, 0, 0, time.UTC),
+					TitleWords:      []string{"test", "two"},
+					AltWords:        []string{"alt", "two"},
+					TranscriptWords: []string{"transcript", "two"},
+					TitleTokens:     []core.Token{{Start: 0, End: 4, Term: "test"}, {Start: 5, End: 6, Term: "two"}},
+					Bigrams:         []string{"test two", "alt two", "transcript two"},
 				}).Return(nil)
 
 				// Comics 3
 				xkcd.EXPECT().Get(gomock.Any(), 3).Return(core.XKCDInfo{
-					NUM:         3,
-					URL:         "http://example.com/3",
-					Title:       "Test 3",
-					Description: "Description 3",
+					NUM:   3,
+					URL:   "http://example.com/3",
+					Title: "Test 3",
+				}, nil)
+				words.EXPECT().NormBatch(gomock.Any(), []string{"Test 3"}).Return([]core.NormResult{
+					{Terms: core.Terms{Words: []string{"test", "three"}}},
 				}, nil)
-				words.EXPECT().Norm(gomock.Any(), "Test 3 Description 3").Return([]string{"test", "three"}, nil)
 				db.EXPECT().Add(gomock.Any(), core.Comics{
-					ID:    3,
-					URL:   "http://example.com/3",
-					Words: []string{"test", "three"},
+					ID:         3,
+					URL:        "http://example.com/3",
+					Words:      []string{"test", "three"},
+					Title:      "Test 3",
+					TitleWords: []string{"test", "three"},
 				}).Return(nil)
 			},
 		},
@@ -147,13 +189,29 @@ func TestService_Update(t *testing.T) {
 				xkcd.EXPECT().LastID(gomock.Any()).Return(2, nil)
 				db.EXPECT().IDs(gomock.Any()).Return([]int{1}, nil)
 				xkcd.EXPECT().Get(gomock.Any(), 2).Return(core.XKCDInfo{
-					NUM:         2,
-					Title:       "Test",
-					Description: "Desc",
+					NUM:   2,
+					Title: "Test",
+				}, nil)
+				words.EXPECT().NormBatch(gomock.Any(), []string{"Test"}).Return(nil, errors.New("norm error"))
+			},
+			expectedErr: "failed to normalize words for a batch of 1 comics: norm error",
+		},
+		{
+			name: "error normalizing a phrase",
+			mockSetup: func(db *mocks.MockDB, xkcd *mocks.MockXKCD, words *mocks.MockWords) {
+				xkcd.EXPECT().LastID(gomock.Any()).Return(2, nil)
+				db.EXPECT().IDs(gomock.Any()).Return([]int{1}, nil)
+				xkcd.EXPECT().Get(gomock.Any(), 2).Return(core.XKCDInfo{
+					NUM:        2,
+					Title:      "Test",
+					Transcript: "Long transcript",
+				}, nil)
+				words.EXPECT().NormBatch(gomock.Any(), []string{"Test", "Long transcript"}).Return([]core.NormResult{
+					{Terms: core.Terms{Words: []string{"test"}}},
+					{Err: errors.New("too large")},
 				}, nil)
-				words.EXPECT().Norm(gomock.Any(), "Test Desc").Return(nil, errors.New("norm error"))
 			},
-			expectedErr: "failed to normalize words for comics 2: norm error",
+			expectedErr: "failed to normalize words for comics 2: too large",
 		},
 		{
 			name: "error adding to db",
@@ -161,16 +219,19 @@ func TestService_Update(t *testing.T) {
 				xkcd.EXPECT().LastID(gomock.Any()).Return(2, nil)
 				db.EXPECT().IDs(gomock.Any()).Return([]int{1}, nil)
 				xkcd.EXPECT().Get(gomock.Any(), 2).Return(core.XKCDInfo{
-					NUM:         2,
-					URL:         "http://example.com/2",
-					Title:       "Test",
-					Description: "Desc",
+					NUM:   2,
+					URL:   "http://example.com/2",
+					Title: "Test",
+				}, nil)
+				words.EXPECT().NormBatch(gomock.Any(), []string{"Test"}).Return([]core.NormResult{
+					{Terms: core.Terms{Words: []string{"test"}}},
 				}, nil)
-				words.EXPECT().Norm(gomock.Any(), "Test Desc").Return([]string{"test"}, nil)
 				db.EXPECT().Add(gomock.Any(), core.Comics{
-					ID:    2,
-					URL:   "http://example.com/2",
-					Words: []string{"test"},
+					ID:         2,
+					URL:        "http://example.com/2",
+					Words:      []string{"test"},
+					Title:      "Test",
+					TitleWords: []string{"test"},
 				}).Return(errors.New("add error"))
 			},
 			expectedErr: "failed to add comics 2 to db: add error",
@@ -190,7 +251,7 @@ func TestService_Update(t *testing.T) {
 				tt.mockSetup(mockDB, mockXKCD, mockWords)
 			}
 
-			service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 2)
+			service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 2, 1)
 			assert.NoError(t, err)
 
 			err = service.Update(context.Background())
@@ -205,6 +266,103 @@ func TestService_Update(t *testing.T) {
 	}
 }
 
+func TestService_UpdateBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockXKCD := mocks.NewMockXKCD(ctrl)
+	mockWords := mocks.NewMockWords(ctrl)
+
+	mockXKCD.EXPECT().LastID(gomock.Any()).Return(4, nil)
+	mockDB.EXPECT().IDs(gomock.Any()).Return(nil, nil)
+	for id := 1; id <= 4; id++ {
+		mockXKCD.EXPECT().Get(gomock.Any(), id).Return(core.XKCDInfo{
+			NUM:   id,
+			Title: fmt.Sprintf("Title %d", id),
+			Alt:   fmt.Sprintf("Alt %d", id),
+		}, nil)
+	}
+
+	// comics 1-3 make a full batch, comics 4 is left for the last one; the
+	// alt of comics 2 fails, so only comics 2 is not added
+	gomock.InOrder(
+		mockWords.EXPECT().
+			NormBatch(gomock.Any(), []string{"Title 1", "Alt 1", "Title 2", "Alt 2", "Title 3", "Alt 3"}).
+			Return([]core.NormResult{
+				{Terms: core.Terms{Words: []string{"title", "1"}}},
+				{Terms: core.Terms{Words: []string{"alt", "1"}}},
+				{Terms: core.Terms{Words: []string{"title", "2"}}},
+				{Err: errors.New("too large")},
+				{Terms: core.Terms{Words: []string{"title", "3"}}},
+				{Terms: core.Terms{Words: []string{"alt", "3"}}},
+			}, nil),
+		mockWords.EXPECT().
+			NormBatch(gomock.Any(), []string{"Title 4", "Alt 4"}).
+			Return([]core.NormResult{
+				{Terms: core.Terms{Words: []string{"title", "4"}}},
+				{Terms: core.Terms{Words: []string{"alt", "4"}}},
+			}, nil),
+	)
+	var added []int
+	mockDB.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c core.Comics) error {
+		assert.Equal(t, []string{"title", fmt.Sprint(c.ID), "alt"}, c.Words)
+		added = append(added, c.ID)
+		return nil
+	}).Times(3)
+
+	service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 1, 3)
+	assert.NoError(t, err)
+
+	err = service.Update(context.Background())
+	assert.EqualError(t, err, "failed to normalize words for comics 2: too large")
+	assert.Equal(t, []int{1, 3, 4}, added)
+}
+
+func TestService_UpdateDialogue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockXKCD := mocks.NewMockXKCD(ctrl)
+	mockWords := mocks.NewMockWords(ctrl)
+
+	mockXKCD.EXPECT().LastID(gomock.Any()).Return(1, nil)
+	mockDB.EXPECT().IDs(gomock.Any()).Return(nil, nil)
+	mockXKCD.EXPECT().Get(gomock.Any(), 1).Return(core.XKCDInfo{
+		NUM:        1,
+		Transcript: "[[A board.]]\nBlack Hat: Chess\nCueball: Chess, really?",
+		Scenes:     []string{"A board."},
+		Dialogue:   []core.Line{{Speaker: "Black Hat", Text: "Chess"}, {Speaker: "Cueball", Text: "Chess, really?"}},
+		TitleText:  "Mate.",
+	}, nil)
+	mockWords.EXPECT().
+		NormBatch(gomock.Any(), []string{"[[A board.]]\nBlack Hat: Chess\nCueball: Chess, really?", "A board.", "Chess", "Chess, really?"}).
+		Return([]core.NormResult{
+			{Terms: core.Terms{Words: []string{"board", "black", "hat", "chess", "cuebal", "realli"}, Bigrams: []string{"black hat"}}},
+			{Terms: core.Terms{Words: []string{"board"}}},
+			{Terms: core.Terms{Words: []string{"chess"}}},
+			{Terms: core.Terms{Words: []string{"chess", "realli"}, Bigrams: []string{"chess realli"}}},
+		}, nil)
+	mockDB.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c core.Comics) error {
+		// parts of the transcript are not merged into the comic
+		assert.Equal(t, []string{"board", "black", "hat", "chess", "cuebal", "realli"}, c.Words)
+		assert.Equal(t, []string{"black hat"}, c.Bigrams)
+		assert.Equal(t, []string{"board"}, c.SceneWords)
+		assert.Equal(t, []string{"chess", "realli"}, c.DialogueWords)
+		assert.Equal(t, []core.Line{
+			{Speaker: "Black Hat", Text: "Chess", Words: []string{"chess"}},
+			{Speaker: "Cueball", Text: "Chess, really?", Words: []string{"chess", "realli"}},
+		}, c.Dialogue)
+		assert.Equal(t, "Mate.", c.TitleText)
+		return nil
+	})
+
+	service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 1, 10)
+	assert.NoError(t, err)
+	assert.NoError(t, service.Update(context.Background()))
+}
+
 func TestService_Stats(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -221,7 +379,7 @@ func TestService_Stats(t *testing.T) {
 					ComicsFetched: 10,
 				}, nil)
 				xkcd.EXPECT().LastID(gomock.Any()).Return(15, nil)
-				xkcd.EXPECT().MissingIds(gomock.Any()).Return([]int{404, 405})
+				xkcd.EXPECT().MissingIds(gomock.Any()).Return(map[int]bool{404: true, 405: true})
 			},
 			expected: core.ServiceStats{
 				DBStats: core.DBStats{
@@ -266,7 +424,7 @@ func TestService_Stats(t *testing.T) {
 				tt.mockSetup(mockDB, mockXKCD)
 			}
 
-			service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 1)
+			service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 1, 1)
 			assert.NoError(t, err)
 
 			stats, err := service.Stats(context.Background())
@@ -290,7 +448,7 @@ func TestService_Status(t *testing.T) {
 	mockXKCD := mocks.NewMockXKCD(ctrl)
 	mockWords := mocks.NewMockWords(ctrl)
 
-	service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 1)
+	service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 1, 1)
 	assert.NoError(t, err)
 
 	assert.Equal(t, core.StatusIdle, service.Status(context.Background()))
@@ -330,7 +488,7 @@ func TestService_Drop(t *testing.T) {
 				tt.mockSetup(mockDB)
 			}
 
-			service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 1)
+			service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 1, 1)
 			assert.NoError(t, err)
 
 			err = service.Drop(context.Background())
@@ -356,7 +514,7 @@ func TestService_Count(t *testing.T) {
 			name: "successful count",
 			mockSetup: func(xkcd *mocks.MockXKCD) {
 				xkcd.EXPECT().LastID(gomock.Any()).Return(10, nil)
-				xkcd.EXPECT().MissingIds(gomock.Any()).Return([]int{404, 405})
+				xkcd.EXPECT().MissingIds(gomock.Any()).Return(map[int]bool{404: true, 405: true})
 			},
 			expected: 8,
 		},
@@ -382,7 +540,7 @@ func TestService_Count(t *testing.T) {
 				tt.mockSetup(mockXKCD)
 			}
 
-			service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 1)
+			service, err := core.NewService(nil, mockDB, mockXKCD, mockWords, 1, 1)
 			assert.NoError(t, err)
 
 			count, err := service.Count(context.Background())
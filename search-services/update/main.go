@@ -56,13 +56,13 @@ func run(cfg config.Config, log *slog.Logger) error {
 	}
 
 	// words adapter
-	wordsClient, err := words.NewClient(cfg.WordsAddress, log)
+	wordsClient, err := words.NewClient(cfg.WordsAddress, cfg.WordsClient, log)
 	if err != nil {
 		return fmt.Errorf("failed to create Words client: %w", err)
 	}
 
 	// service
-	updater, err := core.NewService(log, storage, xkcdClient, wordsClient, cfg.XKCD.Concurrency)
+	updater, err := core.NewService(log, storage, xkcdClient, wordsClient, cfg.XKCD.Concurrency, cfg.WordsBatch)
 	if err != nil {
 		return fmt.Errorf("failed to create Update service: %w", err)
 	}
@@ -0,0 +1,317 @@
+package dictionary
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"yadro.com/course/words/words"
+)
+
+// Sources of the changes of the dictionaries.
+const (
+	SourceStartup = "startup"
+	SourceSignal  = "signal"
+	SourceFile    = "file"
+	SourceUpload  = "upload"
+)
+
+var ErrBadWord = errors.New("bad word")
+
+type Store interface {
+	Load() ([]string, error)
+	// Stage writes the words aside, leaving the store as it is until the
+	// change is committed.
+	Stage([]string) (Staged, error)
+	Stamp() (time.Time, int64)
+}
+
+// Staged is a change of a store written aside.
+type Staged interface {
+	// Commit puts the change in place of the words of the store.
+	Commit() error
+	// Discard drops the change. Committed changes are not affected.
+	Discard()
+}
+
+// Version identifies the active dictionaries: the number grows with every
+// change and the hash is of the words themselves.
+type Version struct {
+	Number    uint64
+	Hash      string
+	UpdatedAt time.Time
+	Source    string
+	StopWords int
+	Protected int
+}
+
+// Record is an entry of the audit log, one per change of the dictionaries.
+type Record struct {
+	Time             time.Time `json:"time"`
+	Version          uint64    `json:"version"`
+	Hash             string    `json:"hash"`
+	Source           string    `json:"source"`
+	Author           string    `json:"author,omitempty"`
+	StopWordsAdded   []string  `json:"stop_words_added,omitempty"`
+	StopWordsRemoved []string  `json:"stop_words_removed,omitempty"`
+	ProtectedAdded   []string  `json:"protected_added,omitempty"`
+	ProtectedRemoved []string  `json:"protected_removed,omitempty"`
+}
+
+type stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Manager keeps the stop words and protected words of a Normalizer in two
+// stores, applies their changes and writes them to the audit log.
+type Manager struct {
+	log       *slog.Logger
+	norm      *words.Normalizer
+	stopWords Store
+	protected Store
+	// audit gets a JSON line per change, nil to only log them
+	audit io.Writer
+	now   func() time.Time
+
+	mu      sync.Mutex
+	current words.Dictionaries
+	version Version
+	stamps  [2]stamp
+}
+
+func New(log *slog.Logger, norm *words.Normalizer, stopWords, protected Store, audit io.Writer) *Manager {
+	return &Manager{
+		log:       log,
+		norm:      norm,
+		stopWords: stopWords,
+		protected: protected,
+		audit:     audit,
+		now:       time.Now,
+	}
+}
+
+// Reload reads the stores again, keeping the dictionaries if they fail.
+func (m *Manager) Reload(source string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stamps := m.readStamps()
+	stopWords, err := m.stopWords.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load stop words: %w", err)
+	}
+	protected, err := m.protected.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load protected words: %w", err)
+	}
+	d, err := clean(words.Dictionaries{StopWords: stopWords, Protected: protected})
+	if err != nil {
+		return err
+	}
+	m.stamps = stamps
+	m.apply(d, source, "")
+	return nil
+}
+
+// Set saves the dictionaries to the stores and applies them. Both stores are
+// written before either is changed, so that a failed write applies nothing.
+func (m *Manager) Set(d words.Dictionaries, author string) (Version, error) {
+	d, err := clean(d)
+	if err != nil {
+		return Version{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stopWords, err := m.stopWords.Stage(d.StopWords)
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to save stop words: %w", err)
+	}
+	protected, err := m.protected.Stage(d.Protected)
+	if err != nil {
+		stopWords.Discard()
+		return Version{}, fmt.Errorf("failed to save protected words: %w", err)
+	}
+	if err := stopWords.Commit(); err != nil {
+		stopWords.Discard()
+		protected.Discard()
+		return Version{}, fmt.Errorf("failed to save stop words: %w", err)
+	}
+	if err := protected.Commit(); err != nil {
+		protected.Discard()
+		m.restoreStopWords()
+		return Version{}, fmt.Errorf("failed to save protected words: %w", err)
+	}
+	m.stamps = m.readStamps()
+	m.apply(d, SourceUpload, author)
+	return m.version, nil
+}
+
+// restoreStopWords puts the active stop words back into their store after
+// the protected words failed to replace theirs, so that the stores keep the
+// active dictionaries. It is called under the lock.
+func (m *Manager) restoreStopWords() {
+	staged, err := m.stopWords.Stage(m.current.StopWords)
+	if err == nil {
+		if err = staged.Commit(); err != nil {
+			staged.Discard()
+		}
+	}
+	if err != nil {
+		m.log.Error("failed to restore stop words", "error", err)
+	}
+}
+
+func (m *Manager) Dictionaries() words.Dictionaries {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current
+}
+
+func (m *Manager) Version() Version {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.version
+}
+
+// Watch reloads the dictionaries when their files change until the context
+// is done.
+func (m *Manager) Watch(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		m.mu.Lock()
+		changed := m.readStamps() != m.stamps
+		m.mu.Unlock()
+		if !changed {
+			continue
+		}
+		if err := m.Reload(SourceFile); err != nil {
+			m.log.Error("failed to reload dictionaries", "error", err)
+		}
+	}
+}
+
+func (m *Manager) readStamps() [2]stamp {
+	var stamps [2]stamp
+	stamps[0].modTime, stamps[0].size = m.stopWords.Stamp()
+	stamps[1].modTime, stamps[1].size = m.protected.Stamp()
+	return stamps
+}
+
+// apply makes the dictionaries active unless they are the active ones
+// already. It is called under the lock.
+func (m *Manager) apply(d words.Dictionaries, source, author string) {
+	h := hash(d)
+	if m.version.Number > 0 && h == m.version.Hash {
+		return
+	}
+
+	record := Record{
+		Time:    m.now().UTC(),
+		Version: m.version.Number + 1,
+		Hash:    h,
+		Source:  source,
+		Author:  author,
+	}
+	record.StopWordsAdded, record.StopWordsRemoved = diff(m.current.StopWords, d.StopWords)
+	record.ProtectedAdded, record.ProtectedRemoved = diff(m.current.Protected, d.Protected)
+
+	m.norm.SetDictionaries(d)
+	m.current = d
+	m.version = Version{
+		Number:    record.Version,
+		Hash:      h,
+		UpdatedAt: record.Time,
+		Source:    source,
+		StopWords: len(d.StopWords),
+		Protected: len(d.Protected),
+	}
+
+	m.log.Info("dictionaries changed",
+		"version", record.Version, "hash", h, "source", source, "author", author,
+		"stop_words_added", record.StopWordsAdded, "stop_words_removed", record.StopWordsRemoved,
+		"protected_added", record.ProtectedAdded, "protected_removed", record.ProtectedRemoved)
+	if m.audit == nil {
+		return
+	}
+	if err := json.NewEncoder(m.audit).Encode(record); err != nil {
+		m.log.Error("failed to write audit record", "version", record.Version, "error", err)
+	}
+}
+
+// clean trims the words and drops empty and repeated ones; a word must not
+// have spaces inside.
+func clean(d words.Dictionaries) (words.Dictionaries, error) {
+	var err error
+	if d.StopWords, err = cleanList(d.StopWords); err != nil {
+		return words.Dictionaries{}, err
+	}
+	if d.Protected, err = cleanList(d.Protected); err != nil {
+		return words.Dictionaries{}, err
+	}
+	return d, nil
+}
+
+func cleanList(list []string) ([]string, error) {
+	var res []string
+	seen := make(map[string]bool)
+	for _, w := range list {
+		w = strings.TrimSpace(w)
+		if w == "" || seen[w] {
+			continue
+		}
+		if strings.IndexFunc(w, unicode.IsSpace) >= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrBadWord, w)
+		}
+		seen[w] = true
+		res = append(res, w)
+	}
+	return res, nil
+}
+
+func hash(d words.Dictionaries) string {
+	h := sha256.New()
+	for _, w := range d.StopWords {
+		fmt.Fprintf(h, "s:%s\n", w)
+	}
+	for _, w := range d.Protected {
+		fmt.Fprintf(h, "p:%s\n", w)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// diff returns the words of next missing from prev and the words of prev
+// missing from next.
+func diff(prev, next []string) ([]string, []string) {
+	var added, removed []string
+	for _, w := range next {
+		if !slices.Contains(prev, w) {
+			added = append(added, w)
+		}
+	}
+	for _, w := range prev {
+		if !slices.Contains(next, w) {
+			removed = append(removed, w)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,184 @@
+package dictionary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"yadro.com/course/words/words"
+)
+
+func newManager(t *testing.T) (*Manager, *words.Normalizer, string, *bytes.Buffer) {
+	norm, err := words.New(words.Config{})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	var audit bytes.Buffer
+	m := New(slog.Default(),
+		norm,
+		NewFile(filepath.Join(dir, "stop_words.txt")),
+		NewFile(filepath.Join(dir, "protected.txt")),
+		&audit)
+	return m, norm, dir, &audit
+}
+
+func records(t *testing.T, audit *bytes.Buffer) []Record {
+	var res []Record
+	dec := json.NewDecoder(audit)
+	for dec.More() {
+		var r Record
+		require.NoError(t, dec.Decode(&r))
+		res = append(res, r)
+	}
+	return res
+}
+
+func TestManager_Reload(t *testing.T) {
+	m, norm, dir, audit := newManager(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stop_words.txt"), []byte("comic\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "protected.txt"), []byte("python\n"), 0o644))
+
+	require.NoError(t, m.Reload(SourceStartup))
+	assert.Equal(t, []string{"python", "robot"}, norm.Norm("comics pythons robots", "", ""))
+	v := m.Version()
+	assert.Equal(t, uint64(1), v.Number)
+	assert.Equal(t, SourceStartup, v.Source)
+	assert.Equal(t, 1, v.StopWords)
+	assert.Equal(t, 1, v.Protected)
+
+	// the same words are not a new version
+	require.NoError(t, m.Reload(SourceSignal))
+	assert.Equal(t, v, m.Version())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stop_words.txt"), []byte("two words\n"), 0o644))
+	assert.ErrorIs(t, m.Reload(SourceSignal), ErrBadWord)
+	assert.Equal(t, v, m.Version())
+
+	rs := records(t, audit)
+	require.Len(t, rs, 1)
+	assert.Equal(t, []string{"comic"}, rs[0].StopWordsAdded)
+	assert.Equal(t, []string{"python"}, rs[0].ProtectedAdded)
+}
+
+func TestManager_Set(t *testing.T) {
+	m, norm, dir, audit := newManager(t)
+	require.NoError(t, m.Reload(SourceStartup))
+
+	v, err := m.Set(words.Dictionaries{StopWords: []string{" comic ", "comic", "panel"}, Protected: []string{"python"}}, "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), v.Number)
+	assert.Equal(t, SourceUpload, v.Source)
+	assert.Equal(t, words.Dictionaries{StopWords: []string{"comic", "panel"}, Protected: []string{"python"}}, m.Dictionaries())
+	assert.Equal(t, []string{"python"}, norm.Norm("comics pythons", "", ""))
+
+	data, err := os.ReadFile(filepath.Join(dir, "stop_words.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "comic\npanel\n", string(data))
+
+	_, err = m.Set(words.Dictionaries{StopWords: []string{"panel"}}, "127.0.0.1")
+	require.NoError(t, err)
+
+	_, err = m.Set(words.Dictionaries{Protected: []string{"a b"}}, "127.0.0.1")
+	assert.ErrorIs(t, err, ErrBadWord)
+
+	rs := records(t, audit)
+	require.Len(t, rs, 3)
+	assert.Equal(t, "127.0.0.1", rs[2].Author)
+	assert.Equal(t, uint64(3), rs[2].Version)
+	assert.Equal(t, []string{"comic"}, rs[2].StopWordsRemoved)
+	assert.Equal(t, []string{"python"}, rs[2].ProtectedRemoved)
+	assert.Empty(t, rs[2].StopWordsAdded)
+}
+
+// failingCommit is a store whose changes fail to be put in place.
+type failingCommit struct {
+	*File
+}
+
+func (f failingCommit) Stage(list []string) (Staged, error) {
+	staged, err := f.File.Stage(list)
+	return failingStaged{staged}, err
+}
+
+type failingStaged struct {
+	Staged
+}
+
+func (failingStaged) Commit() error { return errors.New("read-only file system") }
+
+func TestManager_SetFailure(t *testing.T) {
+	norm, err := words.New(words.Config{})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	stopWords := filepath.Join(dir, "stop_words.txt")
+	require.NoError(t, os.WriteFile(stopWords, []byte("comic\n"), 0o644))
+
+	for name, protected := range map[string]Store{
+		"write":  NewFile(filepath.Join(dir, "missing", "protected.txt")),
+		"rename": failingCommit{NewFile(filepath.Join(dir, "protected.txt"))},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var audit bytes.Buffer
+			m := New(slog.Default(), norm, NewFile(stopWords), protected, &audit)
+			require.NoError(t, m.Reload(SourceStartup))
+			v := m.Version()
+
+			_, err := m.Set(words.Dictionaries{StopWords: []string{"panel"}, Protected: []string{"python"}}, "admin")
+			require.Error(t, err)
+			assert.Equal(t, v, m.Version())
+			assert.Equal(t, words.Dictionaries{StopWords: []string{"comic"}}, m.Dictionaries())
+			assert.Equal(t, []string{"panel"}, norm.Norm("comic panels", "", ""))
+
+			data, err := os.ReadFile(stopWords)
+			require.NoError(t, err)
+			assert.Equal(t, "comic\n", string(data))
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, entries, 1)
+			assert.Len(t, records(t, &audit), 1)
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestManager_AuditFailure(t *testing.T) {
+	norm, err := words.New(words.Config{})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	m := New(slog.Default(), norm, NewFile(filepath.Join(dir, "s.txt")), NewFile(filepath.Join(dir, "p.txt")), failingWriter{})
+
+	_, err = m.Set(words.Dictionaries{StopWords: []string{"comic"}}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"robot"}, norm.Norm("comic robot", "", ""))
+}
+
+func TestManager_Watch(t *testing.T) {
+	m, norm, dir, _ := newManager(t)
+	require.NoError(t, m.Reload(SourceStartup))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.Watch(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stop_words.txt"), []byte("robot\n"), 0o644))
+	assert.Eventually(t, func() bool {
+		return m.Version().Source == SourceFile
+	}, time.Second, 10*time.Millisecond)
+	assert.Empty(t, norm.Norm("robots", "", ""))
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,97 @@
+package dictionary
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// File keeps a word list in a text file, one word per line. Empty lines and
+// comments starting with # are skipped. A missing file has no words.
+type File struct {
+	path string
+}
+
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+func (f *File) Load() ([]string, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list = append(list, line)
+	}
+	return list, scanner.Err()
+}
+
+// Stage writes the words to a temporary file in the directory of the file,
+// so that committing renames it over the file and a reader sees either the
+// old words or the new ones. The directory, not the file itself, has to be
+// mounted into a container for the rename to work.
+func (f *File) Stage(list []string) (Staged, error) {
+	var b bytes.Buffer
+	for _, word := range list {
+		b.WriteString(word)
+		b.WriteByte('\n')
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), "."+filepath.Base(f.path)+".*")
+	if err != nil {
+		return nil, err
+	}
+	_, err = tmp.Write(b.Bytes())
+	if err == nil {
+		err = tmp.Chmod(0o644)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return nil, err
+	}
+	return stagedFile{tmp: tmp.Name(), path: f.path}, nil
+}
+
+type stagedFile struct {
+	tmp  string
+	path string
+}
+
+func (s stagedFile) Commit() error {
+	return os.Rename(s.tmp, s.path)
+}
+
+func (s stagedFile) Discard() {
+	_ = os.Remove(s.tmp)
+}
+
+// Stamp is the modification time and the size of the file, zero for a
+// missing file, to notice changes.
+func (f *File) Stamp() (time.Time, int64) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return time.Time{}, 0
+	}
+	return info.ModTime(), info.Size()
+}
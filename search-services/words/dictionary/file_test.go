@@ -0,0 +1,48 @@
+package dictionary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stop_words.txt")
+	f := NewFile(path)
+
+	list, err := f.Load()
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	modTime, size := f.Stamp()
+	assert.True(t, modTime.IsZero())
+	assert.Zero(t, size)
+
+	require.NoError(t, os.WriteFile(path, []byte("# noise words\ncomic\n\n  panel \n"), 0o644))
+	list, err = f.Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"comic", "panel"}, list)
+
+	staged, err := f.Stage([]string{"xkcd"})
+	require.NoError(t, err)
+	staged.Discard()
+	list, err = f.Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"comic", "panel"}, list)
+
+	staged, err = f.Stage([]string{"xkcd", "comic"})
+	require.NoError(t, err)
+	require.NoError(t, staged.Commit())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "xkcd\ncomic\n", string(data))
+	_, size = f.Stamp()
+	assert.Equal(t, int64(len(data)), size)
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"os"
@@ -18,21 +20,35 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	wordspb "yadro.com/course/proto/words"
+	"yadro.com/course/words/dictionary"
 	"yadro.com/course/words/words"
 )
 
 const (
 	maxPhraseLen    = 20480
+	maxBatchLen     = 1000
 	maxShutdownTime = 5 * time.Second
 )
 
 type Config struct {
-	Port string `yaml:"words_address" env:"WORDS_ADDRESS" env-default:"8080"`
+	Port         string       `yaml:"words_address" env:"WORDS_ADDRESS" env-default:"8080"`
+	Tokenizer    words.Config `yaml:"tokenizer"`
+	Dictionaries Dictionaries `yaml:"dictionaries"`
+}
+
+type Dictionaries struct {
+	StopWordsFile string `yaml:"stop_words_file" env:"STOP_WORDS_FILE" env-default:"stop_words.txt"`
+	ProtectedFile string `yaml:"protected_file" env:"PROTECTED_FILE" env-default:"protected.txt"`
+	// AuditFile gets a JSON line per change, empty to only log them
+	AuditFile   string        `yaml:"audit_file" env:"DICTIONARIES_AUDIT_FILE"`
+	CheckPeriod time.Duration `yaml:"check_period" env:"DICTIONARIES_CHECK_PERIOD" env-default:"10s"`
 }
 
 type server struct {
 	wordspb.UnimplementedWordsServer
-	log *slog.Logger
+	log   *slog.Logger
+	norm  *words.Normalizer
+	dicts *dictionary.Manager
 }
 
 func (s *server) Ping(_ context.Context, in *emptypb.Empty) (*emptypb.Empty, error) {
@@ -40,15 +56,171 @@ func (s *server) Ping(_ context.Context, in *emptypb.Empty) (*emptypb.Empty, err
 }
 
 func (s *server) Norm(_ context.Context, in *wordspb.WordsRequest) (*wordspb.WordsReply, error) {
-	s.log.Debug("norm request", "phrase", in.Phrase)
+	s.log.Debug("norm request", "phrase", in.Phrase, "lang", in.Lang)
+	return s.normPhrase(in.GetPhrase(), in.GetLang(), in.GetMode(), in.GetBigrams())
+}
+
+func (s *server) NormDetailed(_ context.Context, in *wordspb.WordsRequest) (*wordspb.DetailedReply, error) {
+	s.log.Debug("norm detailed request", "phrase", in.Phrase, "lang", in.Lang)
+
+	lang, err := checkPhrase(in.GetPhrase(), in.GetLang(), in.GetMode())
+	if err != nil {
+		return nil, err
+	}
+	return s.detailedReply(s.norm.Tokenize(in.GetPhrase(), in.GetLang(), in.GetMode()), lang, in.GetMode()), nil
+}
+
+// detailedReply describes every token of a phrase in the language of the
+// reply.
+func (s *server) detailedReply(tokens []words.Token, lang, mode string) *wordspb.DetailedReply {
+	reply := &wordspb.DetailedReply{
+		Tokens: make([]*wordspb.Token, len(tokens)),
+		Lang:   lang,
+		Mode:   s.norm.Mode(mode),
+	}
+	for i, t := range tokens {
+		reply.Tokens[i] = &wordspb.Token{
+			Text:     t.Text,
+			Start:    uint32(t.Start),
+			End:      uint32(t.End),
+			Position: uint32(t.Position),
+			Stem:     t.Stem,
+			Lemma:    t.Lemma,
+			Term:     t.Term,
+			Stop:     t.Stop,
+		}
+	}
+	for _, f := range words.Frequencies(tokens) {
+		reply.Terms = append(reply.Terms, &wordspb.TermFrequency{Term: f.Term, Count: uint32(f.Count)})
+	}
+	return reply
+}
+
+func (s *server) NormBatch(_ context.Context, in *wordspb.NormBatchRequest) (*wordspb.NormBatchReply, error) {
+	s.log.Debug("norm batch request", "items", len(in.Items))
+
+	if len(in.GetItems()) > maxBatchLen {
+		return nil, status.Errorf(codes.InvalidArgument, "too many items: %d, at most %d", len(in.Items), maxBatchLen)
+	}
+	reply := &wordspb.NormBatchReply{Results: make([]*wordspb.NormResult, len(in.Items))}
+	for i, item := range in.Items {
+		reply.Results[i] = s.normItem(item)
+	}
+	return reply, nil
+}
+
+func (s *server) NormStream(stream grpc.BidiStreamingServer[wordspb.NormItem, wordspb.NormResult]) error {
+	s.log.Debug("norm stream started")
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(s.normItem(item)); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) Version(_ context.Context, _ *emptypb.Empty) (*wordspb.DictionaryVersion, error) {
+	return s.versionReply(s.dicts.Version()), nil
+}
+
+func (s *server) Dictionaries(_ context.Context, _ *emptypb.Empty) (*wordspb.DictionaryWords, error) {
+	d := s.dicts.Dictionaries()
+	return &wordspb.DictionaryWords{StopWords: d.StopWords, Protected: d.Protected}, nil
+}
+
+func (s *server) SetDictionaries(_ context.Context, in *wordspb.DictionaryWords) (*wordspb.DictionaryVersion, error) {
+	s.log.Debug("set dictionaries request", "stop_words", len(in.StopWords), "protected", len(in.Protected), "author", in.Author)
+
+	v, err := s.dicts.Set(words.Dictionaries{StopWords: in.StopWords, Protected: in.Protected}, in.Author)
+	if err != nil {
+		if errors.Is(err, dictionary.ErrBadWord) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.log.Error("failed to set dictionaries", "error", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return s.versionReply(v), nil
+}
 
-	if len(in.GetPhrase()) > maxPhraseLen {
-		return nil, status.Error(codes.ResourceExhausted, "too large")
+func (s *server) versionReply(v dictionary.Version) *wordspb.DictionaryVersion {
+	return &wordspb.DictionaryVersion{
+		Version:   v.Number,
+		Hash:      v.Hash,
+		UpdatedAt: v.UpdatedAt.Format(time.RFC3339),
+		Source:    v.Source,
+		StopWords: uint32(v.StopWords),
+		Protected: uint32(v.Protected),
+		Config:    s.norm.ConfigHash(),
 	}
+}
 
-	return &wordspb.WordsReply{
-		Words: words.Norm(in.GetPhrase()),
-	}, nil
+// checkPhrase validates the phrase, the language and the mode and returns
+// the language of the reply, the requested or the detected one.
+func checkPhrase(phrase, lang, mode string) (string, error) {
+	if len(phrase) > maxPhraseLen {
+		return "", status.Error(codes.ResourceExhausted, "too large")
+	}
+	if lang != "" && !words.Supported(lang) {
+		return "", status.Errorf(codes.InvalidArgument, "unsupported language %q", lang)
+	}
+	if !words.ValidMode(mode) {
+		return "", status.Errorf(codes.InvalidArgument, "unknown mode %q", mode)
+	}
+	if lang == "" {
+		return words.Detect(phrase), nil
+	}
+	return lang, nil
+}
+
+// normPhrase normalizes the phrase in the language, empty to detect it. The
+// phrase is tokenized once, so the words, forms and bigrams agree even if
+// the dictionaries change meanwhile.
+func (s *server) normPhrase(phrase, lang, mode string, bigrams bool) (*wordspb.WordsReply, error) {
+	replyLang, err := checkPhrase(phrase, lang, mode)
+	if err != nil {
+		return nil, err
+	}
+	return s.wordsReply(s.norm.Tokenize(phrase, lang, mode), replyLang, mode, bigrams), nil
+}
+
+// wordsReply builds the reply to a phrase from its tokens.
+func (s *server) wordsReply(tokens []words.Token, lang, mode string, bigrams bool) *wordspb.WordsReply {
+	reply := &wordspb.WordsReply{
+		Words: words.Terms(tokens),
+		Forms: words.Forms(tokens),
+		Lang:  lang,
+		Mode:  s.norm.Mode(mode),
+	}
+	if bigrams {
+		reply.Bigrams = words.Bigrams(tokens)
+	}
+	return reply
+}
+
+// normItem normalizes an item of a batch or a stream, putting the error
+// into the result so that it does not fail the other items. The reply and
+// the details of a detailed item come from the same tokens.
+func (s *server) normItem(item *wordspb.NormItem) *wordspb.NormResult {
+	lang, err := checkPhrase(item.GetPhrase(), item.GetLang(), item.GetMode())
+	if err != nil {
+		st := status.Convert(err)
+		return &wordspb.NormResult{Id: item.GetId(), Code: uint32(st.Code()), Error: st.Message()}
+	}
+	tokens := s.norm.Tokenize(item.GetPhrase(), item.GetLang(), item.GetMode())
+	result := &wordspb.NormResult{
+		Id:    item.GetId(),
+		Reply: s.wordsReply(tokens, lang, item.GetMode(), item.GetBigrams()),
+	}
+	if item.GetDetailed() {
+		result.Detailed = s.detailedReply(tokens, lang, item.GetMode())
+	}
+	return result
 }
 
 func main() {
@@ -78,18 +250,54 @@ func main() {
 }
 
 func run(cfg Config, log *slog.Logger) error {
+	norm, err := words.New(cfg.Tokenizer)
+	if err != nil {
+		return fmt.Errorf("invalid tokenizer config: %v", err)
+	}
+
+	var audit io.Writer
+	if cfg.Dictionaries.AuditFile != "" {
+		f, err := os.OpenFile(cfg.Dictionaries.AuditFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open audit file: %v", err)
+		}
+		defer f.Close()
+		audit = f
+	}
+	dicts := dictionary.New(log, norm,
+		dictionary.NewFile(cfg.Dictionaries.StopWordsFile),
+		dictionary.NewFile(cfg.Dictionaries.ProtectedFile),
+		audit)
+	if err := dicts.Reload(dictionary.SourceStartup); err != nil {
+		return fmt.Errorf("failed to load dictionaries: %v", err)
+	}
+
 	listener, err := net.Listen("tcp", cfg.Port)
 	if err != nil {
 		return fmt.Errorf("failed to listen port %s: %v", cfg.Port, err)
 	}
 
 	s := grpc.NewServer()
-	wordspb.RegisterWordsServer(s, &server{log: log})
+	wordspb.RegisterWordsServer(s, &server{log: log, norm: norm, dicts: dicts})
 	reflection.Register(s)
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
 	defer stop()
 
+	// SIGHUP reloads the dictionaries at once, changed files are noticed by Watch too
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if err := dicts.Reload(dictionary.SourceSignal); err != nil {
+				log.Error("failed to reload dictionaries", "error", err)
+			}
+		}
+	}()
+	if cfg.Dictionaries.CheckPeriod > 0 {
+		go dicts.Watch(ctx, cfg.Dictionaries.CheckPeriod)
+	}
+
 	go func() {
 		log.Info("starting server", "port", cfg.Port)
 		if err = s.Serve(listener); err != nil {
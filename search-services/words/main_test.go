@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	wordspb "yadro.com/course/proto/words"
+	"yadro.com/course/words/dictionary"
+	"yadro.com/course/words/words"
+)
+
+func newServer(t *testing.T) *server {
+	norm, err := words.New(words.Config{})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	dicts := dictionary.New(slog.Default(), norm,
+		dictionary.NewFile(filepath.Join(dir, "stop_words.txt")),
+		dictionary.NewFile(filepath.Join(dir, "protected.txt")),
+		nil)
+	require.NoError(t, dicts.Reload(dictionary.SourceStartup))
+	return &server{log: slog.Default(), norm: norm, dicts: dicts}
+}
+
+func TestServer_Norm(t *testing.T) {
+	s := newServer(t)
+
+	reply, err := s.Norm(context.Background(), &wordspb.WordsRequest{Phrase: "Running robots"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run", "robot"}, reply.Words)
+	assert.Equal(t, "en", reply.Lang)
+	assert.Equal(t, "stem", reply.Mode)
+	assert.Empty(t, reply.Bigrams)
+
+	reply, err = s.Norm(context.Background(), &wordspb.WordsRequest{Phrase: "Running the robots", Bigrams: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run robot"}, reply.Bigrams)
+
+	reply, err = s.Norm(context.Background(), &wordspb.WordsRequest{Phrase: "Studies of mice", Mode: "lemma"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"study", "mouse"}, reply.Words)
+	assert.Equal(t, "lemma", reply.Mode)
+
+	_, err = s.Norm(context.Background(), &wordspb.WordsRequest{Phrase: strings.Repeat("a", maxPhraseLen+1)})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = s.Norm(context.Background(), &wordspb.WordsRequest{Phrase: "robots", Lang: "de"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.Norm(context.Background(), &wordspb.WordsRequest{Phrase: "robots", Mode: "root"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_NormDetailed(t *testing.T) {
+	s := newServer(t)
+
+	reply, err := s.NormDetailed(context.Background(), &wordspb.WordsRequest{Phrase: "Robots and robots"})
+	require.NoError(t, err)
+	assert.Equal(t, "en", reply.Lang)
+	require.Len(t, reply.Tokens, 3)
+	assert.Equal(t, "and", reply.Tokens[1].Text)
+	assert.True(t, reply.Tokens[1].Stop)
+	assert.Equal(t, uint32(11), reply.Tokens[2].Start)
+	assert.Equal(t, uint32(17), reply.Tokens[2].End)
+	assert.Equal(t, uint32(2), reply.Tokens[2].Position)
+	assert.Equal(t, "robot", reply.Tokens[2].Stem)
+	require.Len(t, reply.Terms, 1)
+	assert.Equal(t, "robot", reply.Terms[0].Term)
+	assert.Equal(t, uint32(2), reply.Terms[0].Count)
+
+	_, err = s.NormDetailed(context.Background(), &wordspb.WordsRequest{Phrase: strings.Repeat("a", maxPhraseLen+1)})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = s.NormDetailed(context.Background(), &wordspb.WordsRequest{Phrase: "robots", Lang: "de"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_NormBatch(t *testing.T) {
+	s := newServer(t)
+
+	t.Run("results in order with item errors", func(t *testing.T) {
+		reply, err := s.NormBatch(context.Background(), &wordspb.NormBatchRequest{Items: []*wordspb.NormItem{
+			{Id: "1", Phrase: "robots"},
+			{Id: "2", Phrase: strings.Repeat("a", maxPhraseLen+1)},
+			{Id: "3", Phrase: "роботы", Lang: "ru"},
+		}})
+		require.NoError(t, err)
+		require.Len(t, reply.Results, 3)
+
+		assert.Equal(t, "1", reply.Results[0].Id)
+		assert.Equal(t, []string{"robot"}, reply.Results[0].Reply.GetWords())
+		assert.Zero(t, reply.Results[0].Code)
+
+		assert.Equal(t, "2", reply.Results[1].Id)
+		assert.Nil(t, reply.Results[1].Reply)
+		assert.Equal(t, uint32(codes.ResourceExhausted), reply.Results[1].Code)
+		assert.NotEmpty(t, reply.Results[1].Error)
+
+		assert.Equal(t, "3", reply.Results[2].Id)
+		assert.Equal(t, []string{"робот"}, reply.Results[2].Reply.GetWords())
+	})
+
+	t.Run("detailed item", func(t *testing.T) {
+		reply, err := s.NormBatch(context.Background(), &wordspb.NormBatchRequest{Items: []*wordspb.NormItem{
+			{Id: "1", Phrase: "Robots and robots", Detailed: true},
+			{Id: "2", Phrase: "robots"},
+		}})
+		require.NoError(t, err)
+		require.Len(t, reply.Results, 2)
+
+		assert.Equal(t, []string{"robot"}, reply.Results[0].Reply.GetWords())
+		detailed := reply.Results[0].Detailed
+		require.NotNil(t, detailed)
+		require.Len(t, detailed.Tokens, 3)
+		assert.Equal(t, "Robots", detailed.Tokens[0].Text)
+		assert.Equal(t, "robot", detailed.Tokens[0].Term)
+		require.Len(t, detailed.Terms, 1)
+		assert.Equal(t, uint32(2), detailed.Terms[0].Count)
+
+		assert.Nil(t, reply.Results[1].Detailed)
+	})
+
+	t.Run("too many items", func(t *testing.T) {
+		_, err := s.NormBatch(context.Background(), &wordspb.NormBatchRequest{
+			Items: make([]*wordspb.NormItem, maxBatchLen+1),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestServer_NormStream(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	wordspb.RegisterWordsServer(s, newServer(t))
+	go func() { _ = s.Serve(listener) }()
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stream, err := wordspb.NewWordsClient(conn).NormStream(context.Background())
+	require.NoError(t, err)
+
+	items := []*wordspb.NormItem{
+		{Id: "a", Phrase: "jumps"},
+		{Id: "b", Phrase: "robots", Lang: "xx"},
+		{Id: "c", Phrase: "lasers"},
+	}
+	for _, item := range items {
+		require.NoError(t, stream.Send(item))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	var results []*wordspb.NormResult
+	for range items {
+		result, err := stream.Recv()
+		require.NoError(t, err)
+		results = append(results, result)
+	}
+
+	assert.Equal(t, "a", results[0].Id)
+	assert.Equal(t, []string{"jump"}, results[0].Reply.GetWords())
+	assert.Equal(t, "b", results[1].Id)
+	assert.Equal(t, uint32(codes.InvalidArgument), results[1].Code)
+	assert.Equal(t, "c", results[2].Id)
+	assert.Equal(t, []string{"laser"}, results[2].Reply.GetWords())
+}
+
+func TestServer_Dictionaries(t *testing.T) {
+	s := newServer(t)
+
+	version, err := s.Version(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), version.Version)
+	assert.Equal(t, dictionary.SourceStartup, version.Source)
+	assert.Equal(t, s.norm.ConfigHash(), version.Config)
+
+	version, err = s.SetDictionaries(context.Background(), &wordspb.DictionaryWords{
+		StopWords: []string{"comic"},
+		Protected: []string{"python"},
+		Author:    "admin",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), version.Version)
+	assert.Equal(t, dictionary.SourceUpload, version.Source)
+	assert.Equal(t, uint32(1), version.StopWords)
+
+	dicts, err := s.Dictionaries(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"comic"}, dicts.StopWords)
+	assert.Equal(t, []string{"python"}, dicts.Protected)
+
+	reply, err := s.Norm(context.Background(), &wordspb.WordsRequest{Phrase: "comics about pythons"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"python"}, reply.Words)
+
+	_, err = s.SetDictionaries(context.Background(), &wordspb.DictionaryWords{StopWords: []string{"two words"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestConfig(t *testing.T) {
+	var cfg Config
+	require.NoError(t, cleanenv.ReadConfig("config.yaml", &cfg))
+
+	norm, err := words.New(cfg.Tokenizer)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c++", "email", "physics", "3.14"}, norm.Norm("C++ e-mail physics 3.14", "", ""))
+}
@@ -0,0 +1,138 @@
+package words
+
+import (
+	"bufio"
+	"embed"
+	"strings"
+)
+
+// Normalization modes: snowball stems for recall or lemmas, which are real
+// words, for reading.
+const (
+	ModeStem  = "stem"
+	ModeLemma = "lemma"
+)
+
+//go:embed lemmas/*.txt
+var lemmaFiles embed.FS
+
+var (
+	// irregular maps irregular English forms to their lemmas
+	irregular = readTable("lemmas/irregular.txt")
+	// base are the English verbs regular forms are checked against
+	base = readBase("lemmas/base.txt")
+)
+
+// readLines returns the lines of an embedded file without comments and
+// empty lines.
+func readLines(name string) []string {
+	f, err := lemmaFiles.Open(name)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func readTable(name string) map[string]string {
+	table := make(map[string]string)
+	for _, line := range readLines(name) {
+		fields := strings.Fields(line)
+		table[fields[0]] = fields[1]
+	}
+	return table
+}
+
+func readBase(name string) map[string]bool {
+	words := make(map[string]bool)
+	for _, line := range readLines(name) {
+		for _, w := range strings.Fields(line) {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+// lemma returns the dictionary form of a word in lower case. Irregular forms
+// come from the table, -ed and -ing forms are only undone to known verbs
+// and plurals by the spelling rules; other words are left as they are, so a
+// lemma is always a real word. There is no Russian table, Russian words are
+// only lower cased.
+func lemma(word, lang string) string {
+	word = strings.ToLower(word)
+	if lang == "" {
+		lang = Detect(word)
+	}
+	if lang != English {
+		return word
+	}
+	if l, ok := irregular[word]; ok {
+		return l
+	}
+	if base[word] {
+		return word
+	}
+	for _, candidate := range verbCandidates(word) {
+		if base[candidate] {
+			return candidate
+		}
+	}
+	return plural(word)
+}
+
+// verbCandidates are the possible bases of an -s, -ed or -ing form.
+func verbCandidates(word string) []string {
+	var res []string
+	for _, suffix := range []string{"ing", "ed"} {
+		stem, ok := strings.CutSuffix(word, suffix)
+		if !ok || len(stem) < 2 {
+			continue
+		}
+		res = append(res, stem, stem+"e")
+		// stopped, running
+		if n := len(stem); stem[n-1] == stem[n-2] {
+			res = append(res, stem[:n-1])
+		}
+		// tried
+		if suffix == "ed" && strings.HasSuffix(stem, "i") {
+			res = append(res, stem[:len(stem)-1]+"y")
+		}
+	}
+	if stem, ok := strings.CutSuffix(word, "ies"); ok {
+		res = append(res, stem+"y")
+	}
+	if stem, ok := strings.CutSuffix(word, "es"); ok {
+		res = append(res, stem)
+	}
+	if stem, ok := strings.CutSuffix(word, "s"); ok {
+		res = append(res, stem)
+	}
+	return res
+}
+
+// plural undoes the regular plural of a noun.
+func plural(word string) string {
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "shes"),
+		strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "xes"),
+		strings.HasSuffix(word, "zzes"):
+		return word[:len(word)-2]
+	case len(word) > 3 && strings.HasSuffix(word, "s") &&
+		!strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") &&
+		!strings.HasSuffix(word, "is"):
+		return word[:len(word)-1]
+	}
+	return word
+}
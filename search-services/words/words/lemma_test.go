@@ -0,0 +1,60 @@
+package words
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLemma(t *testing.T) {
+	tests := map[string]string{
+		"went":      "go",
+		"mice":      "mouse",
+		"Children":  "child",
+		"computing": "compute",
+		"computers": "computer",
+		"libraries": "library",
+		"stopped":   "stop",
+		"tried":     "try",
+		"used":      "use",
+		"boxes":     "box",
+		"classes":   "class",
+		"movies":    "movie",
+		"thing":     "thing",
+		"bus":       "bus",
+		"this":      "this",
+		"python":    "python",
+		"роботы":    "роботы",
+	}
+	for word, expected := range tests {
+		assert.Equal(t, expected, lemma(word, ""), word)
+	}
+}
+
+func TestNormalizer_Modes(t *testing.T) {
+	n, err := New(Config{})
+	require.NoError(t, err)
+	phrase := "computing libraries went"
+
+	assert.Equal(t, []string{"comput", "librari", "went"}, n.Norm(phrase, "", ""))
+	assert.Equal(t, []string{"comput", "librari", "went"}, n.Norm(phrase, "", ModeStem))
+	assert.Equal(t, []string{"compute", "library", "go"}, n.Norm(phrase, "", ModeLemma))
+	assert.Equal(t, map[string]string{"compute": "computing", "library": "libraries", "go": "went"}, Forms(n.Tokenize(phrase, "", ModeLemma)))
+
+	tokens := n.Tokenize("libraries", "", ModeLemma)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "librari", tokens[0].Stem)
+	assert.Equal(t, "library", tokens[0].Lemma)
+	assert.Equal(t, "library", tokens[0].Term)
+
+	lemmas, err := New(Config{Mode: ModeLemma})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"compute", "library", "go"}, lemmas.Norm(phrase, "", ""))
+	assert.Equal(t, []string{"comput", "librari", "went"}, lemmas.Norm(phrase, "", ModeStem))
+
+	_, err = New(Config{Mode: "porter"})
+	assert.EqualError(t, err, `unknown mode "porter"`)
+	assert.True(t, ValidMode(""))
+	assert.False(t, ValidMode("porter"))
+}
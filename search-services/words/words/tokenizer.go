@@ -0,0 +1,206 @@
+package words
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Modes of hyphenated words and contractions.
+const (
+	// ModeSplit splits "e-mail" into "e" and "mail"
+	ModeSplit = "split"
+	// ModeJoin makes "e-mail" the single word "email"
+	ModeJoin = "join"
+	// ModeKeep makes "e-mail" the single word "e-mail"
+	ModeKeep = "keep"
+)
+
+// Config is the tokenizer and filter pipeline of a Normalizer. The zero
+// Config splits phrases on punctuation, spaces and '+' and filters nothing
+// but the stop words of the language.
+type Config struct {
+	// Mode is the default normalization mode: stem (the default) or lemma
+	Mode string `yaml:"mode"`
+
+	// NFKC normalizes the Unicode of every word, so that "ﬁle" is "file"
+	NFKC bool `yaml:"nfkc"`
+	// FoldCase folds the case of every word, so that "STRASSE" is "straße"
+	FoldCase bool `yaml:"fold_case"`
+
+	// Numbers keeps decimal and grouped numbers such as 3.14 and 1,000
+	// whole
+	Numbers bool `yaml:"numbers"`
+	// Hyphens is the mode of hyphenated words: split, join or keep
+	Hyphens string `yaml:"hyphens"`
+	// Contractions is the mode of words with apostrophes such as "don't":
+	// split or keep
+	Contractions string `yaml:"contractions"`
+	// URLs keeps http://, https:// and www. links whole and unstemmed
+	URLs bool `yaml:"urls"`
+	// Emoticons keeps emoticons such as :) as words
+	Emoticons bool `yaml:"emoticons"`
+	// Symbols are characters that belong to the word they follow, "+#"
+	// keeps "c++" and "c#"
+	Symbols string `yaml:"symbols"`
+
+	// MinLength and MaxLength drop words shorter or longer than them (in
+	// characters), zero is no limit
+	MinLength int `yaml:"min_length"`
+	MaxLength int `yaml:"max_length"`
+	// StopWords are dropped in addition to the stop words of the language
+	StopWords []string `yaml:"stop_words"`
+	// Protected words are never stemmed nor dropped
+	Protected []string `yaml:"protected"`
+}
+
+func (c Config) validate() error {
+	if !ValidMode(c.Mode) {
+		return fmt.Errorf("unknown mode %q", c.Mode)
+	}
+	switch c.Hyphens {
+	case "", ModeSplit, ModeJoin, ModeKeep:
+	default:
+		return fmt.Errorf("unknown hyphens mode %q", c.Hyphens)
+	}
+	switch c.Contractions {
+	case "", ModeSplit, ModeKeep:
+	default:
+		return fmt.Errorf("unknown contractions mode %q", c.Contractions)
+	}
+	if c.MinLength < 0 || c.MaxLength < 0 {
+		return fmt.Errorf("negative word length limits: %d, %d", c.MinLength, c.MaxLength)
+	}
+	if c.MaxLength > 0 && c.MaxLength < c.MinLength {
+		return fmt.Errorf("max word length %d is less than min length %d", c.MaxLength, c.MinLength)
+	}
+	return nil
+}
+
+var urlPrefixes = []string{"http://", "https://", "www."}
+
+// emoticons are checked in order, so longer ones go first.
+var emoticons = []string{
+	":-)", ":-(", ":-D", ":-P", ":-p", ":-/", ";-)", ":'(",
+	":)", ":(", ":D", ":P", ":p", ":/", ";)", ":O", ":o", "<3",
+	"^_^", "-_-",
+}
+
+// span is a word found by the tokenizer; whole spans are not stemmed.
+type span struct {
+	start, end int
+	whole      bool
+}
+
+// scan splits the phrase into spans by the rules of the config.
+func (c Config) scan(phrase string) []span {
+	var spans []span
+	for i := 0; i < len(phrase); {
+		if end, ok := c.special(phrase, i); ok {
+			spans = append(spans, span{start: i, end: end, whole: true})
+			i = end
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(phrase[i:])
+		if !c.isWordRune(r) {
+			i += size
+			continue
+		}
+		end := c.wordEnd(phrase, i)
+		spans = append(spans, span{start: i, end: end})
+		i = end
+	}
+	return spans
+}
+
+// special returns the end of the link or emoticon starting at i.
+func (c Config) special(phrase string, i int) (int, bool) {
+	if c.URLs {
+		for _, prefix := range urlPrefixes {
+			if len(phrase)-i > len(prefix) && strings.EqualFold(phrase[i:i+len(prefix)], prefix) {
+				end := i + strings.IndexFunc(phrase[i:]+" ", unicode.IsSpace)
+				return i + len(strings.TrimRight(phrase[i:end], ".,;:!?)]}\"'")), true
+			}
+		}
+	}
+	if c.Emoticons {
+		for _, e := range emoticons {
+			if !strings.HasPrefix(phrase[i:], e) {
+				continue
+			}
+			next, _ := utf8.DecodeRuneInString(phrase[i+len(e):])
+			if !unicode.IsLetter(next) && !unicode.IsDigit(next) {
+				return i + len(e), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (c Config) isWordRune(r rune) bool {
+	return !unicode.IsPunct(r) && !unicode.IsSpace(r) && r != '+'
+}
+
+// wordEnd returns the end of the word starting at start: word runes,
+// symbols following them and the joiners the config keeps inside words.
+func (c Config) wordEnd(phrase string, start int) int {
+	i := start
+	var prev rune
+	for i < len(phrase) {
+		r, size := utf8.DecodeRuneInString(phrase[i:])
+		switch {
+		case c.isWordRune(r):
+		case strings.ContainsRune(c.Symbols, r):
+		case c.joins(prev, r, phrase[i+size:]):
+		default:
+			return i
+		}
+		prev = r
+		i += size
+	}
+	return i
+}
+
+// joins reports whether r between prev and the rest of the phrase is kept
+// inside the word: a hyphen, an apostrophe or a number separator.
+func (c Config) joins(prev, r rune, rest string) bool {
+	next, _ := utf8.DecodeRuneInString(rest)
+	switch r {
+	case '-':
+		return c.Hyphens != "" && c.Hyphens != ModeSplit && isAlnum(prev) && isAlnum(next)
+	case '\'', '’':
+		return c.Contractions == ModeKeep && unicode.IsLetter(prev) && unicode.IsLetter(next)
+	case '.', ',':
+		return c.Numbers && unicode.IsDigit(prev) && unicode.IsDigit(next)
+	}
+	return false
+}
+
+func isAlnum(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// term is the text of a word the filters and the stemmer see.
+func (c Config) term(text string) string {
+	if c.Hyphens == ModeJoin {
+		text = strings.ReplaceAll(text, "-", "")
+	}
+	if c.NFKC {
+		text = norm.NFKC.String(text)
+	}
+	if c.FoldCase {
+		// a Caser is stateful, so it is not shared
+		text = cases.Fold().String(text)
+	}
+	return text
+}
+
+// fits reports whether the term passes the length limits.
+func (c Config) fits(term string) bool {
+	n := utf8.RuneCountInString(term)
+	return n >= c.MinLength && (c.MaxLength == 0 || n <= c.MaxLength)
+}
@@ -0,0 +1,161 @@
+package words
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr string
+	}{
+		{name: "zero config"},
+		{name: "all modes", cfg: Config{Hyphens: ModeJoin, Contractions: ModeKeep, MinLength: 2, MaxLength: 2}},
+		{name: "unknown hyphens", cfg: Config{Hyphens: "drop"}, expectedErr: `unknown hyphens mode "drop"`},
+		{name: "join contractions", cfg: Config{Contractions: ModeJoin}, expectedErr: `unknown contractions mode "join"`},
+		{name: "negative length", cfg: Config{MinLength: -1}, expectedErr: "negative word length limits: -1, 0"},
+		{name: "max below min", cfg: Config{MinLength: 3, MaxLength: 2}, expectedErr: "max word length 2 is less than min length 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNormalizer_Pipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		input    string
+		expected []string
+	}{
+		{
+			name:     "symbols",
+			cfg:      Config{Symbols: "+#"},
+			input:    "C++ and C# +1",
+			expected: []string{"c++", "c#", "1"},
+		},
+		{
+			name:     "split hyphens",
+			input:    "e-mail",
+			expected: []string{"e", "mail"},
+		},
+		{
+			name:     "joined hyphens",
+			cfg:      Config{Hyphens: ModeJoin},
+			input:    "e-mail - re-sent",
+			expected: []string{"email", "resent"},
+		},
+		{
+			name:     "kept hyphens",
+			cfg:      Config{Hyphens: ModeKeep},
+			input:    "e-mail",
+			expected: []string{"e-mail"},
+		},
+		{
+			name:     "contractions",
+			cfg:      Config{Contractions: ModeKeep},
+			input:    "robot's 'quoted'",
+			expected: []string{"robot", "quot"},
+		},
+		{
+			name:     "numbers",
+			cfg:      Config{Numbers: true},
+			input:    "pi is 3.14, not 1,000.",
+			expected: []string{"pi", "3.14", "1,000"},
+		},
+		{
+			name:     "urls",
+			cfg:      Config{URLs: true},
+			input:    "see https://xkcd.com/353/, www.Example.org and http",
+			expected: []string{"see", "https://xkcd.com/353/", "www.Example.org", "http"},
+		},
+		{
+			name:     "emoticons",
+			cfg:      Config{Emoticons: true},
+			input:    "robots :) :-( :Dog",
+			expected: []string{"robot", ":)", ":-(", "dog"},
+		},
+		{
+			name:     "unicode normalization and case folding",
+			cfg:      Config{NFKC: true, FoldCase: true, Protected: []string{"STRASSE"}},
+			input:    "ﬁles Straße",
+			expected: []string{"file", "strasse"},
+		},
+		{
+			name:     "length limits",
+			cfg:      Config{MinLength: 2, MaxLength: 6, Protected: []string{"x"}},
+			input:    "a x robots lengthiest",
+			expected: []string{"x", "robot"},
+		},
+		{
+			name:     "custom stop words",
+			cfg:      Config{StopWords: []string{"Comic", "xkcd"}},
+			input:    "xkcd comics about robots",
+			expected: []string{"robot"},
+		},
+		{
+			name:     "protected words",
+			cfg:      Config{Protected: []string{"Linux", "the"}},
+			input:    "the linux systems",
+			expected: []string{"the", "linux", "system"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := New(tt.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, n.Norm(tt.input, "", ""))
+		})
+	}
+}
+
+func TestNormalizer_TokenizeOffsets(t *testing.T) {
+	n, err := New(Config{NFKC: true, FoldCase: true, Hyphens: ModeJoin, URLs: true})
+	require.NoError(t, err)
+
+	phrase := "ＥＭＡＩＬ e-mail www.xkcd.com."
+	tokens := n.Tokenize(phrase, "", "")
+	require.Len(t, tokens, 3)
+	for _, token := range tokens {
+		assert.Equal(t, token.Text, phrase[token.Start:token.End])
+	}
+	assert.Equal(t, "email", tokens[0].Stem)
+	assert.Equal(t, "email", tokens[1].Stem)
+	assert.Equal(t, "www.xkcd.com", tokens[2].Stem)
+}
+
+func TestNormalizer_ConfigHash(t *testing.T) {
+	a, err := New(Config{Protected: []string{"linux"}})
+	require.NoError(t, err)
+	b, err := New(Config{Protected: []string{"linux"}})
+	require.NoError(t, err)
+	c, err := New(Config{Protected: []string{"linux"}, Mode: ModeLemma})
+	require.NoError(t, err)
+
+	assert.Equal(t, a.ConfigHash(), b.ConfigHash())
+	assert.NotEqual(t, a.ConfigHash(), c.ConfigHash())
+}
+
+func TestNormalizer_SetDictionaries(t *testing.T) {
+	n, err := New(Config{StopWords: []string{"robot"}})
+	require.NoError(t, err)
+
+	n.SetDictionaries(Dictionaries{StopWords: []string{"Comics"}, Protected: []string{"python"}})
+	assert.Equal(t, []string{"python", "laser"}, n.Norm("robots comic pythons python lasers", "", ""))
+
+	n.SetDictionaries(Dictionaries{})
+	assert.Equal(t, []string{"comic", "python", "laser"}, n.Norm("robots comic pythons python lasers", "", ""))
+}
@@ -1,32 +1,279 @@
 package words
 
 import (
-	"maps"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"slices"
 	"strings"
+	"sync/atomic"
 	"unicode"
 
 	"github.com/kljensen/snowball"
 	"github.com/kljensen/snowball/english"
+	"github.com/kljensen/snowball/russian"
 )
 
-func Norm(phrase string) []string {
+const (
+	English = "en"
+	Russian = "ru"
+)
+
+type language struct {
+	// name of the snowball stemmer
+	stemmer    string
+	isStopWord func(string) bool
+}
+
+var languages = map[string]language{
+	English: {stemmer: "english", isStopWord: english.IsStopWord},
+	Russian: {stemmer: "russian", isStopWord: russian.IsStopWord},
+}
+
+// Supported reports whether phrases can be normalized in the language.
+func Supported(lang string) bool {
+	_, ok := languages[lang]
+	return ok
+}
+
+// Detect guesses the language of the phrase by its script: Russian if it
+// has more Cyrillic letters than Latin ones, English otherwise.
+func Detect(phrase string) string {
+	var cyrillic, latin int
+	for _, c := range phrase {
+		switch {
+		case unicode.Is(unicode.Cyrillic, c):
+			cyrillic++
+		case unicode.Is(unicode.Latin, c):
+			latin++
+		}
+	}
+	if cyrillic > latin {
+		return Russian
+	}
+	return English
+}
+
+// Dictionaries are stop words and protected words that can be changed while
+// the Normalizer is in use, in addition to the ones of its Config.
+type Dictionaries struct {
+	StopWords []string
+	Protected []string
+}
+
+type dictionaries struct {
+	stopWords map[string]bool
+	protected map[string]bool
+}
+
+// Normalizer splits phrases into words and stems them by its Config.
+type Normalizer struct {
+	cfg   Config
+	dicts atomic.Pointer[dictionaries]
+}
+
+func New(cfg Config) (*Normalizer, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	n := &Normalizer{cfg: cfg}
+	n.SetDictionaries(Dictionaries{})
+	return n, nil
+}
+
+// ConfigHash identifies the Config, so that clients caching normalized
+// phrases notice a restart with other rules.
+func (n *Normalizer) ConfigHash() string {
+	h := sha256.Sum256(fmt.Appendf(nil, "%+v", n.cfg))
+	return hex.EncodeToString(h[:])[:16]
+}
+
+// SetDictionaries replaces the dictionaries, phrases being normalized keep
+// the previous ones.
+func (n *Normalizer) SetDictionaries(d Dictionaries) {
+	dicts := &dictionaries{
+		stopWords: make(map[string]bool),
+		protected: make(map[string]bool),
+	}
+	for _, w := range append(slices.Clip(n.cfg.StopWords), d.StopWords...) {
+		// the stem drops the other forms of the word too
+		stemmed, _ := stem(n.cfg.term(w), "")
+		dicts.stopWords[n.cfg.term(w)] = true
+		dicts.stopWords[stemmed] = true
+	}
+	for _, w := range append(slices.Clip(n.cfg.Protected), d.Protected...) {
+		dicts.protected[n.cfg.term(w)] = true
+	}
+	n.dicts.Store(dicts)
+}
+
+// Token is a word of a phrase: its text, byte offsets, position among the
+// words of the phrase, stem, lemma and whether it is dropped as a stop word
+// or by the length limits. Term is the stem or the lemma by the mode.
+type Token struct {
+	Text     string
+	Start    int
+	End      int
+	Position int
+	Stem     string
+	Lemma    string
+	Term     string
+	Stop     bool
+}
+
+// TermFreq is the number of times a term occurs in a phrase.
+type TermFreq struct {
+	Term  string
+	Count int
+}
 
-	f := func(c rune) bool {
-		return unicode.IsPunct(c) || unicode.IsSpace(c) || c == '+'
+// ValidMode reports whether the mode is known, empty being the mode of the
+// Config.
+func ValidMode(mode string) bool {
+	return mode == "" || mode == ModeStem || mode == ModeLemma
+}
+
+// Mode returns the mode phrases are normalized in: the given one or the
+// mode of the Config, stemming by default.
+func (n *Normalizer) Mode(mode string) string {
+	switch {
+	case mode != "":
+		return mode
+	case n.cfg.Mode != "":
+		return n.cfg.Mode
+	}
+	return ModeStem
+}
+
+// Tokenize splits the phrase into words, stems and lemmatizes them. Dropped
+// words are kept and marked, so positions count every word. The mode is
+// ModeStem, ModeLemma or empty for the mode of the Config.
+func (n *Normalizer) Tokenize(phrase, lang, mode string) []Token {
+	mode = n.Mode(mode)
+	spans := n.cfg.scan(phrase)
+	dicts := n.dicts.Load()
+	tokens := make([]Token, 0, len(spans))
+	for _, sp := range spans {
+		t := Token{
+			Text:     phrase[sp.start:sp.end],
+			Start:    sp.start,
+			End:      sp.end,
+			Position: len(tokens),
+		}
+		term := n.cfg.term(t.Text)
+		switch {
+		case sp.whole || dicts.protected[term]:
+			t.Stem, t.Lemma = term, term
+		default:
+			t.Stem, t.Stop = stem(term, lang)
+			t.Lemma = lemma(term, lang)
+			t.Stop = t.Stop || dicts.stopWords[term] || dicts.stopWords[t.Stem]
+		}
+		if !dicts.protected[term] && !n.cfg.fits(term) {
+			t.Stop = true
+		}
+		t.Term = t.Stem
+		if mode == ModeLemma {
+			t.Term = t.Lemma
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+// Frequencies counts the terms of the tokens that are not stop words, in
+// the order the terms first appear.
+func Frequencies(tokens []Token) []TermFreq {
+	var freqs []TermFreq
+	index := make(map[string]int)
+	for _, t := range tokens {
+		if t.Stop {
+			continue
+		}
+		i, ok := index[t.Term]
+		if !ok {
+			i = len(freqs)
+			index[t.Term] = i
+			freqs = append(freqs, TermFreq{Term: t.Term})
+		}
+		freqs[i].Count++
+	}
+	return freqs
+}
+
+// Bigrams returns the pairs of adjacent terms of the tokens joined by a
+// space, once each in the order they first appear. Stop words are skipped
+// rather than breaking the pair, so "house of cards" gives "house card".
+func Bigrams(tokens []Token) []string {
+	var bigrams []string
+	seen := make(map[string]bool)
+	prev := ""
+	for _, t := range tokens {
+		if t.Stop {
+			continue
+		}
+		if prev != "" {
+			bigram := prev + " " + t.Term
+			if !seen[bigram] {
+				seen[bigram] = true
+				bigrams = append(bigrams, bigram)
+			}
+		}
+		prev = t.Term
+	}
+	return bigrams
+}
+
+// stem returns the stem of the word in the language and whether it is a
+// stop word. Without a language every word is stemmed in the language of
+// its own script, so that mixed phrases are normalized too.
+func stem(word, lang string) (string, bool) {
+	if lang == "" {
+		lang = Detect(word)
 	}
+	l, ok := languages[lang]
+	if !ok {
+		l = languages[English]
+	}
+	stemmed, _ := snowball.Stem(word, l.stemmer, false)
+	return stemmed, l.isStopWord(stemmed)
+}
+
+// Norm returns unique terms of the phrase in the order they first appear.
+// The language is one of the supported ones or empty to detect it.
+func (n *Normalizer) Norm(phrase, lang, mode string) []string {
+	return Terms(n.Tokenize(phrase, lang, mode))
+}
 
-	split := strings.FieldsFunc(phrase, f)
-	words := make(map[string]bool)
+// Terms returns unique terms of the tokens that are not stop words in the
+// order they first appear.
+func Terms(tokens []Token) []string {
+	var words []string
+	for _, f := range Frequencies(tokens) {
+		words = append(words, f.Term)
+	}
+	return words
+}
 
-	for _, word := range split {
-		stemmed, _ := snowball.Stem(word, "english", false)
+// Forms maps every term of the tokens to the surface word it occurs as most
+// often (in lower case), so that stems can be shown to users as real words.
+func Forms(tokens []Token) map[string]string {
+	counts := make(map[string]map[string]int)
+	forms := make(map[string]string)
 
-		if english.IsStopWord(stemmed) {
+	for _, t := range tokens {
+		if t.Stop {
 			continue
 		}
-		words[stemmed] = true
+		word := strings.ToLower(t.Text)
+		if counts[t.Term] == nil {
+			counts[t.Term] = make(map[string]int)
+		}
+		counts[t.Term][word]++
+		if best, ok := forms[t.Term]; !ok || counts[t.Term][word] > counts[t.Term][best] {
+			forms[t.Term] = word
+		}
 	}
 
-	return slices.Collect(maps.Keys(words))
+	return forms
 }
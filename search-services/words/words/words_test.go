@@ -7,6 +7,9 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// plain is the normalizer of the zero config.
+var plain, _ = New(Config{})
+
 func TestNorm(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -67,7 +70,7 @@ func TestNorm(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := Norm(tt.input)
+			result := plain.Norm(tt.input, "", "")
 
 			sortStrings(result)
 			sortStrings(tt.expected)
@@ -77,8 +80,147 @@ func TestNorm(t *testing.T) {
 	}
 }
 
+func TestNorm_KeepsOrder(t *testing.T) {
+	assert.Equal(t, []string{"linux", "kernel", "panic"}, plain.Norm("Linux kernel panics, kernel", "", ""))
+}
+
+func TestNorm_Languages(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		lang     string
+		expected []string
+	}{
+		{
+			name:     "russian",
+			input:    "Роботы и лазеры в космосе",
+			lang:     Russian,
+			expected: []string{"робот", "лазер", "космос"},
+		},
+		{
+			name:     "russian detected",
+			input:    "Роботы и лазеры в космосе",
+			expected: []string{"робот", "лазер", "космос"},
+		},
+		{
+			name:     "mixed phrase detected by word",
+			input:    "роботы and robots",
+			expected: []string{"робот", "robot"},
+		},
+		{
+			name:     "english stemmer leaves cyrillic words",
+			input:    "the роботы",
+			lang:     English,
+			expected: []string{"роботы"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, plain.Norm(tt.input, tt.lang, ""))
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	assert.Equal(t, Russian, Detect("Привет, мир"))
+	assert.Equal(t, Russian, Detect("Привет, world"))
+	assert.Equal(t, English, Detect("hello, мир"))
+	assert.Equal(t, English, Detect("123"))
+}
+
+func TestSupported(t *testing.T) {
+	assert.True(t, Supported(English))
+	assert.True(t, Supported(Russian))
+	assert.False(t, Supported("de"))
+	assert.False(t, Supported(""))
+}
+
+func TestForms(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]string
+	}{
+		{
+			name:     "empty string",
+			input:    " ",
+			expected: map[string]string{},
+		},
+		{
+			name:     "surface words in lower case",
+			input:    "Running jumps, the Lazy dog",
+			expected: map[string]string{"run": "running", "jump": "jumps", "lazi": "lazy", "dog": "dog"},
+		},
+		{
+			name:     "most frequent form wins",
+			input:    "programs program programming program",
+			expected: map[string]string{"program": "program"},
+		},
+		{
+			name:     "russian forms",
+			input:    "Роботы робот роботы",
+			expected: map[string]string{"робот": "роботы"},
+		},
+		{
+			name:     "first form wins a tie",
+			input:    "computers computer",
+			expected: map[string]string{"comput": "computers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Forms(plain.Tokenize(tt.input, "", "")))
+		})
+	}
+}
+
 func sortStrings(s []string) {
 	sort.Slice(s, func(i, j int) bool {
 		return s[i] < s[j]
 	})
 }
+
+func TestTokenize(t *testing.T) {
+	phrase := "The robots, Роботы!"
+	tokens := plain.Tokenize(phrase, "", "")
+	assert.Equal(t, []Token{
+		{Text: "The", Start: 0, End: 3, Position: 0, Stem: "the", Lemma: "the", Term: "the", Stop: true},
+		{Text: "robots", Start: 4, End: 10, Position: 1, Stem: "robot", Lemma: "robot", Term: "robot"},
+		{Text: "Роботы", Start: 12, End: 24, Position: 2, Stem: "робот", Lemma: "роботы", Term: "робот"},
+	}, tokens)
+	for _, token := range tokens {
+		assert.Equal(t, token.Text, phrase[token.Start:token.End])
+	}
+
+	assert.Empty(t, plain.Tokenize(" ,. ", "", ""))
+}
+
+func TestFrequencies(t *testing.T) {
+	tokens := plain.Tokenize("robots and lasers, a robot", English, "")
+	assert.Equal(t, []TermFreq{
+		{Term: "robot", Count: 2},
+		{Term: "laser", Count: 1},
+	}, Frequencies(tokens))
+	assert.Equal(t, []string{"robot", "laser"}, Terms(tokens))
+}
+
+func TestBigrams(t *testing.T) {
+	tests := []struct {
+		name     string
+		phrase   string
+		expected []string
+	}{
+		{name: "adjacent words", phrase: "Black Hat hacker", expected: []string{"black hat", "hat hacker"}},
+		{name: "stop words are skipped", phrase: "house of cards", expected: []string{"hous card"}},
+		{name: "repeated pairs", phrase: "robots, robots and robots!", expected: []string{"robot robot"}},
+		{name: "single word", phrase: "the robots", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Bigrams(plain.Tokenize(tt.phrase, English, "")))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package wordsclient
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	wordspb "yadro.com/course/proto/words"
+)
+
+// Stats are the counters of a Client.
+type Stats struct {
+	Hits     uint64
+	Misses   uint64
+	Entries  int
+	Capacity int
+	// Invalidations counts the times the cache was dropped for a new version
+	// of the words service
+	Invalidations uint64
+	// Retries counts the calls repeated because the service was unavailable
+	Retries uint64
+}
+
+// cache keeps the latest normalized phrases of one version of the words
+// service, evicting the least recently used ones.
+type cache struct {
+	mu         sync.Mutex
+	capacity   int
+	version    string
+	generation uint64
+	order      *list.List
+	entries    map[string]*list.Element
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+type cacheEntry struct {
+	key   string
+	reply *wordspb.WordsReply
+}
+
+func newCache(capacity int) *cache {
+	return &cache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached reply and the generation of the cache to put the
+// reply of the service with otherwise.
+func (c *cache) get(key string) (*wordspb.WordsReply, uint64, bool) {
+	if c.capacity <= 0 {
+		return nil, 0, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		c.misses.Add(1)
+		return nil, c.generation, false
+	}
+	c.hits.Add(1)
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).reply, c.generation, true
+}
+
+func (c *cache) put(generation uint64, key string, reply *wordspb.WordsReply) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the reply was asked for before the cache was dropped and may be stale
+	if generation != c.generation {
+		return
+	}
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*cacheEntry).reply = reply
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, reply: reply})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// setVersion drops all entries if the version differs from the cached one
+// and reports whether it changed. The first version seen drops the entries
+// too, as they may be of the service before a restart, but is not counted
+// as a change.
+func (c *cache) setVersion(version string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if version == c.version {
+		return false
+	}
+	changed := c.version != ""
+	if changed {
+		c.invalidations.Add(1)
+	}
+	c.version = version
+	c.reset()
+	return changed
+}
+
+// invalidate drops all entries, keeping the version.
+func (c *cache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidations.Add(1)
+	c.reset()
+}
+
+func (c *cache) reset() {
+	c.generation++
+	c.order.Init()
+	clear(c.entries)
+}
+
+func (c *cache) stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Entries:       c.order.Len(),
+		Capacity:      c.capacity,
+		Invalidations: c.invalidations.Load(),
+	}
+}
@@ -0,0 +1,41 @@
+package wordsclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	wordspb "yadro.com/course/proto/words"
+)
+
+func TestCache_Eviction(t *testing.T) {
+	c := newCache(2)
+
+	_, generation, _ := c.get("a")
+	c.put(generation, "a", &wordspb.WordsReply{Words: []string{"a"}})
+	c.put(generation, "b", &wordspb.WordsReply{Words: []string{"b"}})
+	// a is used more recently than b now
+	_, _, ok := c.get("a")
+	assert.True(t, ok)
+	c.put(generation, "c", &wordspb.WordsReply{Words: []string{"c"}})
+
+	_, _, ok = c.get("b")
+	assert.False(t, ok)
+	reply, _, ok := c.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a"}, reply.Words)
+	assert.Equal(t, 2, c.stats().Entries)
+}
+
+func TestCache_StalePut(t *testing.T) {
+	c := newCache(2)
+	c.setVersion("v1")
+
+	_, generation, _ := c.get("a")
+	c.setVersion("v2")
+	// the reply was asked for under v1
+	c.put(generation, "a", &wordspb.WordsReply{})
+
+	_, _, ok := c.get("a")
+	assert.False(t, ok)
+	assert.Equal(t, uint64(1), c.stats().Invalidations)
+}
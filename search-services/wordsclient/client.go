@@ -0,0 +1,249 @@
+// Package wordsclient is the client of the words service shared by the
+// other services: it puts a deadline on every call, retries calls the
+// service is unavailable for and caches normalized phrases until the
+// service reports other dictionaries or tokenizer config.
+package wordsclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	wordspb "yadro.com/course/proto/words"
+)
+
+type Config struct {
+	// Timeout is the deadline of a single call, 0 is no deadline. A batch
+	// gets it for every batchPhrases phrases
+	Timeout time.Duration `yaml:"timeout" env:"WORDS_TIMEOUT" env-default:"2s"`
+	// Retries is the number of times a call is repeated while the service is
+	// unavailable, the pause between them starts at Backoff and doubles
+	Retries int           `yaml:"retries" env:"WORDS_RETRIES" env-default:"3"`
+	Backoff time.Duration `yaml:"backoff" env:"WORDS_BACKOFF" env-default:"100ms"`
+	// CacheSize is the number of normalized phrases kept, 0 disables the cache
+	CacheSize int `yaml:"cache_size" env:"WORDS_CACHE_SIZE" env-default:"10000"`
+	// VersionCheck is how often the version of the service is checked to
+	// drop the cache and tell the OnVersionChange callback, 0 never checks it
+	VersionCheck time.Duration `yaml:"version_check" env:"WORDS_VERSION_CHECK" env-default:"10s"`
+}
+
+// batchPhrases is the number of phrases of a batch normalized within the
+// timeout of a single call.
+const batchPhrases = 100
+
+// Client implements wordspb.WordsClient over another one, so the adapters
+// can use it in place of the generated client.
+type Client struct {
+	log     *slog.Logger
+	client  wordspb.WordsClient
+	cfg     Config
+	cache   *cache
+	retries atomic.Uint64
+	stop    context.CancelFunc
+	conn    *grpc.ClientConn
+	// changed is called when the dictionaries or the config of the service
+	// change
+	changed atomic.Pointer[func()]
+}
+
+var _ wordspb.WordsClient = (*Client)(nil)
+
+// Dial connects to the words service at the address and starts checking its
+// version.
+func Dial(log *slog.Logger, address string, cfg Config) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	c := New(log, wordspb.NewWordsClient(conn), cfg)
+	c.conn = conn
+
+	ctx, stop := context.WithCancel(context.Background())
+	c.stop = stop
+	if cfg.VersionCheck > 0 {
+		go c.Watch(ctx, cfg.VersionCheck)
+	}
+	return c, nil
+}
+
+func New(log *slog.Logger, client wordspb.WordsClient, cfg Config) *Client {
+	return &Client{
+		log:    log,
+		client: client,
+		cfg:    cfg,
+		cache:  newCache(cfg.CacheSize),
+	}
+}
+
+// Close stops checking the version and closes the connection of a dialed
+// Client.
+func (c *Client) Close() error {
+	if c.stop != nil {
+		c.stop()
+	}
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// OnVersionChange sets the function called after the words service reports
+// other dictionaries or tokenizer config than before and after the
+// dictionaries are set through the Client, so that results computed with
+// the old words can be dropped. The first version seen is not a change.
+func (c *Client) OnVersionChange(f func()) {
+	c.changed.Store(&f)
+}
+
+func (c *Client) notify() {
+	if f := c.changed.Load(); f != nil {
+		(*f)()
+	}
+}
+
+func (c *Client) Stats() Stats {
+	stats := c.cache.stats()
+	stats.Retries = c.retries.Load()
+	return stats
+}
+
+// Watch checks the version of the service every period until the context
+// is done and drops the cache when it changes.
+func (c *Client) Watch(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		c.checkVersion(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Client) checkVersion(ctx context.Context) {
+	v, err := c.Version(ctx, &emptypb.Empty{})
+	if err != nil {
+		c.log.Warn("failed to check words service version", "error", err)
+		return
+	}
+	if c.cache.setVersion(v.Hash + "/" + v.Config) {
+		c.log.Info("words service version changed", "hash", v.Hash)
+		c.notify()
+	}
+}
+
+func (c *Client) Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	// a health check is not retried, it would hide the failure
+	ctx, cancel := withTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+	return c.client.Ping(ctx, in, opts...)
+}
+
+// Norm returns the cached reply for the same request if there is one. Cached
+// replies are shared, callers must not change them.
+func (c *Client) Norm(ctx context.Context, in *wordspb.WordsRequest, opts ...grpc.CallOption) (*wordspb.WordsReply, error) {
+	key := fmt.Sprintf("%s\x00%s\x00%t\x00%s", in.GetLang(), in.GetMode(), in.GetBigrams(), in.GetPhrase())
+	reply, generation, ok := c.cache.get(key)
+	if ok {
+		return reply, nil
+	}
+	reply, err := call(ctx, c, "Norm", func(ctx context.Context) (*wordspb.WordsReply, error) {
+		return c.client.Norm(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.cache.put(generation, key, reply)
+	return reply, nil
+}
+
+func (c *Client) NormDetailed(ctx context.Context, in *wordspb.WordsRequest, opts ...grpc.CallOption) (*wordspb.DetailedReply, error) {
+	return call(ctx, c, "NormDetailed", func(ctx context.Context) (*wordspb.DetailedReply, error) {
+		return c.client.NormDetailed(ctx, in, opts...)
+	})
+}
+
+// NormBatch has a deadline growing with the number of phrases, a large batch
+// takes longer than a single phrase.
+func (c *Client) NormBatch(ctx context.Context, in *wordspb.NormBatchRequest, opts ...grpc.CallOption) (*wordspb.NormBatchReply, error) {
+	timeout := c.cfg.Timeout * time.Duration(max(1, (len(in.GetItems())+batchPhrases-1)/batchPhrases))
+	return callWithin(ctx, c, "NormBatch", timeout, func(ctx context.Context) (*wordspb.NormBatchReply, error) {
+		return c.client.NormBatch(ctx, in, opts...)
+	})
+}
+
+// NormStream has neither a deadline nor retries, a stream lives as long as
+// the caller needs it.
+func (c *Client) NormStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[wordspb.NormItem, wordspb.NormResult], error) {
+	return c.client.NormStream(ctx, opts...)
+}
+
+func (c *Client) Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wordspb.DictionaryVersion, error) {
+	return call(ctx, c, "Version", func(ctx context.Context) (*wordspb.DictionaryVersion, error) {
+		return c.client.Version(ctx, in, opts...)
+	})
+}
+
+func (c *Client) Dictionaries(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wordspb.DictionaryWords, error) {
+	return call(ctx, c, "Dictionaries", func(ctx context.Context) (*wordspb.DictionaryWords, error) {
+		return c.client.Dictionaries(ctx, in, opts...)
+	})
+}
+
+// SetDictionaries drops the cache at once rather than at the next version
+// check.
+func (c *Client) SetDictionaries(ctx context.Context, in *wordspb.DictionaryWords, opts ...grpc.CallOption) (*wordspb.DictionaryVersion, error) {
+	v, err := call(ctx, c, "SetDictionaries", func(ctx context.Context) (*wordspb.DictionaryVersion, error) {
+		return c.client.SetDictionaries(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.cache.invalidate()
+	c.notify()
+	return v, nil
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// call makes a call with the deadline of the config, repeating it with
+// backoff while the service is unavailable.
+func call[T any](ctx context.Context, c *Client, method string, f func(context.Context) (T, error)) (T, error) {
+	return callWithin(ctx, c, method, c.cfg.Timeout, f)
+}
+
+// callWithin is call with another deadline for every attempt.
+func callWithin[T any](ctx context.Context, c *Client, method string, timeout time.Duration, f func(context.Context) (T, error)) (T, error) {
+	backoff := c.cfg.Backoff
+	for attempt := 1; ; attempt++ {
+		callCtx, cancel := withTimeout(ctx, timeout)
+		res, err := f(callCtx)
+		cancel()
+		if err == nil || status.Code(err) != codes.Unavailable || attempt > c.cfg.Retries {
+			return res, err
+		}
+
+		c.retries.Add(1)
+		c.log.Warn("words service unavailable, retrying", "method", method, "attempt", attempt, "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return res, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
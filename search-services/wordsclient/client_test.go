@@ -0,0 +1,191 @@
+package wordsclient
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	wordspb "yadro.com/course/proto/words"
+)
+
+// fakeWords answers Norm with the phrase itself after failing the first
+// calls with the given error.
+type fakeWords struct {
+	wordspb.WordsClient
+
+	mu       sync.Mutex
+	calls    int
+	failures int
+	err      error
+	hash     string
+	deadline bool
+	// left is the time the last batch had until its deadline
+	left time.Duration
+}
+
+func (f *fakeWords) Norm(ctx context.Context, in *wordspb.WordsRequest, _ ...grpc.CallOption) (*wordspb.WordsReply, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	_, f.deadline = ctx.Deadline()
+	if f.calls <= f.failures {
+		return nil, f.err
+	}
+	return &wordspb.WordsReply{Words: []string{in.Phrase}, Lang: in.Lang}, nil
+}
+
+func (f *fakeWords) NormBatch(ctx context.Context, in *wordspb.NormBatchRequest, _ ...grpc.CallOption) (*wordspb.NormBatchReply, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	deadline, _ := ctx.Deadline()
+	f.left = time.Until(deadline)
+	return &wordspb.NormBatchReply{}, nil
+}
+
+func (f *fakeWords) Version(context.Context, *emptypb.Empty, ...grpc.CallOption) (*wordspb.DictionaryVersion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &wordspb.DictionaryVersion{Hash: f.hash, Config: "config"}, nil
+}
+
+func (f *fakeWords) SetDictionaries(context.Context, *wordspb.DictionaryWords, ...grpc.CallOption) (*wordspb.DictionaryVersion, error) {
+	return &wordspb.DictionaryVersion{}, nil
+}
+
+func TestClient_NormCache(t *testing.T) {
+	fake := &fakeWords{hash: "a"}
+	c := New(slog.Default(), fake, Config{CacheSize: 10})
+	ctx := context.Background()
+	changes := 0
+	c.OnVersionChange(func() { changes++ })
+
+	for range 3 {
+		reply, err := c.Norm(ctx, &wordspb.WordsRequest{Phrase: "robots"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"robots"}, reply.Words)
+	}
+	// another language or bigrams are another request
+	_, err := c.Norm(ctx, &wordspb.WordsRequest{Phrase: "robots", Lang: "en"})
+	require.NoError(t, err)
+	_, err = c.Norm(ctx, &wordspb.WordsRequest{Phrase: "robots", Bigrams: true})
+	require.NoError(t, err)
+	assert.Equal(t, 3, fake.calls)
+	assert.Equal(t, Stats{Hits: 2, Misses: 3, Entries: 3, Capacity: 10}, c.Stats())
+
+	// the first version only drops what may be left from before a restart
+	c.checkVersion(ctx)
+	c.checkVersion(ctx)
+	assert.Equal(t, uint64(0), c.Stats().Invalidations)
+	assert.Equal(t, 0, changes)
+
+	_, err = c.Norm(ctx, &wordspb.WordsRequest{Phrase: "robots"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, fake.calls)
+
+	fake.hash = "b"
+	c.checkVersion(ctx)
+	_, err = c.Norm(ctx, &wordspb.WordsRequest{Phrase: "robots"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, fake.calls)
+	assert.Equal(t, 1, changes)
+
+	_, err = c.SetDictionaries(ctx, &wordspb.DictionaryWords{})
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Hits: 2, Misses: 5, Entries: 0, Capacity: 10, Invalidations: 2}, c.Stats())
+	assert.Equal(t, 2, changes)
+}
+
+func TestClient_NormNoCache(t *testing.T) {
+	fake := &fakeWords{}
+	c := New(slog.Default(), fake, Config{})
+
+	for range 2 {
+		_, err := c.Norm(context.Background(), &wordspb.WordsRequest{Phrase: "robots"})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, fake.calls)
+	assert.Equal(t, Stats{}, c.Stats())
+}
+
+func TestClient_NormBatchTimeout(t *testing.T) {
+	fake := &fakeWords{}
+	c := New(slog.Default(), fake, Config{Timeout: time.Second})
+	batch := func(n int) time.Duration {
+		_, err := c.NormBatch(context.Background(), &wordspb.NormBatchRequest{Items: make([]*wordspb.NormItem, n)})
+		require.NoError(t, err)
+		return fake.left
+	}
+
+	assert.LessOrEqual(t, batch(1), time.Second)
+	assert.Greater(t, batch(250), 2*time.Second)
+	assert.LessOrEqual(t, batch(250), 3*time.Second)
+}
+
+func TestClient_Retry(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int
+		err           error
+		expectedCode  codes.Code
+		expectedCalls int
+	}{
+		{
+			name:          "recovers",
+			failures:      2,
+			err:           status.Error(codes.Unavailable, "down"),
+			expectedCode:  codes.OK,
+			expectedCalls: 3,
+		},
+		{
+			name:          "gives up",
+			failures:      10,
+			err:           status.Error(codes.Unavailable, "down"),
+			expectedCode:  codes.Unavailable,
+			expectedCalls: 3,
+		},
+		{
+			name:          "other errors are not retried",
+			failures:      1,
+			err:           status.Error(codes.InvalidArgument, "bad"),
+			expectedCode:  codes.InvalidArgument,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeWords{failures: tt.failures, err: tt.err}
+			c := New(slog.Default(), fake, Config{Timeout: time.Second, Retries: 2, Backoff: time.Millisecond})
+
+			_, err := c.Norm(context.Background(), &wordspb.WordsRequest{Phrase: "robots"})
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedCalls, fake.calls)
+			assert.Equal(t, uint64(min(tt.expectedCalls-1, 2)), c.Stats().Retries)
+			assert.True(t, fake.deadline)
+		})
+	}
+}
+
+func TestClient_RetryCanceled(t *testing.T) {
+	fake := &fakeWords{failures: 10, err: status.Error(codes.Unavailable, "down")}
+	c := New(slog.Default(), fake, Config{Retries: 5, Backoff: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err := c.Norm(ctx, &wordspb.WordsRequest{Phrase: "robots"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, fake.calls)
+}